@@ -0,0 +1,304 @@
+// Package filesystem provides adapters that persist data on the local filesystem.
+package filesystem
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// metaDir is the per-task directory holding artifact metadata. Artifact names cannot
+// start with a dot, so it never collides with an artifact.
+const metaDir = ".meta"
+
+// ArtifactStore is a local-filesystem implementation of the ports.ArtifactStore interface.
+// Artifacts are stored as <root>/<taskID>/<name>, their metadata as <root>/<taskID>/.meta/<name>.json.
+// Content is written to a temporary file first and renamed into place, so readers never see
+// partial uploads and a failed upload leaves the previous artifact intact.
+type ArtifactStore struct {
+	root string
+	mu   sync.RWMutex // Keeps content and metadata of an artifact consistent across replacements
+}
+
+// NewArtifactStore creates an artifact store rooted at the given directory.
+// Purpose: Factory function for the disk-backed artifact storage adapter.
+// Inputs:
+//   - root: Directory holding the artifacts; created if it does not exist
+// Outputs:
+//   - ports.ArtifactStore: Initialized store ready for use
+//   - error: Returns error if root is empty or cannot be created
+func NewArtifactStore(root string) (ports.ArtifactStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("artifact root directory cannot be empty")
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+
+	return &ArtifactStore{root: root}, nil
+}
+
+// PutArtifact streams content to disk, replacing any artifact with the same name.
+// Purpose: Persists an artifact while computing its size and SHA-256 checksum.
+// Inputs:
+//   - ctx: Context for cancellation; a canceled upload is discarded
+//   - artifact: Metadata of the artifact (must have a TaskID and a valid Name)
+//   - content: The artifact content, read until EOF
+// Outputs:
+//   - *domain.Artifact: Copy of the stored metadata including Size and Checksum
+//   - error: Returns error if the name is invalid, reading content fails or the disk write fails
+func (s *ArtifactStore) PutArtifact(ctx context.Context, artifact *domain.Artifact, content io.Reader) (*domain.Artifact, error) {
+	if artifact == nil {
+		return nil, fmt.Errorf("artifact cannot be nil")
+	}
+	taskDir, err := s.taskDir(artifact.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateArtifactName(artifact.Name); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Join(taskDir, metaDir), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create task artifact directory: %w", err)
+	}
+
+	// Write to a temporary file first so the previous content stays intact until the rename
+	tmp, err := os.CreateTemp(taskDir, ".upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create artifact file: %w", err)
+	}
+	tmpName := tmp.Name()
+	committed := false
+	defer func() {
+		if !committed {
+			os.Remove(tmpName)
+		}
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write artifact: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("artifact upload interrupted: %w", err)
+	}
+
+	stored := *artifact
+	stored.Size = size
+	stored.Checksum = "sha256:" + hex.EncodeToString(hash.Sum(nil))
+
+	meta, err := json.Marshal(&stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode artifact metadata: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Rename(tmpName, filepath.Join(taskDir, stored.Name)); err != nil {
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+	committed = true
+
+	if err := writeFileAtomic(s.metaPath(taskDir, stored.Name), meta); err != nil {
+		return nil, fmt.Errorf("failed to store artifact metadata: %w", err)
+	}
+
+	return &stored, nil
+}
+
+// GetArtifact opens an artifact's content for streaming.
+// Purpose: Serves downloads with thread-safe access to content and metadata.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - taskID: Unique identifier of the task
+//   - name: Name of the artifact
+// Outputs:
+//   - *domain.Artifact: Metadata of the artifact
+//   - io.ReadCloser: The open content file; the caller must close it
+//   - error: Returns error wrapping domain.ErrArtifactNotFound if the artifact does not exist
+func (s *ArtifactStore) GetArtifact(ctx context.Context, taskID string, name string) (*domain.Artifact, io.ReadCloser, error) {
+	taskDir, err := s.taskDir(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := domain.ValidateArtifactName(name); err != nil {
+		return nil, nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	artifact, err := readMeta(s.metaPath(taskDir, name))
+	if err != nil {
+		return nil, nil, notFound(err, taskID, name)
+	}
+
+	file, err := os.Open(filepath.Join(taskDir, name))
+	if err != nil {
+		return nil, nil, notFound(err, taskID, name)
+	}
+
+	return artifact, file, nil
+}
+
+// ListArtifacts reads the metadata of all artifacts of a task.
+// Purpose: Lists artifacts without opening their content.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - taskID: Unique identifier of the task
+// Outputs:
+//   - []*domain.Artifact: Artifacts sorted by name (empty if the task has none)
+//   - error: Returns error if the metadata cannot be read
+func (s *ArtifactStore) ListArtifacts(ctx context.Context, taskID string) ([]*domain.Artifact, error) {
+	taskDir, err := s.taskDir(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := os.ReadDir(filepath.Join(taskDir, metaDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return []*domain.Artifact{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	artifacts := make([]*domain.Artifact, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		artifact, err := readMeta(filepath.Join(taskDir, metaDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read artifact metadata: %w", err)
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Name < artifacts[j].Name
+	})
+
+	return artifacts, nil
+}
+
+// DeleteArtifact removes an artifact's metadata and content.
+// Purpose: Deletes an artifact with thread-safe access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - taskID: Unique identifier of the task
+//   - name: Name of the artifact
+// Outputs:
+//   - error: Returns error wrapping domain.ErrArtifactNotFound if the artifact does not exist
+func (s *ArtifactStore) DeleteArtifact(ctx context.Context, taskID string, name string) error {
+	taskDir, err := s.taskDir(taskID)
+	if err != nil {
+		return err
+	}
+	if err := domain.ValidateArtifactName(name); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Metadata goes first: an artifact without metadata does not exist for readers
+	if err := os.Remove(s.metaPath(taskDir, name)); err != nil {
+		return notFound(err, taskID, name)
+	}
+	if err := os.Remove(filepath.Join(taskDir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete artifact content: %w", err)
+	}
+
+	return nil
+}
+
+// taskDir returns the directory of a task's artifacts, rejecting IDs that would escape the root.
+func (s *ArtifactStore) taskDir(taskID string) (string, error) {
+	if err := checkSegment("taskID", taskID); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, taskID), nil
+}
+
+// metaPath returns the metadata file of an artifact.
+func (s *ArtifactStore) metaPath(taskDir string, name string) string {
+	return filepath.Join(taskDir, metaDir, name+".json")
+}
+
+// readMeta decodes an artifact metadata file.
+func readMeta(path string) (*domain.Artifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var artifact domain.Artifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("corrupt artifact metadata %s: %w", filepath.Base(path), err)
+	}
+	return &artifact, nil
+}
+
+// writeFileAtomic replaces a file's content via a temporary file and rename.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// checkSegment rejects identifiers that cannot be used as a single path element below the root.
+func checkSegment(kind string, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s cannot be empty", kind)
+	}
+	if value == "." || value == ".." || strings.ContainsAny(value, `/\:`) || strings.ContainsRune(value, 0) {
+		return fmt.Errorf("invalid %s: %q", kind, value)
+	}
+	return nil
+}
+
+// notFound maps a missing file to domain.ErrArtifactNotFound and wraps other errors.
+func notFound(err error, taskID string, name string) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: task %s has no artifact %q", domain.ErrArtifactNotFound, taskID, name)
+	}
+	return fmt.Errorf("failed to read artifact: %w", err)
+}
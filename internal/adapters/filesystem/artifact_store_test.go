@@ -0,0 +1,236 @@
+package filesystem
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// newTestStore creates a store below a fresh directory and returns the directory as well,
+// so tests can check that nothing is written next to the root.
+func newTestStore(t *testing.T) (ports.ArtifactStore, string, string) {
+	t.Helper()
+	parent := t.TempDir()
+	root := filepath.Join(parent, "artifacts")
+	store, err := NewArtifactStore(root)
+	if err != nil {
+		t.Fatalf("NewArtifactStore = %v", err)
+	}
+	return store, root, parent
+}
+
+// put stores content as an artifact of a task.
+func put(t *testing.T, store ports.ArtifactStore, taskID string, name string, content string) *domain.Artifact {
+	t.Helper()
+	artifact, err := store.PutArtifact(context.Background(), &domain.Artifact{TaskID: taskID, Name: name, ContentType: "text/plain"}, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("PutArtifact(%s, %s) = %v", taskID, name, err)
+	}
+	return artifact
+}
+
+// read returns the stored content of an artifact.
+func read(t *testing.T, store ports.ArtifactStore, taskID string, name string) string {
+	t.Helper()
+	_, file, err := store.GetArtifact(context.Background(), taskID, name)
+	if err != nil {
+		t.Fatalf("GetArtifact(%s, %s) = %v", taskID, name, err)
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("read artifact = %v", err)
+	}
+	return string(content)
+}
+
+// failingReader returns some content and then an error, like an aborted upload.
+type failingReader struct {
+	content string
+	read    bool
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.read {
+		return 0, errors.New("connection reset")
+	}
+	r.read = true
+	return copy(p, r.content), nil
+}
+
+func TestCheckSegment(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "task ID", value: "task-42"},
+		{name: "dots inside", value: "a..b"},
+		{name: "empty", value: "", wantErr: true},
+		{name: "current directory", value: ".", wantErr: true},
+		{name: "parent directory", value: "..", wantErr: true},
+		{name: "parent traversal", value: "../other", wantErr: true},
+		{name: "absolute path", value: "/etc", wantErr: true},
+		{name: "windows separator", value: `..\other`, wantErr: true},
+		{name: "windows drive", value: "C:", wantErr: true},
+		{name: "NUL byte", value: "task\x00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkSegment("taskID", tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("checkSegment(%q) = %v, want error %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArtifactStoreRejectsPathsOutsideRoot(t *testing.T) {
+	store, root, parent := newTestStore(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		taskID string
+		file   string
+	}{
+		{name: "task ID traversal", taskID: "..", file: "escape.txt"},
+		{name: "task ID path", taskID: "../outside", file: "escape.txt"},
+		{name: "absolute task ID", taskID: parent, file: "escape.txt"},
+		{name: "name traversal", taskID: "task", file: "../escape.txt"},
+		{name: "absolute name", taskID: "task", file: filepath.Join(parent, "escape.txt")},
+		{name: "name into metadata", taskID: "task", file: ".meta"},
+		{name: "NUL in name", taskID: "task", file: "escape.txt\x00.pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifact := &domain.Artifact{TaskID: tt.taskID, Name: tt.file}
+			if _, err := store.PutArtifact(ctx, artifact, strings.NewReader("data")); err == nil {
+				t.Error("PutArtifact succeeded")
+			}
+			if _, _, err := store.GetArtifact(ctx, tt.taskID, tt.file); err == nil || errors.Is(err, domain.ErrArtifactNotFound) {
+				t.Errorf("GetArtifact = %v, want the path rejected", err)
+			}
+			if err := store.DeleteArtifact(ctx, tt.taskID, tt.file); err == nil || errors.Is(err, domain.ErrArtifactNotFound) {
+				t.Errorf("DeleteArtifact = %v, want the path rejected", err)
+			}
+		})
+	}
+
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		t.Fatalf("ReadDir = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != filepath.Base(root) {
+		t.Errorf("directory around the root holds %v, want only the root", entries)
+	}
+	if entries, err := os.ReadDir(root); err != nil || len(entries) != 0 {
+		t.Errorf("root holds (%v, %v), want nothing stored", entries, err)
+	}
+}
+
+func TestArtifactStoreOverwritesAtomically(t *testing.T) {
+	store, root, _ := newTestStore(t)
+	ctx := context.Background()
+
+	first := put(t, store, "task", "report.txt", "first draft")
+	sum := sha256.Sum256([]byte("first draft"))
+	if first.Size != int64(len("first draft")) || first.Checksum != "sha256:"+hex.EncodeToString(sum[:]) {
+		t.Errorf("stored (%d, %s), want the size and checksum of the content", first.Size, first.Checksum)
+	}
+
+	second := put(t, store, "task", "report.txt", "final")
+	if got := read(t, store, "task", "report.txt"); got != "final" {
+		t.Errorf("content = %q, want the replacement", got)
+	}
+	if second.Checksum == first.Checksum || second.Size != int64(len("final")) {
+		t.Errorf("replacement metadata = %+v, want the new size and checksum", second)
+	}
+
+	// Failed and canceled uploads leave the previous artifact intact
+	if _, err := store.PutArtifact(ctx, &domain.Artifact{TaskID: "task", Name: "report.txt"}, &failingReader{content: "broken"}); err == nil {
+		t.Error("PutArtifact with a failing reader succeeded")
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := store.PutArtifact(canceled, &domain.Artifact{TaskID: "task", Name: "report.txt"}, strings.NewReader("canceled")); !errors.Is(err, context.Canceled) {
+		t.Errorf("PutArtifact with a canceled context = %v, want context.Canceled", err)
+	}
+	meta, file, err := store.GetArtifact(ctx, "task", "report.txt")
+	if err != nil {
+		t.Fatalf("GetArtifact = %v", err)
+	}
+	file.Close()
+	if got := read(t, store, "task", "report.txt"); got != "final" || meta.Checksum != second.Checksum {
+		t.Errorf("after failed uploads = (%q, %s), want the last stored artifact", got, meta.Checksum)
+	}
+
+	// No temporary files are left behind
+	for _, dir := range []string{filepath.Join(root, "task"), filepath.Join(root, "task", metaDir)} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("ReadDir = %v", err)
+		}
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), ".") && entry.Name() != metaDir {
+				t.Errorf("temporary file %s left in %s", entry.Name(), dir)
+			}
+		}
+	}
+}
+
+func TestArtifactStoreListsAndDeletes(t *testing.T) {
+	store, _, _ := newTestStore(t)
+	ctx := context.Background()
+
+	if artifacts, err := store.ListArtifacts(ctx, "task"); err != nil || len(artifacts) != 0 {
+		t.Fatalf("ListArtifacts of a task without artifacts = (%v, %v), want empty", artifacts, err)
+	}
+
+	put(t, store, "task", "b.csv", "b")
+	put(t, store, "task", "a.pdf", "a")
+	put(t, store, "task", "c.txt", "c")
+	put(t, store, "other", "z.txt", "z")
+
+	names := func() []string {
+		t.Helper()
+		artifacts, err := store.ListArtifacts(ctx, "task")
+		if err != nil {
+			t.Fatalf("ListArtifacts = %v", err)
+		}
+		names := make([]string, 0, len(artifacts))
+		for _, artifact := range artifacts {
+			names = append(names, artifact.Name)
+		}
+		return names
+	}
+	if got := strings.Join(names(), ","); got != "a.pdf,b.csv,c.txt" {
+		t.Errorf("ListArtifacts = %s, want the task's artifacts sorted by name", got)
+	}
+
+	if err := store.DeleteArtifact(ctx, "task", "b.csv"); err != nil {
+		t.Fatalf("DeleteArtifact = %v", err)
+	}
+	if got := strings.Join(names(), ","); got != "a.pdf,c.txt" {
+		t.Errorf("ListArtifacts after delete = %s, want a.pdf,c.txt", got)
+	}
+	if _, _, err := store.GetArtifact(ctx, "task", "b.csv"); !errors.Is(err, domain.ErrArtifactNotFound) {
+		t.Errorf("GetArtifact of a deleted artifact = %v, want ErrArtifactNotFound", err)
+	}
+	if err := store.DeleteArtifact(ctx, "task", "b.csv"); !errors.Is(err, domain.ErrArtifactNotFound) {
+		t.Errorf("second DeleteArtifact = %v, want ErrArtifactNotFound", err)
+	}
+	if got := read(t, store, "other", "z.txt"); got != "z" {
+		t.Errorf("artifact of another task = %q, want it untouched", got)
+	}
+}
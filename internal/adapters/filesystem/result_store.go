@@ -0,0 +1,107 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// ResultStore is a local-filesystem implementation of the ports.ResultStore interface.
+// Outputs are stored as <root>/<taskID>/<planID>/<stepID>.out; the reference is the
+// slash-separated "<taskID>/<planID>/<stepID>".
+type ResultStore struct {
+	root string
+}
+
+// NewResultStore creates a result store rooted at the given directory.
+// Purpose: Factory function for the disk-backed storage of large step outputs.
+// Inputs:
+//   - root: Directory holding the outputs; created if it does not exist
+// Outputs:
+//   - ports.ResultStore: Initialized store ready for use
+//   - error: Returns error if root is empty or cannot be created
+func NewResultStore(root string) (ports.ResultStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("result root directory cannot be empty")
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create result directory: %w", err)
+	}
+
+	return &ResultStore{root: root}, nil
+}
+
+// PutResult writes a step output to disk, replacing any output stored for the step.
+// Purpose: Persists large outputs atomically, so readers never see a partial output.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - taskID: Unique identifier of the task
+//   - planID: Unique identifier of the plan owning the step
+//   - stepID: Unique identifier of the step
+//   - output: The full step output
+// Outputs:
+//   - string: Reference of the stored output
+//   - error: Returns error if an identifier is not a plain path element or the disk write fails
+func (s *ResultStore) PutResult(ctx context.Context, taskID string, planID string, stepID string, output string) (string, error) {
+	ref := strings.Join([]string{taskID, planID, stepID}, "/")
+	path, err := s.path(ref)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create result directory: %w", err)
+	}
+	if err := writeFileAtomic(path, []byte(output)); err != nil {
+		return "", fmt.Errorf("failed to store result: %w", err)
+	}
+
+	return ref, nil
+}
+
+// OpenResult opens a stored output.
+// Purpose: Streams outputs with seeking for range requests.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - ref: Reference returned by PutResult
+// Outputs:
+//   - io.ReadSeekCloser: The open output file; the caller must close it
+//   - error: Returns error if the reference is malformed or no output is stored under it
+func (s *ResultStore) OpenResult(ctx context.Context, ref string) (io.ReadSeekCloser, error) {
+	path, err := s.path(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("result not found: %s", ref)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open result: %w", err)
+	}
+
+	return file, nil
+}
+
+// path maps a reference to its file, rejecting references that would escape the root.
+func (s *ResultStore) path(ref string) (string, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid result reference: %q", ref)
+	}
+	for i, kind := range []string{"taskID", "planID", "stepID"} {
+		if err := checkSegment(kind, parts[i]); err != nil {
+			return "", err
+		}
+	}
+
+	return filepath.Join(s.root, parts[0], parts[1], parts[2]+".out"), nil
+}
@@ -0,0 +1,113 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// Normalizer is an LLM-backed implementation of the ports.IntentNormalizer interface.
+// It asks the model to restate the request and extract its entities as JSON.
+type Normalizer struct {
+	provider ports.LLMProvider
+}
+
+// NewNormalizer creates a new LLM-backed intent normalizer.
+// Purpose: Factory function for creating the LLM normalizer adapter.
+// Inputs:
+//   - provider: LLM used to interpret task inputs
+// Outputs:
+//   - ports.IntentNormalizer: Initialized normalizer ready for use
+func NewNormalizer(provider ports.LLMProvider) ports.IntentNormalizer {
+	return &Normalizer{provider: provider}
+}
+
+// Normalize asks the LLM to clean up the task input and extract its entities.
+// Purpose: Handles free-form phrasing, other languages and relative dates that rules miss.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task to normalize (its input and creation date are included in the prompt)
+// Outputs:
+//   - *domain.Intent: The model's intent; entities of unknown types are dropped
+//   - error: Returns error if the LLM call fails or its response is not a valid intent
+func (n *Normalizer) Normalize(ctx context.Context, task *domain.Task) (*domain.Intent, error) {
+	response, err := generate(ctx, n.provider, buildNormalizationPrompt(task))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate intent: %w", err)
+	}
+
+	intent, err := parseIntent(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse intent: %w", err)
+	}
+
+	return intent, nil
+}
+
+// buildNormalizationPrompt describes the request and the expected JSON answer to the model.
+func buildNormalizationPrompt(task *domain.Task) string {
+	var b strings.Builder
+	b.WriteString("You turn user requests for an AI agent into a structured intent.\n\n")
+	fmt.Fprintf(&b, "User request:\n%s\n\n", task.Input)
+	fmt.Fprintf(&b, "The request was made on %s; resolve relative dates against it.\n\n", task.CreatedAt.Format("Monday, 2006-01-02"))
+	b.WriteString("Rewrite the request as one concise imperative sentence in its original language, ")
+	b.WriteString("detect the language (ISO 639-1 code) and extract its parameters:\n")
+	b.WriteString("- DATE: value as YYYY-MM-DD\n")
+	b.WriteString("- AMOUNT: value as \"<number> <ISO 4217 currency>\", e.g. \"800 EUR\"\n")
+	b.WriteString("- LOCATION: value as the place name\n")
+	b.WriteString("\"text\" is the part of the request each entity was read from. ")
+	b.WriteString("\"confidence\" (0-1) is how sure you are the intent captures the request.\n\n")
+	b.WriteString("Respond with JSON only:\n")
+	b.WriteString(`{"intent": "<sentence>", "language": "<code>", "entities": [{"type": "DATE|AMOUNT|LOCATION", "value": "<value>", "text": "<span>"}], "confidence": <number>}`)
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// parseIntent extracts the JSON intent from a model response.
+// Surrounding prose and Markdown code fences are ignored.
+func parseIntent(response string) (*domain.Intent, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("response contains no JSON object")
+	}
+
+	var answer struct {
+		Intent     string          `json:"intent"`
+		Language   string          `json:"language"`
+		Entities   []domain.Entity `json:"entities"`
+		Confidence *float64        `json:"confidence"`
+	}
+	if err := json.Unmarshal([]byte(response[start:end+1]), &answer); err != nil {
+		return nil, fmt.Errorf("invalid intent JSON: %w", err)
+	}
+	if strings.TrimSpace(answer.Intent) == "" {
+		return nil, fmt.Errorf("intent is missing the intent field")
+	}
+	if answer.Confidence == nil {
+		return nil, fmt.Errorf("intent is missing the confidence field")
+	}
+
+	entities := make([]domain.Entity, 0, len(answer.Entities))
+	for _, entity := range answer.Entities {
+		entity.Type = domain.EntityType(strings.ToUpper(string(entity.Type)))
+		switch entity.Type {
+		case domain.EntityTypeDate, domain.EntityTypeAmount, domain.EntityTypeLocation:
+			if entity.Value != "" {
+				entities = append(entities, entity)
+			}
+		}
+	}
+
+	return &domain.Intent{
+		Text:       strings.TrimSpace(answer.Intent),
+		Language:   strings.ToLower(strings.TrimSpace(answer.Language)),
+		Entities:   entities,
+		Confidence: min(max(*answer.Confidence, 0), 1),
+	}, nil
+}
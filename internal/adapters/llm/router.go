@@ -0,0 +1,96 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// Router is an LLM-backed implementation of the ports.AgentRouter interface.
+// It describes the candidate agents to the model and parses the JSON choice it returns.
+type Router struct {
+	provider ports.LLMProvider
+}
+
+// NewRouter creates a new LLM-backed agent router.
+// Purpose: Factory function for creating the LLM router adapter.
+// Inputs:
+//   - provider: LLM used to classify task inputs
+// Outputs:
+//   - ports.AgentRouter: Initialized router ready for use
+func NewRouter(provider ports.LLMProvider) ports.AgentRouter {
+	return &Router{provider: provider}
+}
+
+// Route asks the LLM which agent should handle the task.
+// Purpose: Classifies free-form requests that keyword rules cannot route reliably.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task to route (its input is included in the prompt)
+//   - agents: Candidate agents described by name and description
+// Outputs:
+//   - *domain.AgentRoute: The chosen agent, or an empty Agent if the model picked none
+//   - error: Returns error if the LLM call fails or names an agent that is not a candidate
+func (r *Router) Route(ctx context.Context, task *domain.Task, agents []domain.AgentProfile) (*domain.AgentRoute, error) {
+	response, err := generate(ctx, r.provider, buildRoutingPrompt(task, agents))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate routing decision: %w", err)
+	}
+
+	route, err := parseRoute(response, agents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse routing decision: %w", err)
+	}
+
+	return route, nil
+}
+
+// buildRoutingPrompt describes the request and the candidate agents to the model.
+func buildRoutingPrompt(task *domain.Task, agents []domain.AgentProfile) string {
+	var b strings.Builder
+	b.WriteString("You route user requests to the AI agent best suited to handle them.\n\n")
+	fmt.Fprintf(&b, "User request:\n%s\n\n", task.Input)
+	b.WriteString("Agents:\n")
+	for _, agent := range agents {
+		fmt.Fprintf(&b, "- %s: %s\n", agent.Name, agent.Description)
+	}
+	b.WriteString("\nPick one agent, or \"none\" if no agent fits. Respond with JSON only:\n")
+	b.WriteString(`{"agent": "<agent name or none>", "reason": "<one sentence>"}`)
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// parseRoute extracts the JSON routing decision from a model response.
+// Surrounding prose and Markdown code fences are ignored.
+func parseRoute(response string, agents []domain.AgentProfile) (*domain.AgentRoute, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("response contains no JSON object")
+	}
+
+	var decision struct {
+		Agent  string `json:"agent"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(response[start:end+1]), &decision); err != nil {
+		return nil, fmt.Errorf("invalid routing JSON: %w", err)
+	}
+
+	name := domain.NormalizeAgentName(decision.Agent)
+	if name == "" || name == "NONE" {
+		return &domain.AgentRoute{Reason: decision.Reason}, nil
+	}
+	for _, agent := range agents {
+		if domain.NormalizeAgentName(agent.Name) == name {
+			return &domain.AgentRoute{Agent: agent.Name, Reason: decision.Reason}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: model chose %q", domain.ErrUnknownAgent, decision.Agent)
+}
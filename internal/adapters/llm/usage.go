@@ -0,0 +1,30 @@
+package llm
+
+import (
+	"context"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/execctx"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// generate sends a prompt to the provider and reports the call's token usage.
+// Every adapter in this package calls the LLM through it so no call goes unaccounted.
+// Providers that can stream have the generated text reported live as well.
+func generate(ctx context.Context, provider ports.LLMProvider, prompt string) (string, error) {
+	var response *domain.LLMResponse
+	var err error
+	if streaming, ok := provider.(ports.StreamingLLMProvider); ok {
+		response, err = streaming.StreamText(ctx, prompt, func(delta string) {
+			execctx.RecordLLMDelta(ctx, delta)
+		})
+	} else {
+		response, err = provider.GenerateText(ctx, prompt)
+	}
+	if err != nil {
+		return "", err
+	}
+	execctx.RecordLLMUsage(ctx, response.Usage)
+
+	return response.Text, nil
+}
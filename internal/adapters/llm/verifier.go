@@ -0,0 +1,125 @@
+// Package llm provides adapters that implement core ports on top of ports.LLMProvider.
+// They contain prompt construction and response parsing only; the provider itself
+// (OpenAI, Anthropic, ...) is injected.
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// Verifier is an LLM-backed implementation of the ports.Verifier interface.
+// It asks the model whether the step results achieve the plan goal and parses a JSON verdict.
+type Verifier struct {
+	provider       ports.LLMProvider
+	maxOutputChars int
+}
+
+// NewVerifier creates a new LLM-backed verifier.
+// Purpose: Factory function for creating the LLM verifier adapter.
+// Inputs:
+//   - provider: LLM used to judge the results
+//   - maxOutputChars: Maximum characters of each step output included in the prompt
+// Outputs:
+//   - ports.Verifier: Initialized verifier ready for use
+func NewVerifier(provider ports.LLMProvider, maxOutputChars int) ports.Verifier {
+	return &Verifier{provider: provider, maxOutputChars: maxOutputChars}
+}
+
+// Verify asks the LLM to judge the accumulated step results against Plan.Goal.
+// Purpose: Detects results that are well-formed but do not answer what the user asked for.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task being verified (its input is included in the prompt)
+//   - plan: The plan whose goal and steps are described to the model
+//   - results: Results of the steps that have run so far
+// Outputs:
+//   - *domain.Verification: The model's verdict
+//   - error: Returns error if the LLM call fails or its response is not a valid verdict
+func (v *Verifier) Verify(ctx context.Context, task *domain.Task, plan *domain.Plan, results []*domain.StepResult) (*domain.Verification, error) {
+	response, err := generate(ctx, v.provider, v.buildPrompt(task, plan, results))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate verification: %w", err)
+	}
+
+	verification, err := parseVerification(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse verification: %w", err)
+	}
+
+	return verification, nil
+}
+
+// buildPrompt describes the goal, the plan steps and their results to the model.
+func (v *Verifier) buildPrompt(task *domain.Task, plan *domain.Plan, results []*domain.StepResult) string {
+	byStep := make(map[string]*domain.StepResult, len(results))
+	for _, result := range results {
+		byStep[result.StepID] = result
+	}
+
+	var b strings.Builder
+	b.WriteString("You verify whether an AI agent achieved the user's goal.\n\n")
+	fmt.Fprintf(&b, "User request:\n%s\n\n", task.Input)
+	fmt.Fprintf(&b, "Goal:\n%s\n\n", plan.Goal)
+	b.WriteString("Steps and results:\n")
+	for i := range plan.Steps {
+		step := &plan.Steps[i]
+		fmt.Fprintf(&b, "- [%s] %s (%s)\n", step.Status, step.Title, step.Type)
+		result, exists := byStep[step.ID]
+		if !exists {
+			continue
+		}
+		if !result.Success {
+			fmt.Fprintf(&b, "  error: %s\n", result.ErrorMessage)
+			continue
+		}
+		fmt.Fprintf(&b, "  output: %s\n", v.truncate(result.Output))
+	}
+	b.WriteString("\nDecide whether the results achieve the goal. Respond with JSON only:\n")
+	b.WriteString(`{"passed": true|false, "reason": "<one sentence>", "issues": ["<what is missing or wrong>"]}`)
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// truncate shortens a step output to maxOutputChars characters.
+func (v *Verifier) truncate(output string) string {
+	runes := []rune(output)
+	if v.maxOutputChars <= 0 || len(runes) <= v.maxOutputChars {
+		return output
+	}
+	return string(runes[:v.maxOutputChars]) + "…"
+}
+
+// parseVerification extracts the JSON verdict from a model response.
+// Surrounding prose and Markdown code fences are ignored.
+func parseVerification(response string) (*domain.Verification, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("response contains no JSON object")
+	}
+
+	var verdict struct {
+		Passed *bool    `json:"passed"`
+		Reason string   `json:"reason"`
+		Issues []string `json:"issues"`
+	}
+	if err := json.Unmarshal([]byte(response[start:end+1]), &verdict); err != nil {
+		return nil, fmt.Errorf("invalid verdict JSON: %w", err)
+	}
+	if verdict.Passed == nil {
+		return nil, fmt.Errorf("verdict is missing the passed field")
+	}
+
+	return &domain.Verification{
+		Passed: *verdict.Passed,
+		Reason: verdict.Reason,
+		Issues: verdict.Issues,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// registeredAgent is the ports.Agent stored by the registry.
+type registeredAgent struct {
+	profile  domain.AgentProfile
+	planner  ports.Planner
+	executor ports.Executor
+}
+
+// Profile implements ports.Agent.
+func (a *registeredAgent) Profile() domain.AgentProfile { return a.profile }
+
+// Planner implements ports.Agent.
+func (a *registeredAgent) Planner() ports.Planner { return a.planner }
+
+// Executor implements ports.Agent.
+func (a *registeredAgent) Executor() ports.Executor { return a.executor }
+
+// AgentRegistry is an in-memory implementation of the ports.AgentRegistry interface.
+// It keeps registered agents in a thread-safe map keyed by normalized name.
+// Agents must be registered at startup before tasks are routed to them.
+type AgentRegistry struct {
+	mu     sync.RWMutex
+	agents map[string]*registeredAgent
+}
+
+// NewAgentRegistry creates a new, empty in-memory agent registry.
+// Purpose: Factory function for creating the in-memory agent registry adapter.
+//          Returns the concrete type so callers can Register agents during wiring.
+// Inputs: None
+// Outputs:
+//   - *AgentRegistry: Initialized registry ready for agent registration
+func NewAgentRegistry() *AgentRegistry {
+	return &AgentRegistry{
+		agents: make(map[string]*registeredAgent),
+	}
+}
+
+// Register adds a specialized agent to the registry.
+// Purpose: Makes an agent available for explicit selection and routing.
+//          The profile name is normalized to upper case.
+// Inputs:
+//   - profile: Name, description, allowed tools and system prompt of the agent
+//   - planner: Planner producing the agent's plans
+//   - executor: Executor running the agent's steps
+// Outputs:
+//   - error: Returns error if the name is empty or reserved, a component is nil,
+//            or an agent with that name is already registered
+func (r *AgentRegistry) Register(profile domain.AgentProfile, planner ports.Planner, executor ports.Executor) error {
+	name := domain.NormalizeAgentName(profile.Name)
+	if name == "" {
+		return fmt.Errorf("agent name cannot be empty")
+	}
+	if name == domain.DefaultAgentName {
+		return fmt.Errorf("agent name %s is reserved for the default agent", name)
+	}
+	if planner == nil || executor == nil {
+		return fmt.Errorf("agent %s needs a planner and an executor", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.agents[name]; exists {
+		return fmt.Errorf("agent already registered: %s", name)
+	}
+
+	profile.Name = name
+	profile.AllowedTools = append([]string(nil), profile.AllowedTools...)
+	profile.Keywords = append([]string(nil), profile.Keywords...)
+	r.agents[name] = &registeredAgent{profile: profile, planner: planner, executor: executor}
+
+	return nil
+}
+
+// GetAgent retrieves an agent by name.
+// Purpose: Resolves Task.TargetAgent to the agent's components.
+// Inputs:
+//   - name: Agent name (matched case-insensitively)
+// Outputs:
+//   - ports.Agent: The registered agent
+//   - error: Returns error wrapping domain.ErrUnknownAgent if no such agent is registered
+func (r *AgentRegistry) GetAgent(name string) (ports.Agent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	agent, exists := r.agents[domain.NormalizeAgentName(name)]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnknownAgent, name)
+	}
+
+	return agent, nil
+}
+
+// ListAgents returns the profiles of all registered agents sorted by name.
+// Purpose: Feeds routers and agent discovery endpoints.
+// Inputs: None
+// Outputs:
+//   - []domain.AgentProfile: Profile of every registered agent
+func (r *AgentRegistry) ListAgents() []domain.AgentProfile {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]domain.AgentProfile, 0, len(r.agents))
+	for _, agent := range r.agents {
+		list = append(list, agent.profile)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list
+}
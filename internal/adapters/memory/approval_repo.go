@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// ApprovalRepository is an in-memory implementation of the ports.ApprovalRepository interface.
+// It stores approval requests in a thread-safe map and keeps global and per-task insertion order.
+// All data is lost when the application stops (non-persistent).
+type ApprovalRepository struct {
+	mu        sync.RWMutex
+	approvals map[string]*domain.ApprovalRequest
+	byTask    map[string][]string
+	order     []string
+}
+
+// NewApprovalRepository creates a new in-memory approval repository.
+// Purpose: Factory function for creating the in-memory approval storage adapter.
+// Inputs: None
+// Outputs:
+//   - ports.ApprovalRepository: Initialized repository ready for use
+func NewApprovalRepository() ports.ApprovalRepository {
+	return &ApprovalRepository{
+		approvals: make(map[string]*domain.ApprovalRequest),
+		byTask:    make(map[string][]string),
+	}
+}
+
+// SaveApproval persists an approval request to the in-memory map (insert or update).
+// Purpose: Stores or updates approval state with thread-safe access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - approval: The approval request to save (must have a valid ID and TaskID)
+// Outputs:
+//   - error: Returns error if approval is nil or has an empty ID or TaskID
+func (r *ApprovalRepository) SaveApproval(ctx context.Context, approval *domain.ApprovalRequest) error {
+	if approval == nil {
+		return fmt.Errorf("approval cannot be nil")
+	}
+	if approval.ID == "" {
+		return fmt.Errorf("approval ID cannot be empty")
+	}
+	if approval.TaskID == "" {
+		return fmt.Errorf("approval task ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.approvals[approval.ID]; !exists {
+		r.byTask[approval.TaskID] = append(r.byTask[approval.TaskID], approval.ID)
+		r.order = append(r.order, approval.ID)
+	}
+
+	approvalCopy := *approval
+	r.approvals[approval.ID] = &approvalCopy
+
+	return nil
+}
+
+// GetApproval retrieves an approval request by its unique identifier from memory.
+// Purpose: Loads approval state with thread-safe read access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - id: Unique identifier of the approval request
+// Outputs:
+//   - *domain.ApprovalRequest: A copy of the stored approval request
+//   - error: Returns error if approval is not found or id is empty
+func (r *ApprovalRepository) GetApproval(ctx context.Context, id string) (*domain.ApprovalRequest, error) {
+	if id == "" {
+		return nil, fmt.Errorf("approval ID cannot be empty")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	approval, exists := r.approvals[id]
+	if !exists {
+		return nil, fmt.Errorf("approval not found: %s", id)
+	}
+
+	approvalCopy := *approval
+	return &approvalCopy, nil
+}
+
+// ListTaskApprovals returns all approval requests of a task in creation order.
+// Purpose: Provides approval history for a task with thread-safe read access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - taskID: Unique identifier of the task
+// Outputs:
+//   - []*domain.ApprovalRequest: Copies of the task's approval requests (empty if none)
+//   - error: Always returns nil (this implementation cannot fail)
+func (r *ApprovalRepository) ListTaskApprovals(ctx context.Context, taskID string) ([]*domain.ApprovalRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.byTask[taskID]
+	list := make([]*domain.ApprovalRequest, 0, len(ids))
+	for _, id := range ids {
+		approvalCopy := *r.approvals[id]
+		list = append(list, &approvalCopy)
+	}
+
+	return list, nil
+}
+
+// ListApprovals returns approval requests across all tasks that match the filter.
+// Purpose: Provides reviewer inbox queries with thread-safe read access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - filter: Criteria to match (empty fields match everything)
+// Outputs:
+//   - []*domain.ApprovalRequest: Copies of matching approval requests in creation order
+//   - error: Always returns nil (this implementation cannot fail)
+func (r *ApprovalRepository) ListApprovals(ctx context.Context, filter domain.ApprovalFilter) ([]*domain.ApprovalRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]*domain.ApprovalRequest, 0)
+	for _, id := range r.order {
+		approval := r.approvals[id]
+		if filter.UserID != "" && approval.UserID != filter.UserID {
+			continue
+		}
+		if filter.Status != "" && approval.Status != filter.Status {
+			continue
+		}
+		approvalCopy := *approval
+		list = append(list, &approvalCopy)
+	}
+
+	return list, nil
+}
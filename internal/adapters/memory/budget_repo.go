@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// BudgetRepository is an in-memory implementation of the ports.BudgetRepository interface.
+// It stores user budgets in a thread-safe map keyed by user ID.
+// All data is lost when the application stops (non-persistent).
+type BudgetRepository struct {
+	mu      sync.RWMutex
+	budgets map[string]*domain.UserBudget
+}
+
+// NewBudgetRepository creates a new in-memory budget repository.
+// Purpose: Factory function for creating the in-memory budget storage adapter.
+// Inputs: None
+// Outputs:
+//   - ports.BudgetRepository: Initialized repository ready for use
+func NewBudgetRepository() ports.BudgetRepository {
+	return &BudgetRepository{
+		budgets: make(map[string]*domain.UserBudget),
+	}
+}
+
+// SaveBudget stores a copy of a user budget, replacing any previous one.
+// Purpose: Persists budget data with thread-safe access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - budget: The budget to save (must have a UserID)
+// Outputs:
+//   - error: Returns error if budget is nil or has an empty UserID
+func (r *BudgetRepository) SaveBudget(ctx context.Context, budget *domain.UserBudget) error {
+	if budget == nil {
+		return fmt.Errorf("budget cannot be nil")
+	}
+	if budget.UserID == "" {
+		return fmt.Errorf("budget user ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	budgetCopy := *budget
+	r.budgets[budget.UserID] = &budgetCopy
+
+	return nil
+}
+
+// GetBudget retrieves a copy of a user's budget.
+// Purpose: Fetches budget data with thread-safe read access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - userID: Unique identifier of the user
+// Outputs:
+//   - *domain.UserBudget: Copy of the budget, or nil if none is stored
+//   - error: Always returns nil (this implementation cannot fail)
+func (r *BudgetRepository) GetBudget(ctx context.Context, userID string) (*domain.UserBudget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	budget, exists := r.budgets[userID]
+	if !exists {
+		return nil, nil
+	}
+
+	budgetCopy := *budget
+	return &budgetCopy, nil
+}
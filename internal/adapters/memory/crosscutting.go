@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"math/rand/v2"
 	"time"
 
 	"github.com/JAROBOTAI/jaro/internal/core/ports"
@@ -29,6 +30,16 @@ func (c *SystemClock) Now() time.Time {
 	return time.Now()
 }
 
+// After waits for the duration to elapse using the system timer.
+// Purpose: Provides real waiting for production retry backoff.
+// Inputs:
+//   - d: Duration to wait
+// Outputs:
+//   - <-chan time.Time: Channel that receives the system time once d has elapsed
+func (c *SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
 // UUIDGenerator is a UUID-based ID generator implementation.
 // It implements ports.IDGenerator using Google's UUID library.
 type UUIDGenerator struct{}
@@ -51,6 +62,28 @@ func (g *UUIDGenerator) Generate() string {
 	return uuid.New().String()
 }
 
+// MathRandomSource is a pseudo-random number source implementation.
+// It implements ports.RandomSource using the math/rand/v2 global generator.
+type MathRandomSource struct{}
+
+// NewMathRandomSource creates a new pseudo-random number source.
+// Purpose: Factory function for creating the random source used for retry jitter.
+// Inputs: None
+// Outputs:
+//   - ports.RandomSource: Random source backed by math/rand/v2 (safe for concurrent use)
+func NewMathRandomSource() ports.RandomSource {
+	return &MathRandomSource{}
+}
+
+// Float64 returns a pseudo-random number in [0,1).
+// Purpose: Spreads retry delays so concurrent retries do not align.
+// Inputs: None
+// Outputs:
+//   - float64: Pseudo-random number in [0,1)
+func (r *MathRandomSource) Float64() float64 {
+	return rand.Float64()
+}
+
 // ConsoleLogger is a simple console-based logger implementation.
 // It implements ports.Logger by printing to stdout/stderr.
 type ConsoleLogger struct{}
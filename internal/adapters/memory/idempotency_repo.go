@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// IdempotencyRepository is an in-memory implementation of the ports.IdempotencyRepository interface.
+// It keeps records in a thread-safe map keyed by user and key and drops expired records
+// whenever a key is claimed. All data is lost when the application stops (non-persistent).
+type IdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+	clock   ports.Clock
+}
+
+// NewIdempotencyRepository creates a new in-memory idempotency repository.
+// Purpose: Factory function for creating the in-memory idempotency storage adapter.
+// Inputs:
+//   - clock: Implementation of the Clock port used to expire records
+// Outputs:
+//   - ports.IdempotencyRepository: Initialized repository ready for use
+func NewIdempotencyRepository(clock ports.Clock) ports.IdempotencyRepository {
+	return &IdempotencyRepository{
+		records: make(map[string]*domain.IdempotencyRecord),
+		clock:   clock,
+	}
+}
+
+// ClaimKey stores the record unless an unexpired record holds the same user and key.
+// Purpose: Reserves an idempotency key atomically under the repository lock.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - record: The record to store (UserID, Key, TaskID and ExpiresAt must be set)
+// Outputs:
+//   - *domain.IdempotencyRecord: A copy of the record already holding the key, or nil if claimed
+//   - error: Returns error if record is nil or has an empty UserID, Key or TaskID
+func (r *IdempotencyRepository) ClaimKey(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	if record == nil {
+		return nil, fmt.Errorf("idempotency record cannot be nil")
+	}
+	if record.UserID == "" || record.Key == "" {
+		return nil, fmt.Errorf("idempotency record needs a user ID and key")
+	}
+	if record.TaskID == "" {
+		return nil, fmt.Errorf("idempotency record task ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	for id, existing := range r.records {
+		if existing.IsExpired(now) {
+			delete(r.records, id)
+		}
+	}
+
+	id := recordKey(record.UserID, record.Key)
+	if existing, exists := r.records[id]; exists {
+		existingCopy := *existing
+		return &existingCopy, nil
+	}
+
+	recordCopy := *record
+	r.records[id] = &recordCopy
+
+	return nil, nil
+}
+
+// ReleaseKey deletes the record of a user's idempotency key if present.
+// Purpose: Frees a key whose task could not be created.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - userID: Owner of the key
+//   - key: The idempotency key
+// Outputs:
+//   - error: Always returns nil (this implementation cannot fail)
+func (r *IdempotencyRepository) ReleaseKey(ctx context.Context, userID string, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.records, recordKey(userID, key))
+
+	return nil
+}
+
+// recordKey builds the map key scoping an idempotency key to its user.
+func recordKey(userID string, key string) string {
+	return userID + "\x00" + key
+}
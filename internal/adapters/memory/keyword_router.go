@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// KeywordRouter is a rule-based implementation of the ports.AgentRouter interface.
+// It picks the agent whose AgentProfile.Keywords occur most often in the task input,
+// so routing works locally without an LLM.
+type KeywordRouter struct{}
+
+// NewKeywordRouter creates a new keyword-based agent router.
+// Purpose: Factory function for creating the rule-based router adapter.
+// Inputs: None
+// Outputs:
+//   - ports.AgentRouter: Initialized router ready for use
+func NewKeywordRouter() ports.AgentRouter {
+	return &KeywordRouter{}
+}
+
+// Route picks the agent with the most keyword matches in the task input.
+// Purpose: Scores every candidate by the number of its keywords contained in the input
+//          (case-insensitive). Ties go to the agent listed first.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - task: The task to route (Input and NormalizedIntent are searched)
+//   - agents: Candidate agents
+// Outputs:
+//   - *domain.AgentRoute: The best-matching agent, or an empty Agent if no keyword matches
+//   - error: Always returns nil (this implementation cannot fail)
+func (r *KeywordRouter) Route(ctx context.Context, task *domain.Task, agents []domain.AgentProfile) (*domain.AgentRoute, error) {
+	text := strings.ToLower(task.Input + "\n" + task.NormalizedIntent)
+
+	best := &domain.AgentRoute{Reason: "no agent keyword matches the input"}
+	bestScore := 0
+	for _, agent := range agents {
+		matched := make([]string, 0)
+		for _, keyword := range agent.Keywords {
+			keyword = strings.ToLower(strings.TrimSpace(keyword))
+			if keyword != "" && strings.Contains(text, keyword) {
+				matched = append(matched, keyword)
+			}
+		}
+		if len(matched) > bestScore {
+			bestScore = len(matched)
+			best = &domain.AgentRoute{
+				Agent:  agent.Name,
+				Reason: fmt.Sprintf("input matches keywords %s", strings.Join(matched, ", ")),
+			}
+		}
+	}
+
+	return best, nil
+}
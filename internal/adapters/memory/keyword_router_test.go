@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+func TestKeywordRouterRoute(t *testing.T) {
+	agents := []domain.AgentProfile{
+		{Name: "RESEARCH", Keywords: []string{"research", "paper", "cite"}},
+		{Name: "OPS", Keywords: []string{"deploy", "server", " Restart "}},
+		{Name: "FINANCE", Keywords: []string{"invoice", "paper"}},
+		{Name: "SILENT", Keywords: []string{"", "  "}},
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		intent    string
+		agents    []domain.AgentProfile
+		wantAgent string
+	}{
+		{name: "single match", input: "Deploy the new build", wantAgent: "OPS"},
+		{name: "most matches wins", input: "cite the paper in the research notes", wantAgent: "RESEARCH"},
+		{name: "tie goes to the agent listed first", input: "a paper on taxes", wantAgent: "RESEARCH"},
+		{name: "case-insensitive with trimmed keywords", input: "RESTART the box", wantAgent: "OPS"},
+		{name: "matches the normalized intent", input: "do the thing", intent: "send the invoice", wantAgent: "FINANCE"},
+		{name: "keyword inside a longer word", input: "redeployment", wantAgent: "OPS"},
+		{name: "no match", input: "bake some bread", wantAgent: ""},
+		{name: "empty input", input: "", wantAgent: ""},
+		{name: "blank keywords never match", input: "   ", agents: agents[3:], wantAgent: ""},
+		{name: "no agents", input: "deploy", agents: []domain.AgentProfile{}, wantAgent: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := agents
+			if tt.agents != nil {
+				candidates = tt.agents
+			}
+
+			route, err := NewKeywordRouter().Route(context.Background(), &domain.Task{Input: tt.input, NormalizedIntent: tt.intent}, candidates)
+			if err != nil {
+				t.Fatalf("Route = %v", err)
+			}
+			if route.Agent != tt.wantAgent {
+				t.Errorf("Route(%q) = %q (%s), want %q", tt.input, route.Agent, route.Reason, tt.wantAgent)
+			}
+			if route.Reason == "" {
+				t.Error("Reason is empty")
+			}
+		})
+	}
+}
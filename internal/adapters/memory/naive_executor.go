@@ -6,13 +6,17 @@ import (
 	"time"
 
 	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/execctx"
 	"github.com/JAROBOTAI/jaro/internal/core/ports"
 )
 
 // NaiveExecutor is a simple mock implementation of the ports.Executor interface.
 // It simulates step execution with a short sleep and always returns success.
 // This allows testing the orchestrator flow without actual tool or LLM calls.
-type NaiveExecutor struct{}
+// Created with a tool registry, it runs the tools of TOOL_CALL steps that are registered.
+type NaiveExecutor struct {
+	tools ports.ToolRegistry
+}
 
 // NewNaiveExecutor creates a new mock executor for testing.
 // Purpose: Factory function for creating the mock executor adapter.
@@ -23,23 +27,46 @@ func NewNaiveExecutor() ports.Executor {
 	return &NaiveExecutor{}
 }
 
+// NewNaiveToolExecutor creates a mock executor that runs registered tools.
+// Purpose: Exercises real tools (and tool call recording) without an LLM-backed executor.
+//          TOOL_CALL steps whose tool is registered run it with ToolInput through
+//          the ports.ToolInvoker in ctx; all other steps are simulated as by NewNaiveExecutor.
+// Inputs:
+//   - tools: Registry providing the tools of TOOL_CALL steps
+// Outputs:
+//   - ports.Executor: Initialized executor ready for use
+func NewNaiveToolExecutor(tools ports.ToolRegistry) ports.Executor {
+	return &NaiveExecutor{tools: tools}
+}
+
 // ExecuteStep simulates step execution with a 100ms delay and returns success.
 // Purpose: Provides a predictable execution flow for testing without real tools or LLM.
 //          Logs step execution to console and simulates processing time.
 // Inputs:
-//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - ctx: Context for cancellation and timeout control (interrupts the simulated work)
 //   - task: The parent task (provides context information)
 //   - step: The step to execute (used for logging and result generation)
 // Outputs:
-//   - *domain.StepResult: Always returns success with a mock output message
-//   - error: Always returns nil (this implementation cannot fail)
+//   - *domain.StepResult: Returns success with a mock output message
+//   - error: Returns ctx.Err() if the context is cancelled before the step completes
 func (e *NaiveExecutor) ExecuteStep(ctx context.Context, task *domain.Task, step *domain.Step) (*domain.StepResult, error) {
+	if e.tools != nil && step.Type == domain.StepTypeToolCall && step.ToolName != "" {
+		if tool, err := e.tools.GetTool(step.ToolName); err == nil {
+			return e.executeTool(ctx, tool, step)
+		}
+	}
+
 	// Log execution start
 	fmt.Printf("[EXECUTOR] Executing Step: %s (Type: %s)...\n", step.Title, step.Type)
 
 	// Simulate processing time
 	startTime := time.Now()
-	time.Sleep(100 * time.Millisecond)
+	select {
+	case <-time.After(100 * time.Millisecond):
+	case <-ctx.Done():
+		fmt.Printf("[EXECUTOR] Step interrupted: %s\n", step.Title)
+		return nil, ctx.Err()
+	}
 	duration := time.Since(startTime)
 
 	// Log execution completion
@@ -56,3 +83,31 @@ func (e *NaiveExecutor) ExecuteStep(ctx context.Context, task *domain.Task, step
 
 	return result, nil
 }
+
+// executeTool runs the tool of a TOOL_CALL step with the step's ToolInput.
+// Tool errors are returned for the orchestrator's retry policy to classify.
+func (e *NaiveExecutor) executeTool(ctx context.Context, tool domain.Tool, step *domain.Step) (*domain.StepResult, error) {
+	fmt.Printf("[EXECUTOR] Calling tool %s for step: %s\n", tool.Name(), step.Title)
+
+	startTime := time.Now()
+	output, err := invokeTool(ctx, tool, step.ToolInput)
+	if err != nil {
+		return nil, fmt.Errorf("tool %s failed: %w", tool.Name(), err)
+	}
+
+	return &domain.StepResult{
+		StepID:     step.ID,
+		Success:    true,
+		Output:     output,
+		DurationMs: time.Since(startTime).Milliseconds(),
+	}, nil
+}
+
+// invokeTool runs a tool through the orchestrator's ToolInvoker in ctx, which enforces the
+// agent's AllowedTools and records the call, or directly outside task execution.
+func invokeTool(ctx context.Context, tool domain.Tool, input string) (string, error) {
+	if invoker, ok := execctx.ToolInvokerFromContext(ctx); ok {
+		return invoker.InvokeTool(ctx, tool, input)
+	}
+	return tool.Execute(input)
+}
@@ -71,3 +71,26 @@ func (p *NaivePlanner) CreatePlan(ctx context.Context, task *domain.Task, tools
 
 	return plan, nil
 }
+
+// RevisePlan generates a replacement plan after failed verification.
+// Purpose: Provides a predictable revision for testing replanning without LLM.
+//          The revision is the same fixed 2-step plan with fresh IDs, so it succeeds
+//          only if verification failed for reasons outside the plan itself.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - task: The task requiring a plan (used for TaskID linking)
+//   - previous: The plan that failed verification (its goal is kept)
+//   - verification: The failed verification (unused in this simple implementation)
+//   - tools: Available tools (unused in this simple implementation)
+// Outputs:
+//   - *domain.Plan: Fixed plan with 2 fresh steps
+//   - error: Always returns nil (this implementation cannot fail)
+func (p *NaivePlanner) RevisePlan(ctx context.Context, task *domain.Task, previous *domain.Plan, verification *domain.Verification, tools []domain.ToolMetadata) (*domain.Plan, error) {
+	plan, err := p.CreatePlan(ctx, task, tools)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Goal = previous.Goal
+	return plan, nil
+}
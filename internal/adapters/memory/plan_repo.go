@@ -0,0 +1,229 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// PlanRepository is an in-memory implementation of the ports.PlanRepository interface.
+// It stores plans and step results in thread-safe maps and keeps per-task plan order
+// for local development and testing.
+// All data is lost when the application stops (non-persistent).
+type PlanRepository struct {
+	mu      sync.RWMutex
+	plans   map[string]*domain.Plan
+	byTask  map[string][]string
+	results map[string]map[string]domain.StepResult
+}
+
+// NewPlanRepository creates a new in-memory plan repository.
+// Purpose: Factory function for creating the in-memory plan storage adapter.
+// Inputs: None
+// Outputs:
+//   - ports.PlanRepository: Initialized repository ready for use
+func NewPlanRepository() ports.PlanRepository {
+	return &PlanRepository{
+		plans:   make(map[string]*domain.Plan),
+		byTask:  make(map[string][]string),
+		results: make(map[string]map[string]domain.StepResult),
+	}
+}
+
+// SavePlan persists a plan to the in-memory map (insert or update).
+// Purpose: Stores or replaces the full plan with thread-safe access. When replacing,
+//          every step status change must be allowed by the step state machine.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - plan: The plan to save (must have a valid ID)
+// Outputs:
+//   - error: Returns error if plan is nil or has an empty ID, or a *domain.TransitionError
+//            if a stored step status cannot move to the new one
+func (r *PlanRepository) SavePlan(ctx context.Context, plan *domain.Plan) error {
+	if plan == nil {
+		return fmt.Errorf("plan cannot be nil")
+	}
+	if plan.ID == "" {
+		return fmt.Errorf("plan ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.plans[plan.ID]
+	if exists {
+		for i := range plan.Steps {
+			for j := range stored.Steps {
+				if stored.Steps[j].ID != plan.Steps[i].ID || stored.Steps[j].Status == plan.Steps[i].Status {
+					continue
+				}
+				if err := domain.CheckStepTransition(plan.Steps[i].ID, stored.Steps[j].Status, plan.Steps[i].Status); err != nil {
+					return err
+				}
+			}
+		}
+	} else {
+		r.byTask[plan.TaskID] = append(r.byTask[plan.TaskID], plan.ID)
+	}
+	r.plans[plan.ID] = copyPlan(plan)
+
+	return nil
+}
+
+// GetPlan retrieves a plan by its unique identifier from memory.
+// Purpose: Loads plan state from in-memory storage with thread-safe read access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - id: Unique identifier of the plan to retrieve
+// Outputs:
+//   - *domain.Plan: A copy of the stored plan
+//   - error: Returns error if plan is not found or id is empty
+func (r *PlanRepository) GetPlan(ctx context.Context, id string) (*domain.Plan, error) {
+	if id == "" {
+		return nil, fmt.Errorf("plan ID cannot be empty")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	plan, exists := r.plans[id]
+	if !exists {
+		return nil, fmt.Errorf("plan not found: %s", id)
+	}
+
+	return copyPlan(plan), nil
+}
+
+// ListTaskPlans returns all plans of a task in creation order.
+// Purpose: Provides the plan revision history of a task with thread-safe read access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - taskID: Unique identifier of the task
+// Outputs:
+//   - []*domain.Plan: Copies of the task's plans (empty if none)
+//   - error: Always returns nil (this implementation cannot fail)
+func (r *PlanRepository) ListTaskPlans(ctx context.Context, taskID string) ([]*domain.Plan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.byTask[taskID]
+	list := make([]*domain.Plan, 0, len(ids))
+	for _, id := range ids {
+		list = append(list, copyPlan(r.plans[id]))
+	}
+
+	return list, nil
+}
+
+// UpdateStep replaces a single step of a stored plan.
+// Purpose: Persists step progress without requiring callers to resave the whole plan.
+//          Status changes must be allowed by the step state machine.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - planID: Unique identifier of the plan owning the step
+//   - step: The updated step (matched by ID)
+// Outputs:
+//   - error: Returns error if step is nil, or plan or step is not found, or a
+//            *domain.TransitionError if the stored status cannot move to step.Status
+func (r *PlanRepository) UpdateStep(ctx context.Context, planID string, step *domain.Step) error {
+	if step == nil {
+		return fmt.Errorf("step cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	plan, exists := r.plans[planID]
+	if !exists {
+		return fmt.Errorf("plan not found: %s", planID)
+	}
+
+	for i := range plan.Steps {
+		if plan.Steps[i].ID == step.ID {
+			// Updates that keep the status (e.g., the retry count) are not transitions
+			if plan.Steps[i].Status != step.Status {
+				if err := domain.CheckStepTransition(step.ID, plan.Steps[i].Status, step.Status); err != nil {
+					return err
+				}
+			}
+			plan.Steps[i] = *step
+			return nil
+		}
+	}
+
+	return fmt.Errorf("step not found: %s", step.ID)
+}
+
+// SaveStepResult stores the execution result of a step in memory.
+// Purpose: Keeps step outputs available after execution.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - planID: Unique identifier of the plan owning the step
+//   - result: The result to store (StepID must reference a step of the plan)
+// Outputs:
+//   - error: Returns error if result is nil, or plan or step is not found
+func (r *PlanRepository) SaveStepResult(ctx context.Context, planID string, result *domain.StepResult) error {
+	if result == nil {
+		return fmt.Errorf("step result cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	plan, exists := r.plans[planID]
+	if !exists {
+		return fmt.Errorf("plan not found: %s", planID)
+	}
+	if !planHasStep(plan, result.StepID) {
+		return fmt.Errorf("step not found: %s", result.StepID)
+	}
+
+	if r.results[planID] == nil {
+		r.results[planID] = make(map[string]domain.StepResult)
+	}
+	r.results[planID][result.StepID] = *result
+
+	return nil
+}
+
+// GetStepResult retrieves the stored result of a step from memory.
+// Purpose: Loads a step output with thread-safe read access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - planID: Unique identifier of the plan owning the step
+//   - stepID: Unique identifier of the step
+// Outputs:
+//   - *domain.StepResult: A copy of the stored result
+//   - error: Returns error if no result is stored for the step
+func (r *PlanRepository) GetStepResult(ctx context.Context, planID string, stepID string) (*domain.StepResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result, exists := r.results[planID][stepID]
+	if !exists {
+		return nil, fmt.Errorf("step result not found: %s", stepID)
+	}
+
+	return &result, nil
+}
+
+// copyPlan returns a copy of the plan with its own Steps slice.
+func copyPlan(plan *domain.Plan) *domain.Plan {
+	planCopy := *plan
+	planCopy.Steps = make([]domain.Step, len(plan.Steps))
+	copy(planCopy.Steps, plan.Steps)
+	return &planCopy
+}
+
+// planHasStep reports whether the plan contains a step with the given ID.
+func planHasStep(plan *domain.Plan, stepID string) bool {
+	for i := range plan.Steps {
+		if plan.Steps[i].ID == stepID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,297 @@
+package memory
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// fillerPrefixes are politeness phrases stripped from the start of a request.
+var fillerPrefixes = []string{
+	"please", "kindly", "could you", "can you", "would you", "will you",
+	"i want you to", "i need you to", "i would like you to", "molim te", "molim vas",
+}
+
+// languageStopwords lists frequent short words per ISO 639-1 language, in detection order
+// (ties go to the language listed first).
+var languageStopwords = []struct {
+	language string
+	words    []string
+}{
+	{"en", []string{"the", "a", "an", "and", "for", "in", "of", "to", "me", "with", "under", "is", "my", "on", "find"}},
+	{"sr", []string{"i", "u", "za", "na", "je", "da", "se", "od", "do", "sa", "mi", "ispod", "nađi", "pronađi",
+		"и", "у", "за", "на", "је", "да", "се", "од", "до", "са", "ми", "испод"}},
+	{"de", []string{"der", "die", "das", "und", "ein", "eine", "für", "mit", "ist", "ich", "unter", "von", "nicht"}},
+	{"fr", []string{"le", "la", "les", "et", "un", "une", "pour", "dans", "avec", "est", "je", "du", "des"}},
+	{"es", []string{"el", "la", "los", "las", "y", "un", "una", "para", "en", "con", "es", "del", "por"}},
+}
+
+// currencyCodes maps currency symbols and words (lower case) to ISO 4217 codes.
+var currencyCodes = map[string]string{
+	"€": "EUR", "eur": "EUR", "euro": "EUR", "euros": "EUR", "evra": "EUR",
+	"$": "USD", "usd": "USD", "dollar": "USD", "dollars": "USD",
+	"£": "GBP", "gbp": "GBP", "pound": "GBP", "pounds": "GBP",
+	"rsd": "RSD", "din": "RSD", "dinar": "RSD", "dinara": "RSD",
+	"chf": "CHF",
+}
+
+// relativeDays maps words for today, tomorrow and yesterday to their offset in days.
+var relativeDays = map[string]int{
+	"today": 0, "tomorrow": 1, "yesterday": -1,
+	"danas": 0, "sutra": 1, "juče": -1, "juce": -1,
+}
+
+var (
+	amountAfterPattern  = regexp.MustCompile(`(?i)(\d[\d.,]*)\s?(€|\$|£|(?:eur|euros?|evra|usd|dollars?|gbp|pounds?|rsd|din(?:ara?)?|chf)\b)`)
+	amountBeforePattern = regexp.MustCompile(`(€|\$|£)\s?(\d[\d.,]*)`)
+	isoDatePattern      = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`)
+	dayFirstDatePattern = regexp.MustCompile(`\b(\d{1,2})[./](\d{1,2})[./](\d{4})\b`)
+	dayMonthPattern     = regexp.MustCompile(`(?i)\b(\d{1,2})\.?\s+(january|february|march|april|may|june|july|august|september|october|november|december)\s+(\d{4})\b`)
+	monthDayPattern     = regexp.MustCompile(`(?i)\b(january|february|march|april|may|june|july|august|september|october|november|december)\s+(\d{1,2}),?\s+(\d{4})\b`)
+	relativeDayPattern  = regexp.MustCompile(`(?i)\b(today|tomorrow|yesterday|danas|sutra|juče|juce)\b`)
+	locationPattern     = regexp.MustCompile(`\b(?:in|at|near|around|from|to|u|na|kod|blizu)\s+(\p{Lu}[\p{L}'-]+(?:\s+\p{Lu}[\p{L}'-]+)*)`)
+)
+
+// locationStopwords are capitalized words that follow location prepositions without being places.
+var locationStopwords = map[string]bool{
+	"january": true, "february": true, "march": true, "april": true, "may": true, "june": true,
+	"july": true, "august": true, "september": true, "october": true, "november": true, "december": true,
+	"monday": true, "tuesday": true, "wednesday": true, "thursday": true, "friday": true,
+	"saturday": true, "sunday": true,
+}
+
+// RuleNormalizer is a deterministic implementation of the ports.IntentNormalizer interface.
+// It strips filler phrases, detects the language from common words and extracts dates,
+// money amounts and locations with regular expressions. It requires no LLM.
+type RuleNormalizer struct{}
+
+// NewRuleNormalizer creates a new rule-based intent normalizer.
+// Purpose: Factory function for creating the deterministic normalizer adapter.
+// Inputs: None
+// Outputs:
+//   - ports.IntentNormalizer: Initialized normalizer ready for use
+func NewRuleNormalizer() ports.IntentNormalizer {
+	return &RuleNormalizer{}
+}
+
+// Normalize cleans up the task input and extracts its entities.
+// Purpose: Produces a structured intent from simple rules:
+//          - whitespace is collapsed and leading filler ("please", "could you") removed
+//          - the language with the most common-word matches wins (en, sr, de, fr, es)
+//          - DATE: YYYY-MM-DD, D.M.YYYY (day first), "17 October 2026", "October 17, 2026",
+//            and today/tomorrow/yesterday relative to Task.CreatedAt
+//          - AMOUNT: numbers with a currency symbol, code or word ("800 EUR", "$1,200")
+//          - LOCATION: capitalized words after in/at/near/from/to (and Serbian u/na/kod/blizu)
+//          Confidence starts at 0.3 and gains 0.3 for a recognized language and 0.1 per
+//          entity (at most 0.3), so rules never claim more than 0.9.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - task: The task whose Input is normalized
+// Outputs:
+//   - *domain.Intent: The normalized intent
+//   - error: Always returns nil (this implementation cannot fail)
+func (n *RuleNormalizer) Normalize(ctx context.Context, task *domain.Task) (*domain.Intent, error) {
+	text := strings.Join(strings.Fields(task.Input), " ")
+	language := detectLanguage(text)
+	entities := extractEntities(text, task.CreatedAt)
+
+	confidence := 0.3
+	if language != "" {
+		confidence += 0.3
+	}
+	confidence += 0.1 * float64(min(len(entities), 3))
+
+	return &domain.Intent{
+		Text:       cleanIntent(text),
+		Language:   language,
+		Entities:   entities,
+		Confidence: confidence,
+	}, nil
+}
+
+// cleanIntent removes filler phrases and trailing punctuation from a whitespace-collapsed request.
+func cleanIntent(text string) string {
+	cleaned := text
+	for stripped := true; stripped; {
+		stripped = false
+		for _, prefix := range fillerPrefixes {
+			if n, ok := fillerPrefixLen(cleaned, prefix); ok {
+				cleaned = strings.TrimLeft(cleaned[n:], " ,")
+				stripped = true
+				break
+			}
+		}
+	}
+	cleaned = strings.TrimRight(cleaned, " .!?")
+	if cleaned == "" {
+		return text
+	}
+
+	runes := []rune(cleaned)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// fillerPrefixLen reports whether text starts with the filler phrase followed by a space or
+// comma (case-insensitive) and returns the phrase's length in bytes of text. The length is
+// measured in text, not in the phrase, because case folding may change a rune's byte width
+// (e.g., the Kelvin sign folds to "k").
+func fillerPrefixLen(text string, prefix string) (int, bool) {
+	n := 0
+	for range prefix {
+		if n >= len(text) {
+			return 0, false
+		}
+		_, size := utf8.DecodeRuneInString(text[n:])
+		n += size
+	}
+	if !strings.EqualFold(text[:n], prefix) || n >= len(text) || (text[n] != ' ' && text[n] != ',') {
+		return 0, false
+	}
+	return n, true
+}
+
+// detectLanguage returns the language whose common words occur most often, or "" if none occur.
+func detectLanguage(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	best, bestScore := "", 0
+	for _, candidate := range languageStopwords {
+		score := 0
+		for _, word := range words {
+			for _, stopword := range candidate.words {
+				if word == stopword {
+					score++
+					break
+				}
+			}
+		}
+		if score > bestScore {
+			best, bestScore = candidate.language, score
+		}
+	}
+	return best
+}
+
+// entitySpan is an extracted entity with its byte range in the input.
+type entitySpan struct {
+	entity     domain.Entity
+	start, end int
+}
+
+// extractEntities finds dates, amounts and locations in input order.
+// Earlier extractors win where matches overlap (e.g., the digits of a date are no amount).
+func extractEntities(text string, now time.Time) []domain.Entity {
+	var spans []entitySpan
+	add := func(entityType domain.EntityType, value string, start, end int) {
+		for _, span := range spans {
+			if start < span.end && span.start < end {
+				return
+			}
+		}
+		spans = append(spans, entitySpan{
+			entity: domain.Entity{Type: entityType, Value: value, Text: text[start:end]},
+			start:  start,
+			end:    end,
+		})
+	}
+
+	for _, m := range isoDatePattern.FindAllStringIndex(text, -1) {
+		if date, err := time.Parse("2006-01-02", text[m[0]:m[1]]); err == nil {
+			add(domain.EntityTypeDate, date.Format("2006-01-02"), m[0], m[1])
+		}
+	}
+	for _, m := range dayFirstDatePattern.FindAllStringSubmatchIndex(text, -1) {
+		value := text[m[2]:m[3]] + "." + text[m[4]:m[5]] + "." + text[m[6]:m[7]]
+		if date, err := time.Parse("2.1.2006", value); err == nil {
+			add(domain.EntityTypeDate, date.Format("2006-01-02"), m[0], m[1])
+		}
+	}
+	for _, m := range dayMonthPattern.FindAllStringSubmatchIndex(text, -1) {
+		value := text[m[2]:m[3]] + " " + strings.ToLower(text[m[4]:m[5]]) + " " + text[m[6]:m[7]]
+		if date, err := time.Parse("2 January 2006", value); err == nil {
+			add(domain.EntityTypeDate, date.Format("2006-01-02"), m[0], m[1])
+		}
+	}
+	for _, m := range monthDayPattern.FindAllStringSubmatchIndex(text, -1) {
+		value := text[m[4]:m[5]] + " " + strings.ToLower(text[m[2]:m[3]]) + " " + text[m[6]:m[7]]
+		if date, err := time.Parse("2 January 2006", value); err == nil {
+			add(domain.EntityTypeDate, date.Format("2006-01-02"), m[0], m[1])
+		}
+	}
+	if !now.IsZero() {
+		for _, m := range relativeDayPattern.FindAllStringIndex(text, -1) {
+			offset := relativeDays[strings.ToLower(text[m[0]:m[1]])]
+			add(domain.EntityTypeDate, now.AddDate(0, 0, offset).Format("2006-01-02"), m[0], m[1])
+		}
+	}
+
+	for _, m := range amountAfterPattern.FindAllStringSubmatchIndex(text, -1) {
+		if number, ok := normalizeAmount(text[m[2]:m[3]]); ok {
+			add(domain.EntityTypeAmount, number+" "+currencyCodes[currencyKey(text[m[4]:m[5]])], m[0], m[1])
+		}
+	}
+	for _, m := range amountBeforePattern.FindAllStringSubmatchIndex(text, -1) {
+		if number, ok := normalizeAmount(text[m[4]:m[5]]); ok {
+			add(domain.EntityTypeAmount, number+" "+currencyCodes[text[m[2]:m[3]]], m[0], m[1])
+		}
+	}
+
+	for _, m := range locationPattern.FindAllStringSubmatchIndex(text, -1) {
+		name := text[m[2]:m[3]]
+		if locationStopwords[strings.ToLower(strings.Fields(name)[0])] || currencyCodes[strings.ToLower(name)] != "" {
+			continue
+		}
+		add(domain.EntityTypeLocation, name, m[2], m[3])
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	entities := make([]domain.Entity, 0, len(spans))
+	for _, span := range spans {
+		entities = append(entities, span.entity)
+	}
+	return entities
+}
+
+// currencyKey maps a matched currency spelling to its key in currencyCodes
+// (plural and inflected forms of "dinar" included).
+func currencyKey(currency string) string {
+	key := strings.ToLower(currency)
+	if strings.HasPrefix(key, "din") {
+		return "din"
+	}
+	return key
+}
+
+// normalizeAmount converts a written number to plain decimal notation.
+// A separator followed by exactly three digits is read as a thousands separator
+// ("1,200", "1.500"), any other separator as the decimal point ("12,50").
+func normalizeAmount(number string) (string, bool) {
+	number = strings.TrimRight(number, ".,")
+	if number == "" {
+		return "", false
+	}
+
+	var b strings.Builder
+	groups := strings.FieldsFunc(number, func(r rune) bool { return r == '.' || r == ',' })
+	for i, group := range groups {
+		switch {
+		case i == 0 || len(group) == 3:
+			b.WriteString(group)
+		case i == len(groups)-1:
+			b.WriteString("." + group)
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
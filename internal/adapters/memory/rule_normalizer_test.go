@@ -0,0 +1,183 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+func TestRuleNormalizerNormalize(t *testing.T) {
+	created := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	date := func(value, text string) domain.Entity {
+		return domain.Entity{Type: domain.EntityTypeDate, Value: value, Text: text}
+	}
+	amount := func(value, text string) domain.Entity {
+		return domain.Entity{Type: domain.EntityTypeAmount, Value: value, Text: text}
+	}
+	location := func(name string) domain.Entity {
+		return domain.Entity{Type: domain.EntityTypeLocation, Value: name, Text: name}
+	}
+
+	tests := []struct {
+		name           string
+		input          string
+		wantText       string
+		wantLanguage   string
+		wantEntities   []domain.Entity
+		wantConfidence float64
+	}{
+		{
+			name:           "empty input",
+			input:          "",
+			wantText:       "",
+			wantConfidence: 0.3,
+		},
+		{
+			name:           "whitespace only",
+			input:          " \t\n ",
+			wantText:       "",
+			wantConfidence: 0.3,
+		},
+		{
+			name:           "filler only is kept",
+			input:          "please",
+			wantText:       "Please",
+			wantConfidence: 0.3,
+		},
+		{
+			name:           "collapsed whitespace and stacked filler",
+			input:          "  Please,   could you\tfind a flat in Berlin under 800 EUR!  ",
+			wantText:       "Find a flat in Berlin under 800 EUR",
+			wantLanguage:   "en",
+			wantEntities:   []domain.Entity{location("Berlin"), amount("800 EUR", "800 EUR")},
+			wantConfidence: 0.8,
+		},
+		{
+			name:         "dates in every format",
+			input:        "book it for 2026-11-02, 3.12.2026, 17 October 2026 or October 18, 2026 but not tomorrow",
+			wantText:     "Book it for 2026-11-02, 3.12.2026, 17 October 2026 or October 18, 2026 but not tomorrow",
+			wantLanguage: "en",
+			wantEntities: []domain.Entity{
+				date("2026-11-02", "2026-11-02"),
+				date("2026-12-03", "3.12.2026"),
+				date("2026-10-17", "17 October 2026"),
+				date("2026-10-18", "October 18, 2026"),
+				date("2026-10-17", "tomorrow"),
+			},
+			wantConfidence: 0.9, // Entities add at most 0.3
+		},
+		{
+			name:           "invalid date is no date and no amount",
+			input:          "the 2026-13-45 file",
+			wantText:       "The 2026-13-45 file",
+			wantLanguage:   "en",
+			wantConfidence: 0.6,
+		},
+		{
+			name:         "amount notations",
+			input:        "pay $1,200 and 12,50 eur and €1.500 and 3 dinara",
+			wantText:     "Pay $1,200 and 12,50 eur and €1.500 and 3 dinara",
+			wantLanguage: "en",
+			wantEntities: []domain.Entity{
+				amount("1200 USD", "$1,200"),
+				amount("12.50 EUR", "12,50 eur"),
+				amount("1500 EUR", "€1.500"),
+				amount("3 RSD", "3 dinara"),
+			},
+			wantConfidence: 0.9,
+		},
+		{
+			name:           "month after a preposition is no location",
+			input:          "move in May",
+			wantText:       "Move in May",
+			wantLanguage:   "en",
+			wantConfidence: 0.6,
+		},
+		{
+			name:           "serbian latin with diacritics",
+			input:          "molim te, nađi stan u Čačku za sutra",
+			wantText:       "Nađi stan u Čačku za sutra",
+			wantLanguage:   "sr",
+			wantEntities:   []domain.Entity{location("Čačku"), date("2026-10-17", "sutra")},
+			wantConfidence: 0.8,
+		},
+		{
+			name:           "serbian cyrillic",
+			input:          "нађи стан у граду за мене",
+			wantText:       "Нађи стан у граду за мене",
+			wantLanguage:   "sr",
+			wantConfidence: 0.6,
+		},
+		{
+			name:           "leading emoji is kept intact",
+			input:          "😀 please help",
+			wantText:       "😀 please help",
+			wantConfidence: 0.3,
+		},
+		{
+			name:           "filler spelled with a wider rune is stripped whole",
+			input:          "Kindly help me", // Kelvin sign: three bytes folding to "k"
+			wantText:       "Help me",
+			wantLanguage:   "en",
+			wantConfidence: 0.6,
+		},
+		{
+			name:           "rune lower-casing to a narrower one is no filler",
+			input:          "İ want you to help",
+			wantText:       "İ want you to help",
+			wantLanguage:   "en",
+			wantConfidence: 0.6,
+		},
+		{
+			name:           "multi-word location",
+			input:          "flights from New York",
+			wantText:       "Flights from New York",
+			wantLanguage:   "",
+			wantEntities:   []domain.Entity{location("New York")},
+			wantConfidence: 0.4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intent, err := NewRuleNormalizer().Normalize(context.Background(), &domain.Task{Input: tt.input, CreatedAt: created})
+			if err != nil {
+				t.Fatalf("Normalize = %v", err)
+			}
+			if intent.Text != tt.wantText {
+				t.Errorf("Text = %q, want %q", intent.Text, tt.wantText)
+			}
+			if !utf8.ValidString(intent.Text) {
+				t.Errorf("Text = %q is not valid UTF-8", intent.Text)
+			}
+			if intent.Language != tt.wantLanguage {
+				t.Errorf("Language = %q, want %q", intent.Language, tt.wantLanguage)
+			}
+			if len(intent.Entities) != len(tt.wantEntities) {
+				t.Fatalf("Entities = %+v, want %+v", intent.Entities, tt.wantEntities)
+			}
+			for i, want := range tt.wantEntities {
+				if intent.Entities[i] != want {
+					t.Errorf("Entities[%d] = %+v, want %+v", i, intent.Entities[i], want)
+				}
+			}
+			if math.Abs(intent.Confidence-tt.wantConfidence) > 1e-9 {
+				t.Errorf("Confidence = %v, want %v", intent.Confidence, tt.wantConfidence)
+			}
+		})
+	}
+}
+
+func TestRuleNormalizerIgnoresRelativeDaysWithoutCreationTime(t *testing.T) {
+	intent, err := NewRuleNormalizer().Normalize(context.Background(), &domain.Task{Input: "call me tomorrow"})
+	if err != nil {
+		t.Fatalf("Normalize = %v", err)
+	}
+	if len(intent.Entities) != 0 {
+		t.Errorf("Entities = %+v, want none without Task.CreatedAt", intent.Entities)
+	}
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// minKeywordLength is the shortest goal word treated as a keyword (shorter words are
+// mostly articles and prepositions).
+const minKeywordLength = 4
+
+// RuleVerifier is a deterministic implementation of the ports.Verifier interface.
+// It checks step results for failures and missing outputs and, optionally, that the
+// results mention enough of the plan goal's keywords. It requires no LLM.
+type RuleVerifier struct {
+	minGoalCoverage float64
+}
+
+// NewRuleVerifier creates a new rule-based verifier.
+// Purpose: Factory function for creating the deterministic verifier adapter.
+// Inputs:
+//   - minGoalCoverage: Fraction (0-1) of goal keywords that must appear in step outputs;
+//     0 disables the goal keyword check
+// Outputs:
+//   - ports.Verifier: Initialized verifier ready for use
+func NewRuleVerifier(minGoalCoverage float64) ports.Verifier {
+	return &RuleVerifier{minGoalCoverage: minGoalCoverage}
+}
+
+// Verify checks the accumulated step results against simple rules.
+// Purpose: Catches plans that "succeeded" without producing anything usable:
+//          - at least one step must have produced a result
+//          - every result must be successful
+//          - THINK, TOOL_CALL and DECISION steps must produce output
+//          - outputs must cover minGoalCoverage of the goal keywords (if enabled)
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - task: The task being verified (unused in this implementation)
+//   - plan: The plan whose goal and step types are checked
+//   - results: Results of the steps that have run so far
+// Outputs:
+//   - *domain.Verification: Verdict listing every rule that was violated
+//   - error: Always returns nil (this implementation cannot fail)
+func (v *RuleVerifier) Verify(ctx context.Context, task *domain.Task, plan *domain.Plan, results []*domain.StepResult) (*domain.Verification, error) {
+	if len(results) == 0 {
+		return &domain.Verification{
+			Passed: false,
+			Reason: "no step produced a result",
+		}, nil
+	}
+
+	stepTypes := make(map[string]domain.StepType, len(plan.Steps))
+	for i := range plan.Steps {
+		stepTypes[plan.Steps[i].ID] = plan.Steps[i].Type
+	}
+
+	issues := make([]string, 0)
+	outputs := make([]string, 0, len(results))
+	for _, result := range results {
+		if !result.Success {
+			issues = append(issues, fmt.Sprintf("step %s reported failure: %s", result.StepID, result.ErrorMessage))
+			continue
+		}
+		switch stepTypes[result.StepID] {
+		case domain.StepTypeThink, domain.StepTypeToolCall, domain.StepTypeDecision:
+			if strings.TrimSpace(result.Output) == "" {
+				issues = append(issues, fmt.Sprintf("step %s produced no output", result.StepID))
+			}
+		}
+		outputs = append(outputs, result.Output)
+	}
+
+	if v.minGoalCoverage > 0 {
+		if missing, coverage := goalCoverage(plan.Goal, strings.Join(outputs, "\n")); coverage < v.minGoalCoverage {
+			issues = append(issues, fmt.Sprintf("results cover %.0f%% of the goal (missing: %s)", coverage*100, strings.Join(missing, ", ")))
+		}
+	}
+
+	if len(issues) > 0 {
+		return &domain.Verification{
+			Passed: false,
+			Reason: fmt.Sprintf("%d verification rule(s) violated", len(issues)),
+			Issues: issues,
+		}, nil
+	}
+
+	return &domain.Verification{
+		Passed: true,
+		Reason: fmt.Sprintf("%d step result(s) satisfy all verification rules", len(results)),
+	}, nil
+}
+
+// goalCoverage returns the goal keywords missing from text and the fraction that is present.
+// A goal without keywords is fully covered.
+func goalCoverage(goal string, text string) ([]string, float64) {
+	words := strings.FieldsFunc(strings.ToLower(goal), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool)
+	keywords := make([]string, 0, len(words))
+	for _, word := range words {
+		if len([]rune(word)) < minKeywordLength || seen[word] {
+			continue
+		}
+		seen[word] = true
+		keywords = append(keywords, word)
+	}
+	if len(keywords) == 0 {
+		return nil, 1
+	}
+
+	haystack := strings.ToLower(text)
+	missing := make([]string, 0)
+	for _, keyword := range keywords {
+		if !strings.Contains(haystack, keyword) {
+			missing = append(missing, keyword)
+		}
+	}
+
+	return missing, float64(len(keywords)-len(missing)) / float64(len(keywords))
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+func TestRuleVerifierVerify(t *testing.T) {
+	plan := &domain.Plan{
+		Goal: "Summarize the quarterly sales report",
+		Steps: []domain.Step{
+			{ID: "think", Type: domain.StepTypeThink},
+			{ID: "tool", Type: domain.StepTypeToolCall},
+			{ID: "decide", Type: domain.StepTypeDecision},
+			{ID: "gate", Type: domain.StepTypeApprovalGate},
+		},
+	}
+	ok := func(stepID string, output string) *domain.StepResult {
+		return &domain.StepResult{StepID: stepID, Success: true, Output: output}
+	}
+
+	tests := []struct {
+		name       string
+		goal       string // Overrides the plan goal if set
+		coverage   float64
+		results    []*domain.StepResult
+		wantPassed bool
+		wantIssues []string // Substrings expected in the issues, in order
+	}{
+		{
+			name:       "no results",
+			wantPassed: false,
+		},
+		{
+			name:       "all steps produced output",
+			results:    []*domain.StepResult{ok("think", "an idea"), ok("tool", "data")},
+			wantPassed: true,
+		},
+		{
+			name: "failed step",
+			results: []*domain.StepResult{
+				ok("think", "an idea"),
+				{StepID: "tool", Success: false, ErrorMessage: "timeout"},
+			},
+			wantIssues: []string{"step tool reported failure: timeout"},
+		},
+		{
+			name: "empty outputs of producing steps",
+			results: []*domain.StepResult{
+				ok("think", ""),
+				ok("tool", " \n\t"),
+				ok("decide", ""),
+			},
+			wantIssues: []string{"step think produced no output", "step tool produced no output", "step decide produced no output"},
+		},
+		{
+			name:       "approval gate may have no output",
+			results:    []*domain.StepResult{ok("think", "an idea"), ok("gate", "")},
+			wantPassed: true,
+		},
+		{
+			name:       "goal covered ignoring case",
+			coverage:   1,
+			results:    []*domain.StepResult{ok("think", "Quarterly SALES: up"), ok("tool", "summarize the report")},
+			wantPassed: true,
+		},
+		{
+			name:       "goal partly covered",
+			coverage:   0.75,
+			results:    []*domain.StepResult{ok("think", "sales were up")},
+			wantIssues: []string{"results cover 25% of the goal (missing: summarize, quarterly, report)"},
+		},
+		{
+			name:       "goal coverage at the threshold",
+			coverage:   0.5,
+			results:    []*domain.StepResult{ok("think", "the sales report")},
+			wantPassed: true,
+		},
+		{
+			name:       "short goal words are not keywords",
+			goal:       "Get a map of the area",
+			coverage:   1,
+			results:    []*domain.StepResult{ok("think", "area")},
+			wantPassed: true,
+		},
+		{
+			name:       "goal without keywords",
+			goal:       "Do it, now!",
+			coverage:   1,
+			results:    []*domain.StepResult{ok("think", "done")},
+			wantPassed: true,
+		},
+		{
+			name:       "coverage check disabled",
+			coverage:   0,
+			results:    []*domain.StepResult{ok("think", "unrelated")},
+			wantPassed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := *plan
+			if tt.goal != "" {
+				p.Goal = tt.goal
+			}
+
+			verification, err := NewRuleVerifier(tt.coverage).Verify(context.Background(), &domain.Task{}, &p, tt.results)
+			if err != nil {
+				t.Fatalf("Verify = %v", err)
+			}
+			if verification.Passed != tt.wantPassed {
+				t.Fatalf("Passed = %v, want %v (%+v)", verification.Passed, tt.wantPassed, verification)
+			}
+			if verification.Reason == "" {
+				t.Error("Reason is empty")
+			}
+			if len(verification.Issues) != len(tt.wantIssues) {
+				t.Fatalf("Issues = %q, want %q", verification.Issues, tt.wantIssues)
+			}
+			for i, want := range tt.wantIssues {
+				if !strings.Contains(verification.Issues[i], want) {
+					t.Errorf("Issues[%d] = %q, want it to contain %q", i, verification.Issues[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// ScheduleRepository is an in-memory implementation of the ports.ScheduleRepository interface.
+// It stores schedules in a thread-safe map and keeps insertion order for listings.
+// All data is lost when the application stops (non-persistent).
+type ScheduleRepository struct {
+	mu        sync.RWMutex
+	schedules map[string]*domain.Schedule
+	order     []string
+}
+
+// NewScheduleRepository creates a new in-memory schedule repository.
+// Purpose: Factory function for creating the in-memory schedule storage adapter.
+// Inputs: None
+// Outputs:
+//   - ports.ScheduleRepository: Initialized repository ready for use
+func NewScheduleRepository() ports.ScheduleRepository {
+	return &ScheduleRepository{
+		schedules: make(map[string]*domain.Schedule),
+	}
+}
+
+// SaveSchedule persists a schedule to the in-memory map (insert or update).
+// Purpose: Stores or updates schedule state with thread-safe access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - schedule: The schedule to save (must have a valid ID)
+// Outputs:
+//   - error: Returns error if schedule is nil or has an empty ID
+func (r *ScheduleRepository) SaveSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	if schedule == nil {
+		return fmt.Errorf("schedule cannot be nil")
+	}
+	if schedule.ID == "" {
+		return fmt.Errorf("schedule ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.schedules[schedule.ID]; !exists {
+		r.order = append(r.order, schedule.ID)
+	}
+
+	scheduleCopy := *schedule
+	r.schedules[schedule.ID] = &scheduleCopy
+
+	return nil
+}
+
+// GetSchedule retrieves a schedule by its unique identifier from memory.
+// Purpose: Loads schedule state with thread-safe read access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - id: Unique identifier of the schedule
+// Outputs:
+//   - *domain.Schedule: A copy of the stored schedule
+//   - error: Returns error if schedule is not found or id is empty
+func (r *ScheduleRepository) GetSchedule(ctx context.Context, id string) (*domain.Schedule, error) {
+	if id == "" {
+		return nil, fmt.Errorf("schedule ID cannot be empty")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, exists := r.schedules[id]
+	if !exists {
+		return nil, fmt.Errorf("schedule not found: %s", id)
+	}
+
+	scheduleCopy := *schedule
+	return &scheduleCopy, nil
+}
+
+// ListSchedules returns schedules that match the filter.
+// Purpose: Provides listings and due-schedule queries with thread-safe read access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - filter: Criteria to match (empty fields match everything)
+// Outputs:
+//   - []*domain.Schedule: Copies of matching schedules in creation order
+//   - error: Always returns nil (this implementation cannot fail)
+func (r *ScheduleRepository) ListSchedules(ctx context.Context, filter domain.ScheduleFilter) ([]*domain.Schedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]*domain.Schedule, 0)
+	for _, id := range r.order {
+		schedule := r.schedules[id]
+		if !filter.Matches(schedule) {
+			continue
+		}
+		scheduleCopy := *schedule
+		list = append(list, &scheduleCopy)
+	}
+
+	return list, nil
+}
+
+// DeleteSchedule removes a schedule from memory.
+// Purpose: Deletes a schedule with thread-safe write access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - id: Unique identifier of the schedule
+// Outputs:
+//   - error: Returns error if schedule is not found or id is empty
+func (r *ScheduleRepository) DeleteSchedule(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("schedule ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.schedules[id]; !exists {
+		return fmt.Errorf("schedule not found: %s", id)
+	}
+
+	delete(r.schedules, id)
+	for i, scheduleID := range r.order {
+		if scheduleID == id {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
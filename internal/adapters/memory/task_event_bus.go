@@ -0,0 +1,196 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// subscriberBufferSize is the number of events a subscriber may lag behind before it is dropped.
+const subscriberBufferSize = 256
+
+// eventStream is the buffered event stream of one task.
+type eventStream struct {
+	events      []*domain.TaskEvent // Most recent events, oldest first (trimmed to the buffer size)
+	lastSeq     int64
+	closed      bool
+	subscribers map[*eventSubscriber]struct{}
+	lastActive  uint64 // Activity counter value of the last publish or subscribe
+}
+
+// eventSubscriber is one live subscription to a task's stream.
+type eventSubscriber struct {
+	ch chan *domain.TaskEvent
+}
+
+// TaskEventBus is an in-memory implementation of the ports.TaskEventBus interface.
+// It keeps the most recent events of each task in a bounded buffer and drops the buffers of
+// the least recently active tasks without subscribers once too many tasks are tracked.
+// Subscribers that fall more than subscriberBufferSize events behind are dropped (their
+// channel is closed) instead of blocking publishers; they resume from the buffer.
+// All data is lost when the application stops (non-persistent).
+type TaskEventBus struct {
+	mu         sync.Mutex
+	streams    map[string]*eventStream
+	bufferSize int
+	maxTasks   int
+	activity   uint64
+}
+
+// NewTaskEventBus creates a new in-memory task event bus.
+// Purpose: Factory function for creating the in-memory live event adapter.
+// Inputs:
+//   - bufferSize: Recent events kept per task for resuming clients (at least 1)
+//   - maxTasks: Number of task streams kept before inactive ones are dropped (at least 1)
+// Outputs:
+//   - ports.TaskEventBus: Initialized bus ready for use
+func NewTaskEventBus(bufferSize int, maxTasks int) ports.TaskEventBus {
+	return &TaskEventBus{
+		streams:    make(map[string]*eventStream),
+		bufferSize: max(bufferSize, 1),
+		maxTasks:   max(maxTasks, 1),
+	}
+}
+
+// Publish appends an event to its task's stream and delivers it to the subscribers.
+// Purpose: Assigns the next Seq of the task with thread-safe access.
+// Inputs:
+//   - event: The event to publish (Seq is assigned in place; ignored if nil)
+// Outputs: None
+func (b *TaskEventBus) Publish(event *domain.TaskEvent) {
+	if event == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stream := b.stream(event.TaskID)
+	stream.lastSeq++
+	event.Seq = stream.lastSeq
+
+	// Trim in batches so appends stay amortized O(1)
+	stream.events = append(stream.events, event)
+	if len(stream.events) >= 2*b.bufferSize {
+		stream.events = append([]*domain.TaskEvent(nil), stream.events[len(stream.events)-b.bufferSize:]...)
+	}
+
+	for subscriber := range stream.subscribers {
+		select {
+		case subscriber.ch <- event:
+		default:
+			// Too far behind: drop the subscriber rather than block the task
+			delete(stream.subscribers, subscriber)
+			close(subscriber.ch)
+		}
+	}
+}
+
+// CloseTask marks a task's stream as finished and closes its subscribers' channels.
+// Purpose: Ends live subscriptions once a task is terminal; the buffer is kept for replays.
+// Inputs:
+//   - taskID: Unique identifier of the task
+// Outputs: None
+func (b *TaskEventBus) CloseTask(taskID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stream := b.stream(taskID)
+	stream.closed = true
+	for subscriber := range stream.subscribers {
+		delete(stream.subscribers, subscriber)
+		close(subscriber.ch)
+	}
+}
+
+// Subscribe returns the buffered events after afterSeq and a channel of new events.
+// Purpose: Registers a live subscriber with thread-safe access; the subscriber is removed
+//          (and its channel closed) once ctx is done.
+// Inputs:
+//   - ctx: Lifetime of the subscription
+//   - taskID: Unique identifier of the task
+//   - afterSeq: Seq of the last event the client has seen (0: none)
+// Outputs:
+//   - *domain.TaskEventSubscription: Backlog (copy) and live channel
+func (b *TaskEventBus) Subscribe(ctx context.Context, taskID string, afterSeq int64) *domain.TaskEventSubscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stream := b.stream(taskID)
+
+	// A position beyond the stream predates a restart of the sequence: replay everything
+	if afterSeq > stream.lastSeq {
+		afterSeq = 0
+	}
+
+	buffered := stream.events
+	if len(buffered) > b.bufferSize {
+		buffered = buffered[len(buffered)-b.bufferSize:]
+	}
+	backlog := make([]*domain.TaskEvent, 0, len(buffered))
+	for _, event := range buffered {
+		if event.Seq > afterSeq {
+			backlog = append(backlog, event)
+		}
+	}
+
+	subscriber := &eventSubscriber{ch: make(chan *domain.TaskEvent, subscriberBufferSize)}
+	if stream.closed {
+		close(subscriber.ch)
+		return &domain.TaskEventSubscription{Backlog: backlog, Events: subscriber.ch, Closed: true}
+	}
+
+	stream.subscribers[subscriber] = struct{}{}
+	context.AfterFunc(ctx, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := stream.subscribers[subscriber]; ok {
+			delete(stream.subscribers, subscriber)
+			close(subscriber.ch)
+		}
+	})
+
+	return &domain.TaskEventSubscription{Backlog: backlog, Events: subscriber.ch}
+}
+
+// stream returns the stream of a task, creating it (and evicting an inactive one) if needed.
+// The caller must hold b.mu.
+func (b *TaskEventBus) stream(taskID string) *eventStream {
+	b.activity++
+
+	if stream, ok := b.streams[taskID]; ok {
+		stream.lastActive = b.activity
+		return stream
+	}
+
+	if len(b.streams) >= b.maxTasks {
+		b.evictInactive()
+	}
+
+	stream := &eventStream{
+		subscribers: make(map[*eventSubscriber]struct{}),
+		lastActive:  b.activity,
+	}
+	b.streams[taskID] = stream
+	return stream
+}
+
+// evictInactive drops the least recently active stream without subscribers, if any.
+// The caller must hold b.mu.
+func (b *TaskEventBus) evictInactive() {
+	var oldestID string
+	var oldest *eventStream
+	for taskID, stream := range b.streams {
+		if len(stream.subscribers) > 0 {
+			continue
+		}
+		if oldest == nil || stream.lastActive < oldest.lastActive {
+			oldestID, oldest = taskID, stream
+		}
+	}
+	if oldest != nil {
+		delete(b.streams, oldestID)
+	}
+}
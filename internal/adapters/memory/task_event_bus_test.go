@@ -0,0 +1,223 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// eventTimeout bounds waits for asynchronous unsubscription.
+const eventTimeout = 5 * time.Second
+
+// publishN publishes n events of a task and returns them.
+func publishN(bus *TaskEventBus, taskID string, n int) []*domain.TaskEvent {
+	events := make([]*domain.TaskEvent, 0, n)
+	for i := 0; i < n; i++ {
+		event := &domain.TaskEvent{TaskID: taskID, Type: "STEP_STATUS_CHANGED"}
+		bus.Publish(event)
+		events = append(events, event)
+	}
+	return events
+}
+
+// seqs returns the Seq of each event.
+func seqs(events []*domain.TaskEvent) []int64 {
+	out := make([]int64, 0, len(events))
+	for _, event := range events {
+		out = append(out, event.Seq)
+	}
+	return out
+}
+
+// receive reads the next live event or fails the test.
+func receive(t *testing.T, events <-chan *domain.TaskEvent) *domain.TaskEvent {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("events channel closed")
+		}
+		return event
+	case <-time.After(eventTimeout):
+		t.Fatal("no event received")
+	}
+	return nil
+}
+
+// awaitClosed drains the channel until it is closed and returns the events it still held.
+func awaitClosed(t *testing.T, events <-chan *domain.TaskEvent) []*domain.TaskEvent {
+	t.Helper()
+	drained := make([]*domain.TaskEvent, 0)
+	timeout := time.After(eventTimeout)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return drained
+			}
+			drained = append(drained, event)
+		case <-timeout:
+			t.Fatal("events channel was never closed")
+		}
+	}
+}
+
+func TestTaskEventBusReplaysAfterSeq(t *testing.T) {
+	tests := []struct {
+		name       string
+		bufferSize int
+		afterSeq   int64
+		want       []int64
+	}{
+		{name: "from the start", bufferSize: 10, afterSeq: 0, want: []int64{1, 2, 3, 4, 5}},
+		{name: "after a seen event", bufferSize: 10, afterSeq: 3, want: []int64{4, 5}},
+		{name: "fully caught up", bufferSize: 10, afterSeq: 5, want: []int64{}},
+		{name: "position from before a restart", bufferSize: 10, afterSeq: 42, want: []int64{1, 2, 3, 4, 5}},
+		{name: "only the buffered events", bufferSize: 3, afterSeq: 0, want: []int64{3, 4, 5}},
+		{name: "buffer smaller than the gap", bufferSize: 2, afterSeq: 1, want: []int64{4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewTaskEventBus(tt.bufferSize, 10).(*TaskEventBus)
+			publishN(bus, "task-1", 5)
+
+			sub := bus.Subscribe(context.Background(), "task-1", tt.afterSeq)
+			if got := seqs(sub.Backlog); !slices.Equal(got, tt.want) {
+				t.Errorf("Backlog = %v, want %v", got, tt.want)
+			}
+			if sub.Closed {
+				t.Error("Closed = true for an open stream")
+			}
+
+			// Live events continue right after the backlog
+			publishN(bus, "task-1", 1)
+			if event := receive(t, sub.Events); event.Seq != 6 {
+				t.Errorf("live event Seq = %d, want 6", event.Seq)
+			}
+		})
+	}
+}
+
+func TestTaskEventBusFansOutPerTask(t *testing.T) {
+	bus := NewTaskEventBus(10, 10).(*TaskEventBus)
+	ctx := context.Background()
+
+	first := bus.Subscribe(ctx, "task-1", 0)
+	second := bus.Subscribe(ctx, "task-1", 0)
+	other := bus.Subscribe(ctx, "task-2", 0)
+
+	publishN(bus, "task-1", 3)
+	publishN(bus, "task-2", 1)
+
+	for name, sub := range map[string]*domain.TaskEventSubscription{"first": first, "second": second} {
+		for want := int64(1); want <= 3; want++ {
+			if event := receive(t, sub.Events); event.Seq != want || event.TaskID != "task-1" {
+				t.Errorf("%s subscriber got (%s, %d), want (task-1, %d)", name, event.TaskID, event.Seq, want)
+			}
+		}
+	}
+	// Sequences are numbered per task
+	if event := receive(t, other.Events); event.Seq != 1 || event.TaskID != "task-2" {
+		t.Errorf("task-2 subscriber got (%s, %d), want (task-2, 1)", event.TaskID, event.Seq)
+	}
+	for name, sub := range map[string]*domain.TaskEventSubscription{"first": first, "second": second, "other": other} {
+		select {
+		case event := <-sub.Events:
+			t.Errorf("%s subscriber got unexpected event %+v", name, event)
+		default:
+		}
+	}
+}
+
+func TestTaskEventBusCleansUpSubscribers(t *testing.T) {
+	bus := NewTaskEventBus(10, 10).(*TaskEventBus)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	leaving := bus.Subscribe(ctx, "task-1", 0)
+	staying := bus.Subscribe(context.Background(), "task-1", 0)
+	cancel()
+	if drained := awaitClosed(t, leaving.Events); len(drained) != 0 {
+		t.Errorf("unsubscribed channel held %d events, want none", len(drained))
+	}
+	bus.mu.Lock()
+	remaining := len(bus.streams["task-1"].subscribers)
+	bus.mu.Unlock()
+	if remaining != 1 {
+		t.Fatalf("subscribers after unsubscribe = %d, want 1", remaining)
+	}
+
+	// Publishing after the unsubscribe reaches only the remaining subscriber
+	publishN(bus, "task-1", 1)
+	if event := receive(t, staying.Events); event.Seq != 1 {
+		t.Errorf("remaining subscriber got Seq %d, want 1", event.Seq)
+	}
+
+	// Closing the task ends the remaining subscription but keeps the buffer for replays
+	bus.CloseTask("task-1")
+	awaitClosed(t, staying.Events)
+	late := bus.Subscribe(context.Background(), "task-1", 0)
+	if !late.Closed || !slices.Equal(seqs(late.Backlog), []int64{1}) {
+		t.Errorf("subscription after close = (closed %v, backlog %v), want closed with backlog [1]", late.Closed, seqs(late.Backlog))
+	}
+	if _, open := <-late.Events; open {
+		t.Error("events channel of a closed stream is open")
+	}
+}
+
+func TestTaskEventBusDropsSlowSubscriber(t *testing.T) {
+	const published = subscriberBufferSize + 10
+	bus := NewTaskEventBus(published, 10).(*TaskEventBus)
+	ctx := context.Background()
+
+	slow := bus.Subscribe(ctx, "task-1", 0)
+	fast := bus.Subscribe(ctx, "task-1", 0)
+
+	// Publishing never blocks on the slow subscriber, which stops reading
+	for i := 1; i <= published; i++ {
+		publishN(bus, "task-1", 1)
+		if event := receive(t, fast.Events); event.Seq != int64(i) {
+			t.Fatalf("fast subscriber got Seq %d, want %d", event.Seq, i)
+		}
+	}
+
+	// The slow subscriber keeps what fit in its channel, then sees it closed
+	drained := awaitClosed(t, slow.Events)
+	if len(drained) != subscriberBufferSize || drained[len(drained)-1].Seq != subscriberBufferSize {
+		t.Fatalf("slow subscriber received %d events, want the first %d", len(drained), subscriberBufferSize)
+	}
+
+	// It resumes from the buffer where it left off
+	resumed := bus.Subscribe(ctx, "task-1", drained[len(drained)-1].Seq)
+	if got := seqs(resumed.Backlog); len(got) != published-subscriberBufferSize || got[0] != subscriberBufferSize+1 {
+		t.Errorf("resumed backlog = %v, want Seq %d to %d", got, subscriberBufferSize+1, published)
+	}
+
+	// The fast subscriber is still live
+	publishN(bus, "task-1", 1)
+	if event := receive(t, fast.Events); event.Seq != published+1 {
+		t.Errorf("fast subscriber got Seq %d, want %d", event.Seq, published+1)
+	}
+}
+
+func TestTaskEventBusEvictsOnlyInactiveStreams(t *testing.T) {
+	bus := NewTaskEventBus(10, 2).(*TaskEventBus)
+
+	watched := bus.Subscribe(context.Background(), "task-1", 0)
+	publishN(bus, "task-1", 1)
+	publishN(bus, "task-2", 1)
+	publishN(bus, "task-3", 1) // Evicts task-2: task-1 has a subscriber
+
+	if event := receive(t, watched.Events); event.Seq != 1 {
+		t.Errorf("watched task event Seq = %d, want 1", event.Seq)
+	}
+	if backlog := bus.Subscribe(context.Background(), "task-1", 0).Backlog; len(backlog) != 1 {
+		t.Errorf("task-1 backlog = %v, want its event kept", seqs(backlog))
+	}
+	if backlog := bus.Subscribe(context.Background(), "task-2", 0).Backlog; len(backlog) != 0 {
+		t.Errorf("task-2 backlog = %v, want the evicted stream empty", seqs(backlog))
+	}
+}
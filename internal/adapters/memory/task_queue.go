@@ -0,0 +1,260 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// servedHistory is how long the queue remembers when a user was last served. Users keep their
+// turn while they have nothing queued, so submitting one task at a time does not put them
+// ahead of users with a backlog; users idle for longer count as never served.
+const servedHistory = 10 * time.Minute
+
+// servedTurn records when a user last received a worker.
+type servedTurn struct {
+	turn uint64
+	at   time.Time
+}
+
+// queuedTask is a queue entry with the scheduling attributes captured at enqueue time.
+type queuedTask struct {
+	taskID     string
+	userID     string
+	rank       int
+	enqueuedAt time.Time
+	seq        uint64
+}
+
+// TaskQueue is an in-memory priority queue implementation of the ports.TaskQueue interface.
+// It serves higher Task.Priority first, round-robins between users of equal priority and
+// ages waiting tasks (one priority level per aging interval) so no user or priority starves.
+// Blocked consumers are woken through a signal channel.
+// Queued tasks are lost when the application stops; crash recovery re-queues them.
+type TaskQueue struct {
+	mu       sync.Mutex
+	items    []queuedTask
+	served   map[string]servedTurn // user ID → turn at which the user was last served
+	turn     uint64
+	seq      uint64
+	capacity int
+	aging    time.Duration
+	clock    ports.Clock
+	ready    chan struct{}
+}
+
+// NewTaskQueue creates a new in-memory task queue.
+// Purpose: Factory function for creating the in-memory queue adapter.
+// Inputs:
+//   - capacity: Maximum number of queued tasks (Enqueue fails beyond it)
+//   - aging: Wait after which a queued task is promoted by one priority level (0 disables aging)
+//   - clock: Implementation of the Clock port used to measure waiting time
+// Outputs:
+//   - ports.TaskQueue: Initialized queue ready for use
+func NewTaskQueue(capacity int, aging time.Duration, clock ports.Clock) ports.TaskQueue {
+	return &TaskQueue{
+		served:   make(map[string]servedTurn),
+		capacity: capacity,
+		aging:    aging,
+		clock:    clock,
+		ready:    make(chan struct{}, 1),
+	}
+}
+
+// Enqueue adds a task to the queue.
+// Purpose: Accepts work for the worker pool with thread-safe access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - task: The task to queue (must have a valid ID)
+// Outputs:
+//   - error: Returns error if task is nil or has an empty ID, or wraps domain.ErrQueueFull
+func (q *TaskQueue) Enqueue(ctx context.Context, task *domain.Task) error {
+	if task == nil {
+		return fmt.Errorf("task cannot be nil")
+	}
+	if task.ID == "" {
+		return fmt.Errorf("task ID cannot be empty")
+	}
+
+	q.mu.Lock()
+	if len(q.items) >= q.capacity {
+		q.mu.Unlock()
+		return fmt.Errorf("%w: %d tasks queued", domain.ErrQueueFull, q.capacity)
+	}
+	q.seq++
+	q.items = append(q.items, queuedTask{
+		taskID:     task.ID,
+		userID:     task.UserID,
+		rank:       task.Priority.Rank(),
+		enqueuedAt: q.clock.Now(),
+		seq:        q.seq,
+	})
+	q.mu.Unlock()
+
+	q.signal()
+	return nil
+}
+
+// Dequeue removes the next task to run, blocking until one is available.
+// Purpose: Feeds idle workers with thread-safe access.
+// Inputs:
+//   - ctx: Context for cancellation; Dequeue returns when it is done
+// Outputs:
+//   - string: Unique identifier of the dequeued task
+//   - error: Returns ctx.Err() if the context ends before a task is available
+func (q *TaskQueue) Dequeue(ctx context.Context) (string, error) {
+	for {
+		q.mu.Lock()
+		if len(q.items) > 0 {
+			now := q.clock.Now()
+			i := q.next(q.items, q.turns(), now)
+			item := q.items[i]
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			q.markServed(item.userID, now)
+			remaining := len(q.items)
+			q.mu.Unlock()
+
+			// Pass the wake-up on so other waiting workers see the remaining tasks
+			if remaining > 0 {
+				q.signal()
+			}
+			return item.taskID, nil
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.ready:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+// Len returns the number of tasks waiting in the queue.
+// Purpose: Supports monitoring with thread-safe read access.
+// Inputs: None
+// Outputs:
+//   - int: Number of queued tasks
+func (q *TaskQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.items)
+}
+
+// Position reports where a task currently stands in the dequeue order.
+// Purpose: Replays the scheduling decisions on a snapshot of the queue. The result assumes
+//          no further arrivals and no aging, so it is an estimate for long waits.
+// Inputs:
+//   - taskID: Unique identifier of the queued task
+// Outputs:
+//   - int: 1-based position (1 = next to be dequeued)
+//   - bool: False if the task is not in the queue
+func (q *TaskQueue) Position(taskID string) (int, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	items := append([]queuedTask(nil), q.items...)
+	served := q.turns()
+	turn := q.turn
+	now := q.clock.Now()
+
+	for position := 1; len(items) > 0; position++ {
+		i := q.next(items, served, now)
+		if items[i].taskID == taskID {
+			return position, true
+		}
+		turn++
+		served[items[i].userID] = turn
+		items = append(items[:i], items[i+1:]...)
+	}
+
+	return 0, false
+}
+
+// Remove drops a task from the queue before a worker picks it up.
+// Purpose: Frees the capacity held by a task that was canceled while queued.
+// Inputs:
+//   - taskID: Unique identifier of the task to remove
+// Outputs:
+//   - bool: True if the task was queued and has been removed
+func (q *TaskQueue) Remove(taskID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, item := range q.items {
+		if item.taskID == taskID {
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// next returns the index of the item to dequeue first: highest aged priority, then the user
+// served least recently, then the oldest submission. Callers must hold q.mu.
+func (q *TaskQueue) next(items []queuedTask, served map[string]uint64, now time.Time) int {
+	best := 0
+	for i := 1; i < len(items); i++ {
+		a, b := items[i], items[best]
+		if pa, pb := q.effectiveRank(a, now), q.effectiveRank(b, now); pa != pb {
+			if pa > pb {
+				best = i
+			}
+			continue
+		}
+		if sa, sb := served[a.userID], served[b.userID]; sa != sb {
+			if sa < sb {
+				best = i
+			}
+			continue
+		}
+		if a.seq < b.seq {
+			best = i
+		}
+	}
+	return best
+}
+
+// effectiveRank returns the item's priority rank raised by one level per aging interval waited.
+func (q *TaskQueue) effectiveRank(item queuedTask, now time.Time) int {
+	if q.aging <= 0 {
+		return item.rank
+	}
+	return item.rank + int(now.Sub(item.enqueuedAt)/q.aging)
+}
+
+// turns returns a copy of the turn at which each user was last served. Callers must hold q.mu.
+func (q *TaskQueue) turns() map[string]uint64 {
+	turns := make(map[string]uint64, len(q.served))
+	for user, served := range q.served {
+		turns[user] = served.turn
+	}
+	return turns
+}
+
+// markServed records that the user just received a worker and forgets users not served
+// within servedHistory, so the map stays bounded by recently active users.
+// Callers must hold q.mu.
+func (q *TaskQueue) markServed(userID string, now time.Time) {
+	q.turn++
+	q.served[userID] = servedTurn{turn: q.turn, at: now}
+
+	for user, served := range q.served {
+		if now.Sub(served.at) > servedHistory {
+			delete(q.served, user)
+		}
+	}
+}
+
+// signal wakes one blocked consumer without blocking the caller.
+func (q *TaskQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,311 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// fakeClock is a manually advanced ports.Clock.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- c.Advance(d)
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+// queueOp is one step of a queue scenario: advance the clock, enqueue a task or dequeue one.
+type queueOp struct {
+	advance time.Duration
+	enqueue *domain.Task
+	dequeue string // Expected task ID
+}
+
+func enqueue(id string, user string, priority domain.TaskPriority) queueOp {
+	return queueOp{enqueue: &domain.Task{ID: id, UserID: user, Priority: priority}}
+}
+
+func dequeue(id string) queueOp {
+	return queueOp{dequeue: id}
+}
+
+func advance(d time.Duration) queueOp {
+	return queueOp{advance: d}
+}
+
+func TestTaskQueueOrdering(t *testing.T) {
+	tests := []struct {
+		name  string
+		aging time.Duration
+		ops   []queueOp
+	}{
+		{
+			name: "higher priority first",
+			ops: []queueOp{
+				enqueue("low", "alice", domain.TaskPriorityLow),
+				enqueue("normal", "alice", domain.TaskPriorityNormal),
+				enqueue("urgent", "alice", domain.TaskPriorityUrgent),
+				enqueue("high", "alice", domain.TaskPriorityHigh),
+				dequeue("urgent"), dequeue("high"), dequeue("normal"), dequeue("low"),
+			},
+		},
+		{
+			name: "empty priority counts as normal",
+			ops: []queueOp{
+				enqueue("low", "alice", domain.TaskPriorityLow),
+				enqueue("default", "alice", ""),
+				dequeue("default"), dequeue("low"),
+			},
+		},
+		{
+			name: "same user is served in submission order",
+			ops: []queueOp{
+				enqueue("a1", "alice", domain.TaskPriorityNormal),
+				enqueue("a2", "alice", domain.TaskPriorityNormal),
+				enqueue("a3", "alice", domain.TaskPriorityNormal),
+				dequeue("a1"), dequeue("a2"), dequeue("a3"),
+			},
+		},
+		{
+			name: "users of equal priority take turns",
+			ops: []queueOp{
+				enqueue("a1", "alice", domain.TaskPriorityNormal),
+				enqueue("a2", "alice", domain.TaskPriorityNormal),
+				enqueue("a3", "alice", domain.TaskPriorityNormal),
+				enqueue("b1", "bob", domain.TaskPriorityNormal),
+				enqueue("b2", "bob", domain.TaskPriorityNormal),
+				dequeue("a1"), dequeue("b1"), dequeue("a2"), dequeue("b2"), dequeue("a3"),
+			},
+		},
+		{
+			name: "priority wins over fairness",
+			ops: []queueOp{
+				enqueue("a1", "alice", domain.TaskPriorityHigh),
+				enqueue("a2", "alice", domain.TaskPriorityHigh),
+				enqueue("b1", "bob", domain.TaskPriorityNormal),
+				dequeue("a1"), dequeue("a2"), dequeue("b1"),
+			},
+		},
+		{
+			name: "served user keeps their turn while idle",
+			ops: []queueOp{
+				enqueue("a1", "alice", domain.TaskPriorityNormal),
+				enqueue("b1", "bob", domain.TaskPriorityNormal),
+				enqueue("b2", "bob", domain.TaskPriorityNormal),
+				dequeue("a1"),
+				dequeue("b1"),
+				// Alice resubmits before Carol: she was served, Carol never was
+				enqueue("a2", "alice", domain.TaskPriorityNormal),
+				enqueue("c1", "carol", domain.TaskPriorityNormal),
+				dequeue("c1"), dequeue("a2"), dequeue("b2"),
+			},
+		},
+		{
+			name: "served turns are forgotten after the history window",
+			ops: []queueOp{
+				enqueue("a1", "alice", domain.TaskPriorityNormal),
+				dequeue("a1"),
+				advance(servedHistory + time.Minute),
+				enqueue("b1", "bob", domain.TaskPriorityNormal),
+				dequeue("b1"),
+				// Alice's turn aged out when Bob was served: she now counts as never served
+				enqueue("b2", "bob", domain.TaskPriorityNormal),
+				enqueue("a2", "alice", domain.TaskPriorityNormal),
+				dequeue("a2"), dequeue("b2"),
+			},
+		},
+		{
+			name:  "waiting task is promoted by aging",
+			aging: time.Minute,
+			ops: []queueOp{
+				enqueue("old", "alice", domain.TaskPriorityLow),
+				advance(2 * time.Minute),
+				enqueue("new", "bob", domain.TaskPriorityNormal),
+				dequeue("old"), dequeue("new"),
+			},
+		},
+		{
+			name:  "aging below one interval does not promote",
+			aging: time.Minute,
+			ops: []queueOp{
+				enqueue("old", "alice", domain.TaskPriorityLow),
+				advance(59 * time.Second),
+				enqueue("new", "bob", domain.TaskPriorityNormal),
+				dequeue("new"), dequeue("old"),
+			},
+		},
+		{
+			name: "aging disabled",
+			ops: []queueOp{
+				enqueue("old", "alice", domain.TaskPriorityLow),
+				advance(time.Hour),
+				enqueue("new", "bob", domain.TaskPriorityNormal),
+				dequeue("new"), dequeue("old"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			queue := NewTaskQueue(100, tt.aging, clock)
+			ctx := context.Background()
+
+			for i, op := range tt.ops {
+				switch {
+				case op.advance > 0:
+					clock.Advance(op.advance)
+				case op.enqueue != nil:
+					if err := queue.Enqueue(ctx, op.enqueue); err != nil {
+						t.Fatalf("op %d: Enqueue(%s) = %v", i, op.enqueue.ID, err)
+					}
+				default:
+					got, err := queue.Dequeue(ctx)
+					if err != nil {
+						t.Fatalf("op %d: Dequeue() = %v", i, err)
+					}
+					if got != op.dequeue {
+						t.Fatalf("op %d: Dequeue() = %s, want %s", i, got, op.dequeue)
+					}
+				}
+			}
+
+			if n := queue.Len(); n != 0 {
+				t.Errorf("Len() = %d after the scenario, want 0", n)
+			}
+		})
+	}
+}
+
+func TestTaskQueuePosition(t *testing.T) {
+	queue := NewTaskQueue(100, 0, newFakeClock())
+	ctx := context.Background()
+
+	for _, task := range []*domain.Task{
+		{ID: "a1", UserID: "alice", Priority: domain.TaskPriorityNormal},
+		{ID: "a2", UserID: "alice", Priority: domain.TaskPriorityNormal},
+		{ID: "b1", UserID: "bob", Priority: domain.TaskPriorityNormal},
+		{ID: "h1", UserID: "carol", Priority: domain.TaskPriorityHigh},
+	} {
+		if err := queue.Enqueue(ctx, task); err != nil {
+			t.Fatalf("Enqueue(%s) = %v", task.ID, err)
+		}
+	}
+
+	tests := []struct {
+		taskID string
+		want   int
+		found  bool
+	}{
+		{taskID: "h1", want: 1, found: true},
+		{taskID: "a1", want: 2, found: true},
+		{taskID: "b1", want: 3, found: true},
+		{taskID: "a2", want: 4, found: true},
+		{taskID: "missing", want: 0, found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.taskID, func(t *testing.T) {
+			got, found := queue.Position(tt.taskID)
+			if got != tt.want || found != tt.found {
+				t.Errorf("Position(%s) = (%d, %v), want (%d, %v)", tt.taskID, got, found, tt.want, tt.found)
+			}
+		})
+	}
+
+	// Position replays the order without consuming the queue
+	for _, want := range []string{"h1", "a1", "b1", "a2"} {
+		got, err := queue.Dequeue(ctx)
+		if err != nil || got != want {
+			t.Fatalf("Dequeue() = (%s, %v), want %s", got, err, want)
+		}
+	}
+}
+
+func TestTaskQueueRemove(t *testing.T) {
+	queue := NewTaskQueue(2, 0, newFakeClock())
+	ctx := context.Background()
+
+	for _, id := range []string{"t1", "t2"} {
+		if err := queue.Enqueue(ctx, &domain.Task{ID: id, UserID: "alice"}); err != nil {
+			t.Fatalf("Enqueue(%s) = %v", id, err)
+		}
+	}
+	if err := queue.Enqueue(ctx, &domain.Task{ID: "t3", UserID: "alice"}); !errors.Is(err, domain.ErrQueueFull) {
+		t.Fatalf("Enqueue beyond capacity = %v, want ErrQueueFull", err)
+	}
+
+	if !queue.Remove("t1") {
+		t.Fatal("Remove(t1) = false, want true")
+	}
+	if queue.Remove("t1") {
+		t.Fatal("Remove(t1) twice = true, want false")
+	}
+	if _, found := queue.Position("t1"); found {
+		t.Fatal("removed task still has a position")
+	}
+
+	// The freed slot accepts a new task
+	if err := queue.Enqueue(ctx, &domain.Task{ID: "t3", UserID: "alice"}); err != nil {
+		t.Fatalf("Enqueue after Remove = %v", err)
+	}
+	if n := queue.Len(); n != 2 {
+		t.Fatalf("Len() = %d, want 2", n)
+	}
+}
+
+func TestTaskQueueDequeue(t *testing.T) {
+	queue := NewTaskQueue(10, 0, newFakeClock())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := queue.Dequeue(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Dequeue on an empty queue with a canceled context = %v, want context.Canceled", err)
+	}
+
+	// A blocked consumer is woken by a later enqueue
+	got := make(chan string, 1)
+	go func() {
+		id, err := queue.Dequeue(context.Background())
+		if err != nil {
+			id = err.Error()
+		}
+		got <- id
+	}()
+	if err := queue.Enqueue(context.Background(), &domain.Task{ID: "t1", UserID: "alice"}); err != nil {
+		t.Fatalf("Enqueue = %v", err)
+	}
+	select {
+	case id := <-got:
+		if id != "t1" {
+			t.Fatalf("Dequeue() = %s, want t1", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("blocked Dequeue was not woken by Enqueue")
+	}
+}
@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/JAROBOTAI/jaro/internal/core/domain"
@@ -30,12 +31,14 @@ func NewTaskRepository() ports.TaskRepository {
 
 // SaveTask persists a task to the in-memory map (insert or update).
 // Purpose: Stores or updates task state in memory with thread-safe access.
-//          This implementation uses a write lock to ensure concurrent safety.
+//          This implementation uses a write lock to ensure concurrent safety and
+//          rejects updates whose status change violates the task state machine.
 // Inputs:
 //   - ctx: Context for cancellation and timeout control (unused in this implementation)
 //   - task: The task to save (must have a valid ID)
 // Outputs:
-//   - error: Returns error if task is nil or has an empty ID
+//   - error: Returns error if task is nil or has an empty ID, or a *domain.TransitionError
+//            if the stored status cannot move to task.Status
 func (r *TaskRepository) SaveTask(ctx context.Context, task *domain.Task) error {
 	if task == nil {
 		return fmt.Errorf("task cannot be nil")
@@ -47,9 +50,15 @@ func (r *TaskRepository) SaveTask(ctx context.Context, task *domain.Task) error
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	// Updates that keep the status (e.g., progress or metadata) are not transitions
+	if stored, exists := r.tasks[task.ID]; exists && stored.Status != task.Status {
+		if err := domain.CheckTaskTransition(task.ID, stored.Status, task.Status); err != nil {
+			return err
+		}
+	}
+
 	// Deep copy to avoid external mutations
-	taskCopy := *task
-	r.tasks[task.ID] = &taskCopy
+	r.tasks[task.ID] = task.Clone()
 
 	return nil
 }
@@ -76,6 +85,35 @@ func (r *TaskRepository) GetTask(ctx context.Context, id string) (*domain.Task,
 	}
 
 	// Deep copy to avoid external mutations
-	taskCopy := *task
-	return &taskCopy, nil
+	return task.Clone(), nil
+}
+
+// ListTasks returns all tasks matching the filter from memory, oldest first.
+// Purpose: Supports crash recovery and listings with thread-safe read access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - filter: Criteria to match (empty fields match everything)
+// Outputs:
+//   - []*domain.Task: Copies of matching tasks ordered by CreatedAt (then ID)
+//   - error: Always returns nil (this implementation cannot fail)
+func (r *TaskRepository) ListTasks(ctx context.Context, filter domain.TaskFilter) ([]*domain.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]*domain.Task, 0)
+	for _, task := range r.tasks {
+		if !filter.Matches(task) {
+			continue
+		}
+		list = append(list, task.Clone())
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.Before(list[j].CreatedAt)
+		}
+		return list[i].ID < list[j].ID
+	})
+
+	return list, nil
 }
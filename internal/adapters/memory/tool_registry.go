@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// ToolRegistry is an in-memory implementation of the ports.ToolRegistry interface.
+// It keeps registered tools and their metadata in a thread-safe map.
+// Tools must be registered at startup before the orchestrator begins planning.
+type ToolRegistry struct {
+	mu    sync.RWMutex
+	tools map[string]domain.Tool
+	meta  map[string]domain.ToolMetadata
+}
+
+// NewToolRegistry creates a new, empty in-memory tool registry.
+// Purpose: Factory function for creating the in-memory tool registry adapter.
+//          Returns the concrete type so callers can Register tools during wiring.
+// Inputs: None
+// Outputs:
+//   - *ToolRegistry: Initialized registry ready for tool registration
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{
+		tools: make(map[string]domain.Tool),
+		meta:  make(map[string]domain.ToolMetadata),
+	}
+}
+
+// Register adds a tool and its metadata to the registry.
+// Purpose: Makes a tool available for planning and execution.
+//          The metadata name is always taken from the tool itself.
+// Inputs:
+//   - tool: The tool implementation to register
+//   - meta: Descriptive metadata (category, risk level) used by planners and the UI
+// Outputs:
+//   - error: Returns error if tool is nil, has an empty name, or is already registered
+func (r *ToolRegistry) Register(tool domain.Tool, meta domain.ToolMetadata) error {
+	if tool == nil {
+		return fmt.Errorf("tool cannot be nil")
+	}
+	name := tool.Name()
+	if name == "" {
+		return fmt.Errorf("tool name cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tools[name]; exists {
+		return fmt.Errorf("tool already registered: %s", name)
+	}
+
+	meta.Name = name
+	if meta.Description == "" {
+		meta.Description = tool.Description()
+	}
+	r.tools[name] = tool
+	r.meta[name] = meta
+
+	return nil
+}
+
+// GetTool retrieves a tool by its unique name.
+// Purpose: Provides access to a specific tool for execution.
+// Inputs:
+//   - name: Unique name of the tool
+// Outputs:
+//   - domain.Tool: The registered tool
+//   - error: Returns error if no tool with that name is registered
+func (r *ToolRegistry) GetTool(name string) (domain.Tool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tool, exists := r.tools[name]
+	if !exists {
+		return nil, fmt.Errorf("tool not found: %s", name)
+	}
+
+	return tool, nil
+}
+
+// ListTools returns metadata for all registered tools sorted by name.
+// Purpose: Enables tool discovery for planning and UI purposes.
+// Inputs: None
+// Outputs:
+//   - []domain.ToolMetadata: Metadata of every registered tool
+func (r *ToolRegistry) ListTools() []domain.ToolMetadata {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]domain.ToolMetadata, 0, len(r.meta))
+	for _, meta := range r.meta {
+		list = append(list, meta)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list
+}
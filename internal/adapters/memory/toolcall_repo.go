@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// ToolCallRepository is an in-memory implementation of the ports.ToolCallRepository interface.
+// It keeps tool calls in an append-only slice in the order they were saved.
+// All data is lost when the application stops (non-persistent).
+type ToolCallRepository struct {
+	mu    sync.RWMutex
+	calls []*domain.ToolCall
+}
+
+// NewToolCallRepository creates a new in-memory tool call repository.
+// Purpose: Factory function for creating the in-memory tool call log adapter.
+// Inputs: None
+// Outputs:
+//   - ports.ToolCallRepository: Initialized repository ready for use
+func NewToolCallRepository() ports.ToolCallRepository {
+	return &ToolCallRepository{}
+}
+
+// SaveToolCall appends a tool call to the log.
+// Purpose: Stores a copy of the call with thread-safe access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - call: The tool call to save (must have a valid ID and TaskID)
+// Outputs:
+//   - error: Returns error if call is nil or has an empty ID or TaskID
+func (r *ToolCallRepository) SaveToolCall(ctx context.Context, call *domain.ToolCall) error {
+	if call == nil {
+		return fmt.Errorf("tool call cannot be nil")
+	}
+	if call.ID == "" {
+		return fmt.Errorf("tool call ID cannot be empty")
+	}
+	if call.TaskID == "" {
+		return fmt.Errorf("tool call task ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	callCopy := *call
+	r.calls = append(r.calls, &callCopy)
+
+	return nil
+}
+
+// ListToolCalls returns copies of the calls matching the filter in the order they were saved.
+// Purpose: Feeds task tool call listings and cross-task queries.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - filter: Criteria to match (empty fields match everything); Limit keeps the most recent calls
+// Outputs:
+//   - []*domain.ToolCall: Matching calls (saved with the clock's time, so oldest first)
+//   - error: Always returns nil (this implementation cannot fail)
+func (r *ToolCallRepository) ListToolCalls(ctx context.Context, filter domain.ToolCallFilter) ([]*domain.ToolCall, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]*domain.ToolCall, 0)
+	for _, call := range r.calls {
+		if filter.Matches(call) {
+			callCopy := *call
+			list = append(list, &callCopy)
+		}
+	}
+	if filter.Limit > 0 && len(list) > filter.Limit {
+		list = list[len(list)-filter.Limit:]
+	}
+
+	return list, nil
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// UsageRepository is an in-memory implementation of the ports.UsageRepository interface.
+// It keeps usage records in an append-only slice in the order they were saved.
+// All data is lost when the application stops (non-persistent).
+type UsageRepository struct {
+	mu      sync.RWMutex
+	records []*domain.UsageRecord
+}
+
+// NewUsageRepository creates a new in-memory usage repository.
+// Purpose: Factory function for creating the in-memory usage ledger adapter.
+// Inputs: None
+// Outputs:
+//   - ports.UsageRepository: Initialized repository ready for use
+func NewUsageRepository() ports.UsageRepository {
+	return &UsageRepository{}
+}
+
+// SaveUsage appends a usage record to the ledger.
+// Purpose: Stores a copy of the record with thread-safe access.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - record: The usage record to save (must have a valid ID and TaskID)
+// Outputs:
+//   - error: Returns error if record is nil or has an empty ID or TaskID
+func (r *UsageRepository) SaveUsage(ctx context.Context, record *domain.UsageRecord) error {
+	if record == nil {
+		return fmt.Errorf("usage record cannot be nil")
+	}
+	if record.ID == "" {
+		return fmt.Errorf("usage record ID cannot be empty")
+	}
+	if record.TaskID == "" {
+		return fmt.Errorf("usage record task ID cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recordCopy := *record
+	r.records = append(r.records, &recordCopy)
+
+	return nil
+}
+
+// ListUsage returns copies of all records matching the filter in the order they were saved.
+// Purpose: Feeds task usage totals and usage reports.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused in this implementation)
+//   - filter: Criteria to match (empty fields match everything)
+// Outputs:
+//   - []*domain.UsageRecord: Matching records (saved with the clock's time, so oldest first)
+//   - error: Always returns nil (this implementation cannot fail)
+func (r *UsageRepository) ListUsage(ctx context.Context, filter domain.UsageFilter) ([]*domain.UsageRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]*domain.UsageRecord, 0)
+	for _, record := range r.records {
+		if filter.Matches(record) {
+			recordCopy := *record
+			list = append(list, &recordCopy)
+		}
+	}
+
+	return list, nil
+}
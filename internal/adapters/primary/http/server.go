@@ -1,19 +1,29 @@
 package http
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/JAROBOTAI/jaro/internal/config"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
 	"github.com/JAROBOTAI/jaro/internal/core/ports"
+	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
 )
 
 // Server is the HTTP adapter that exposes the orchestrator through REST API.
-// It follows Hexagonal Architecture by depending only on the Orchestrator port interface.
+// It follows Hexagonal Architecture by depending only on the Orchestrator and Scheduler port interfaces.
 // This is a Primary Adapter (driving side) that receives external requests.
 type Server struct {
 	orchestrator ports.Orchestrator
+	scheduler    ports.Scheduler
 	config       *config.Config
 }
 
@@ -21,12 +31,14 @@ type Server struct {
 // Purpose: Factory function for creating the HTTP API adapter with dependency injection.
 // Inputs:
 //   - orch: Implementation of the Orchestrator port for handling business logic
+//   - sched: Implementation of the Scheduler port for schedule management
 //   - cfg: Configuration settings for server limits, timeouts, and security
 // Outputs:
 //   - *Server: Initialized HTTP server ready to handle requests
-func NewServer(orch ports.Orchestrator, cfg *config.Config) *Server {
+func NewServer(orch ports.Orchestrator, sched ports.Scheduler, cfg *config.Config) *Server {
 	return &Server{
 		orchestrator: orch,
+		scheduler:    sched,
 		config:       cfg,
 	}
 }
@@ -52,6 +64,47 @@ func (s *Server) Run(addr string) error {
 	// Task management endpoints
 	router.POST("/tasks", s.createTaskHandler)
 	router.GET("/tasks/:id", s.getTaskStatusHandler)
+	router.GET("/tasks/:id/plan", s.getTaskPlanHandler)
+	router.GET("/tasks/:id/plans", s.getTaskPlansHandler)
+	router.GET("/tasks/:id/children", s.getTaskChildrenHandler)
+	router.GET("/tasks/:id/usage", s.getTaskUsageHandler)
+	router.GET("/tasks/:id/tool-calls", s.getTaskToolCallsHandler)
+	router.GET("/tasks/:id/events", s.streamTaskEventsHandler)
+	router.GET("/tasks/:id/steps/:stepId/result", s.getStepResultHandler)
+	router.POST("/tasks/:id/cancel", s.cancelTaskHandler)
+	router.PUT("/tasks/:id/budget", s.updateTaskBudgetHandler)
+
+	// Artifact endpoints
+	router.POST("/tasks/:id/artifacts", s.uploadArtifactHandler)
+	router.GET("/tasks/:id/artifacts", s.listArtifactsHandler)
+	router.GET("/tasks/:id/artifacts/:name", s.downloadArtifactHandler)
+	router.DELETE("/tasks/:id/artifacts/:name", s.deleteArtifactHandler)
+
+	// Approval endpoints
+	router.GET("/approvals", s.listApprovalsHandler)
+	router.GET("/tasks/:id/approvals", s.getTaskApprovalsHandler)
+	router.POST("/tasks/:id/steps/:stepId/approval", s.submitApprovalHandler)
+
+	// Schedule endpoints
+	router.POST("/schedules", s.createScheduleHandler)
+	router.GET("/schedules", s.listSchedulesHandler)
+	router.GET("/schedules/:id", s.getScheduleHandler)
+	router.POST("/schedules/:id/pause", s.pauseScheduleHandler)
+	router.POST("/schedules/:id/resume", s.resumeScheduleHandler)
+	router.DELETE("/schedules/:id", s.deleteScheduleHandler)
+
+	// Agent endpoints
+	router.GET("/agents", s.listAgentsHandler)
+
+	// Tool call endpoints
+	router.GET("/tool-calls", s.listToolCallsHandler)
+
+	// Usage endpoints
+	router.GET("/usage", s.getUsageHandler)
+
+	// Budget endpoints
+	router.GET("/budgets/:userId", s.getUserBudgetHandler)
+	router.PUT("/budgets/:userId", s.setUserBudgetHandler)
 
 	// Start server
 	return router.Run(addr)
@@ -72,16 +125,28 @@ func (s *Server) healthCheckHandler(c *gin.Context) {
 
 // CreateTaskRequest represents the expected JSON payload for creating a task.
 type CreateTaskRequest struct {
-	Input  string `json:"input" binding:"required"`
-	UserID string `json:"user_id" binding:"required"`
+	Input    string     `json:"input" binding:"required"`
+	UserID   string     `json:"user_id" binding:"required"`
+	Priority string     `json:"priority"` // LOW, NORMAL (default), HIGH or URGENT
+	Deadline *time.Time `json:"deadline"` // Optional RFC 3339 time by which the task must finish
+	Timeout  string     `json:"timeout"`  // Optional Go duration (e.g. "30m"); the earlier of deadline and timeout wins
+
+	TargetAgent string `json:"target_agent"` // Optional agent (see GET /agents); routed from the input when empty
+
+	MaxCostUSD float64 `json:"max_cost_usd"` // Optional LLM cost limit of the task in USD (0 = unlimited)
+	MaxTokens  int64   `json:"max_tokens"`   // Optional LLM token limit of the task (0 = unlimited)
 }
 
 // createTaskHandler handles POST /tasks requests to create new tasks.
-// Purpose: Receives user input, creates a task via orchestrator, and returns task details.
+// Purpose: Receives user input, queues a task via orchestrator, and returns task details
+//          without waiting for execution. Clients poll GET /tasks/:id for progress.
 //          This is the primary entry point for submitting work to the JARO system.
+//          Requests repeated with the same Idempotency-Key header (per user_id) get the
+//          original task back instead of creating a duplicate.
 // Inputs:
-//   - c: Gin context containing request body with Input and UserID
-// Outputs: JSON response with task_id and status (201 Created) or error (400/500)
+//   - c: Gin context containing request body with Input, UserID and optional Priority,
+//        Deadline, Timeout and TargetAgent, and an optional Idempotency-Key header
+// Outputs: JSON response with task_id, status, priority and deadline (202 Accepted) or error (400/409/422/500/503)
 func (s *Server) createTaskHandler(c *gin.Context) {
 	var req CreateTaskRequest
 
@@ -94,9 +159,86 @@ func (s *Server) createTaskHandler(c *gin.Context) {
 		return
 	}
 
-	// Call orchestrator to create task
-	task, err := s.orchestrator.StartTask(c.Request.Context(), req.Input, req.UserID)
+	// Call orchestrator to create and queue the task
+	opts := domain.TaskOptions{
+		Priority:    domain.TaskPriority(strings.ToUpper(req.Priority)),
+		TargetAgent: req.TargetAgent,
+		Budget:      domain.BudgetLimit{MaxCostUSD: req.MaxCostUSD, MaxTokens: req.MaxTokens},
+
+		IdempotencyKey: c.GetHeader("Idempotency-Key"),
+	}
+	if req.Deadline != nil {
+		opts.Deadline = *req.Deadline
+	}
+	if req.Timeout != "" {
+		timeout, err := time.ParseDuration(req.Timeout)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid timeout",
+				"details": err.Error(),
+			})
+			return
+		}
+		opts.Timeout = timeout
+	}
+	task, err := s.orchestrator.StartTask(c.Request.Context(), req.Input, req.UserID, opts)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPriority) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid priority",
+				"details": "priority must be one of: LOW, NORMAL, HIGH, URGENT",
+			})
+			return
+		}
+
+		if errors.Is(err, domain.ErrUnknownAgent) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "unknown agent",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidDeadline) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid deadline",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidBudget) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid budget",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		if errors.Is(err, domain.ErrIdempotencyKeyReused) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error": "idempotency key reused with a different request",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		if errors.Is(err, domain.ErrIdempotencyKeyInUse) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "a request with this idempotency key is still in progress, retry later",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		if errors.Is(err, domain.ErrQueueFull) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "task queue is full, retry later",
+				"details": err.Error(),
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "failed to create task",
 			"details": err.Error(),
@@ -104,19 +246,25 @@ func (s *Server) createTaskHandler(c *gin.Context) {
 		return
 	}
 
-	// Return successful response
-	c.JSON(http.StatusCreated, gin.H{
+	// Return accepted response
+	response := gin.H{
 		"task_id": task.ID,
 		"status": task.Status,
+		"priority": task.Priority,
 		"created_at": task.CreatedAt,
 		"user_id": task.UserID,
 		"input": task.Input,
-	})
+	}
+	if !task.Deadline.IsZero() {
+		response["deadline"] = task.Deadline
+	}
+	c.JSON(http.StatusAccepted, response)
 }
 
 // getTaskStatusHandler handles GET /tasks/:id requests to retrieve task status.
 // Purpose: Allows clients to query the current state and progress of a task.
-//          Returns the complete task object including status, artifacts, and metadata.
+//          Returns the complete task object including status, artifacts, and metadata,
+//          plus queue_position while the task is waiting for a worker.
 // Inputs:
 //   - c: Gin context with task ID in URL parameter (:id)
 // Outputs: JSON response with full task object (200 OK) or error (404/500)
@@ -154,3 +302,1241 @@ func (s *Server) getTaskStatusHandler(c *gin.Context) {
 	// Return full task object
 	c.JSON(http.StatusOK, task)
 }
+
+// getTaskPlanHandler handles GET /tasks/:id/plan requests to retrieve a task's plan.
+// Purpose: Shows clients the steps the agent intends to run and the progress of each step.
+// Inputs:
+//   - c: Gin context with task ID in URL parameter (:id)
+// Outputs: JSON response with the plan object (200 OK) or error (404/500)
+func (s *Server) getTaskPlanHandler(c *gin.Context) {
+	taskID := c.Param("id")
+	if taskID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "task_id is required",
+		})
+		return
+	}
+
+	plan, err := s.orchestrator.GetTaskPlan(c.Request.Context(), taskID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "plan not found",
+				"task_id": taskID,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get task plan",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// getTaskPlansHandler handles GET /tasks/:id/plans requests to retrieve a task's plan revisions.
+// Purpose: Shows every plan the agent produced, including revisions made after failed verification.
+// Inputs:
+//   - c: Gin context with task ID in URL parameter (:id)
+// Outputs: JSON response with the plans ordered by revision (200 OK) or error (404/500)
+func (s *Server) getTaskPlansHandler(c *gin.Context) {
+	taskID := c.Param("id")
+	if taskID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "task_id is required",
+		})
+		return
+	}
+
+	plans, err := s.orchestrator.GetTaskPlans(c.Request.Context(), taskID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "task not found",
+				"task_id": taskID,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get task plans",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task_id": taskID,
+		"plans": plans,
+		"count": len(plans),
+	})
+}
+
+// getTaskChildrenHandler handles GET /tasks/:id/children requests to retrieve a task's sub-tasks.
+// Purpose: Lets clients walk a task tree; each child can be inspected through the task endpoints.
+// Inputs:
+//   - c: Gin context with task ID in URL parameter (:id)
+// Outputs: JSON response with the direct children in creation order (200 OK) or error (404/500)
+func (s *Server) getTaskChildrenHandler(c *gin.Context) {
+	taskID := c.Param("id")
+	if taskID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "task_id is required",
+		})
+		return
+	}
+
+	children, err := s.orchestrator.GetTaskChildren(c.Request.Context(), taskID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "task not found",
+				"task_id": taskID,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get task children",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task_id": taskID,
+		"children": children,
+		"count": len(children),
+	})
+}
+
+// getStepResultHandler handles GET /tasks/:id/steps/:stepId/result requests.
+// Purpose: Returns the full output of an executed step as plain text, including outputs
+//          too large to keep inline in the plan. Supports Range requests, so large
+//          outputs can be fetched in parts. X-Step-Success and X-Step-Error-Code carry
+//          the outcome of the step.
+// Inputs:
+//   - c: Gin context with task ID (:id), step ID (:stepId) and optional query parameter
+//        plan_id (default: the task's current plan)
+// Outputs: The step output (200 OK / 206 Partial Content) or error (404/416/500)
+func (s *Server) getStepResultHandler(c *gin.Context) {
+	taskID := c.Param("id")
+	stepID := c.Param("stepId")
+
+	result, output, err := s.orchestrator.GetStepOutput(c.Request.Context(), taskID, stepID, c.Query("plan_id"))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "step result not found",
+				"task_id": taskID,
+				"step_id": stepID,
+				"details": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get step result",
+			"details": err.Error(),
+		})
+		return
+	}
+	defer output.Close()
+
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Header("X-Step-Success", strconv.FormatBool(result.Success))
+	if result.ErrorCode != "" {
+		c.Header("X-Step-Error-Code", result.ErrorCode)
+	}
+	http.ServeContent(c.Writer, c.Request, "", time.Time{}, output)
+}
+
+// streamTaskEventsHandler handles GET /tasks/:id/events requests with a Server-Sent Events stream.
+// Purpose: Pushes task progress (status changes, step start/finish, approval requests and LLM
+//          token deltas) as it happens, so clients need not poll GET /tasks/:id. Each event
+//          carries its Seq as SSE id; a reconnecting client sends the last one in Last-Event-ID
+//          and receives the buffered events it missed. The stream ends once the task finishes.
+// Inputs:
+//   - c: Gin context with task ID in URL parameter (:id) and optional Last-Event-ID header
+//        (or last_event_id query parameter for clients that cannot set headers)
+// Outputs: text/event-stream of task events (200 OK), 204 No Content if the task has finished
+//          and nothing is left to replay (stops EventSource reconnects), or error (400/404/500)
+func (s *Server) streamTaskEventsHandler(c *gin.Context) {
+	taskID := c.Param("id")
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var afterSeq int64
+	if lastEventID != "" {
+		seq, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || seq < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid Last-Event-ID",
+				"details": "must be the id of a received event",
+			})
+			return
+		}
+		afterSeq = seq
+	}
+
+	// Returning ends the subscription, including when writing to a disconnected client fails
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	subscription, err := s.orchestrator.SubscribeTaskEvents(ctx, taskID, afterSeq)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "task not found",
+				"task_id": taskID,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to subscribe to task events",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if subscription.Closed && len(subscription.Backlog) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Keep reverse proxies (nginx) from buffering the stream
+	c.Status(http.StatusOK)
+
+	for _, event := range subscription.Backlog {
+		if err := writeTaskEvent(c.Writer, event); err != nil {
+			return
+		}
+	}
+	if err := flushEvents(c.Writer); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(s.config.SSEHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-subscription.Events:
+			// Closed when the task finished or this client fell behind; EventSource reconnects
+			// with Last-Event-ID and resumes from the buffer
+			if !ok {
+				return
+			}
+			if err := writeTaskEvent(c.Writer, event); err != nil {
+				return
+			}
+			if err := flushEvents(c.Writer); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			// A comment line keeps idle connections open through proxies
+			if _, err := io.WriteString(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := flushEvents(c.Writer); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// writeTaskEvent writes a task event as an SSE message named after its type with its Seq as id.
+// It returns the write error, e.g. once the client has disconnected.
+func writeTaskEvent(w gin.ResponseWriter, event *domain.TaskEvent) error {
+	return sse.Encode(w, sse.Event{
+		Id:    strconv.FormatInt(event.Seq, 10),
+		Event: event.Type,
+		Data:  event,
+	})
+}
+
+// flushEvents sends the buffered SSE output to the client.
+// gin's Flush swallows connection errors, so the flush goes through the wrapped
+// http.ResponseWriter to report them.
+func flushEvents(w gin.ResponseWriter) error {
+	w.WriteHeaderNow()
+	if wrapper, ok := w.(interface{ Unwrap() http.ResponseWriter }); ok {
+		return http.NewResponseController(wrapper.Unwrap()).Flush()
+	}
+	w.Flush()
+	return nil
+}
+
+// getTaskUsageHandler handles GET /tasks/:id/usage requests to retrieve a task's LLM usage.
+// Purpose: Shows the tokens and cost of a task broken down by step, phase and model.
+// Inputs:
+//   - c: Gin context with task ID in URL parameter (:id)
+// Outputs: JSON response with task_id and usage summary (200 OK) or error (404/500)
+func (s *Server) getTaskUsageHandler(c *gin.Context) {
+	taskID := c.Param("id")
+	if taskID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "task_id is required",
+		})
+		return
+	}
+
+	usage, err := s.orchestrator.GetTaskUsage(c.Request.Context(), taskID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "task not found",
+				"task_id": taskID,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get task usage",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task_id": taskID,
+		"usage": usage,
+	})
+}
+
+// getUsageHandler handles GET /usage requests to aggregate LLM usage across tasks.
+// Purpose: Reports token usage and cost per user and time range (e.g., a calendar month).
+// Inputs:
+//   - c: Gin context with optional query parameters user_id, from (inclusive) and to
+//        (exclusive); times are RFC 3339 or YYYY-MM-DD (UTC midnight)
+// Outputs: JSON response with the filter and usage summary (200 OK) or error (400/500)
+func (s *Server) getUsageHandler(c *gin.Context) {
+	filter := domain.UsageFilter{UserID: c.Query("user_id")}
+
+	for _, bound := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		value := c.Query(bound.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := parseUsageTime(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid " + bound.name,
+				"details": "must be an RFC 3339 time or a YYYY-MM-DD date",
+			})
+			return
+		}
+		*bound.target = parsed
+	}
+
+	usage, err := s.orchestrator.GetUsage(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTimeRange) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid time range",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get usage",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id": filter.UserID,
+		"from": c.Query("from"),
+		"to": c.Query("to"),
+		"usage": usage,
+	})
+}
+
+// defaultToolCallLimit is the number of most recent calls GET /tool-calls returns without a limit parameter.
+const defaultToolCallLimit = 100
+
+// getTaskToolCallsHandler handles GET /tasks/:id/tool-calls requests.
+// Purpose: Returns the exact inputs, outputs and errors of every tool a task's steps invoked,
+//          the first thing to inspect when an agent misbehaves.
+// Inputs:
+//   - c: Gin context with task ID in URL parameter (:id)
+// Outputs: JSON response with tool_calls array, oldest first (200 OK) or error (404/500)
+func (s *Server) getTaskToolCallsHandler(c *gin.Context) {
+	taskID := c.Param("id")
+
+	calls, err := s.orchestrator.GetTaskToolCalls(c.Request.Context(), taskID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "task not found",
+				"task_id": taskID,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get task tool calls",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task_id": taskID,
+		"tool_calls": calls,
+		"count": len(calls),
+	})
+}
+
+// listToolCallsHandler handles GET /tool-calls requests for cross-task analysis.
+// Purpose: Lists tool calls across tasks, e.g., every failed call of a tool since a deploy.
+// Inputs:
+//   - c: Gin context with optional query parameters tool, status (SUCCEEDED, FAILED, REJECTED),
+//        since (RFC 3339 or YYYY-MM-DD, inclusive), task_id and limit (most recent calls,
+//        default 100)
+// Outputs: JSON response with tool_calls array, oldest first (200 OK) or error (400/500)
+func (s *Server) listToolCallsHandler(c *gin.Context) {
+	filter := domain.ToolCallFilter{
+		TaskID:   c.Query("task_id"),
+		ToolName: c.Query("tool"),
+		Status:   domain.ToolCallStatus(strings.ToUpper(c.Query("status"))),
+		Limit:    defaultToolCallLimit,
+	}
+
+	if value := c.Query("since"); value != "" {
+		since, err := parseUsageTime(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid since",
+				"details": "must be an RFC 3339 time or a YYYY-MM-DD date",
+			})
+			return
+		}
+		filter.Since = since
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid limit",
+				"details": "limit must be a positive integer",
+			})
+			return
+		}
+		filter.Limit = limit
+	}
+
+	calls, err := s.orchestrator.ListToolCalls(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToolCallStatus) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid status filter",
+				"details": "status must be one of: SUCCEEDED, FAILED, REJECTED",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to list tool calls",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tool_calls": calls,
+		"count": len(calls),
+	})
+}
+
+// SetUserBudgetRequest represents the expected JSON payload for setting a user budget.
+type SetUserBudgetRequest struct {
+	AdminID string             `json:"admin_id" binding:"required"` // Administrator making the change
+	Daily   domain.BudgetLimit `json:"daily"`                       // max_cost_usd / max_tokens per UTC day (0 = unlimited)
+	Monthly domain.BudgetLimit `json:"monthly"`                     // max_cost_usd / max_tokens per UTC month (0 = unlimited)
+}
+
+// setUserBudgetHandler handles PUT /budgets/:userId requests.
+// Purpose: Sets a user's daily and monthly LLM spend caps; paused tasks of the user that
+//          fit the new budget resume.
+// Inputs:
+//   - c: Gin context with user ID (:userId) and body with admin_id, daily and monthly limits
+// Outputs: JSON response with the stored budget (200 OK) or error (400/403/500); 403 if
+//          admin_id is not one of the configured BUDGET_ADMINS
+func (s *Server) setUserBudgetHandler(c *gin.Context) {
+	var req SetUserBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	budget, err := s.orchestrator.SetUserBudget(c.Request.Context(), domain.UserBudget{
+		UserID:  c.Param("userId"),
+		Daily:   req.Daily,
+		Monthly: req.Monthly,
+	}, req.AdminID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotBudgetAdmin) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "not allowed to change budgets",
+				"details": err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidBudget) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid budget",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to set budget",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// getUserBudgetHandler handles GET /budgets/:userId requests.
+// Purpose: Shows a user's budget with the spend of the current UTC day and month.
+// Inputs:
+//   - c: Gin context with user ID (:userId)
+// Outputs: JSON response with the budget status (200 OK) or error (500)
+func (s *Server) getUserBudgetHandler(c *gin.Context) {
+	status, err := s.orchestrator.GetUserBudget(c.Request.Context(), c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get budget",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// UpdateTaskBudgetRequest represents the expected JSON payload for changing a task budget.
+type UpdateTaskBudgetRequest struct {
+	AdminID    string  `json:"admin_id" binding:"required"` // Administrator making the change
+	MaxCostUSD float64 `json:"max_cost_usd"`                // New LLM cost limit in USD (0 = unlimited)
+	MaxTokens  int64   `json:"max_tokens"`                  // New LLM token limit (0 = unlimited)
+}
+
+// updateTaskBudgetHandler handles PUT /tasks/:id/budget requests.
+// Purpose: Raises (or lowers) the budget of a task paused in BUDGET_EXCEEDED; the task
+//          resumes if the new budget allows.
+// Inputs:
+//   - c: Gin context with task ID (:id) and body with admin_id and the new limits
+// Outputs: JSON response with the updated task (200 OK) or error (400/403/404/409/500); 403 if
+//          admin_id is not one of the configured BUDGET_ADMINS
+func (s *Server) updateTaskBudgetHandler(c *gin.Context) {
+	taskID := c.Param("id")
+
+	var req UpdateTaskBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	budget := domain.BudgetLimit{MaxCostUSD: req.MaxCostUSD, MaxTokens: req.MaxTokens}
+	task, err := s.orchestrator.UpdateTaskBudget(c.Request.Context(), taskID, budget, req.AdminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotBudgetAdmin):
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "not allowed to change budgets",
+				"details": err.Error(),
+			})
+		case errors.Is(err, domain.ErrInvalidBudget):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid budget",
+				"details": err.Error(),
+			})
+		case errors.Is(err, domain.ErrTaskFinished),
+			errors.Is(err, domain.ErrTaskNotOverBudget):
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "task is not paused over budget",
+				"details": err.Error(),
+			})
+		case strings.Contains(err.Error(), "not found"):
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "task not found",
+				"task_id": taskID,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to update task budget",
+				"details": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// parseUsageTime parses a usage query bound given as an RFC 3339 time or a YYYY-MM-DD date.
+func parseUsageTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// multipartOverhead is the room left in upload requests for form fields and part headers
+// beyond the file itself.
+const multipartOverhead = 1 << 20
+
+// sniffLength is the number of leading bytes http.DetectContentType considers.
+const sniffLength = 512
+
+// uploadArtifactHandler handles POST /tasks/:id/artifacts multipart uploads.
+// Purpose: Attaches a file (e.g., a PDF) to a task. The content type is sniffed from the
+//          file's first bytes rather than trusted from the client, and must be one of the
+//          configured AllowedMIMETypes; the file may not exceed MaxFileUploadSize.
+// Inputs:
+//   - c: Gin context with task ID (:id) and multipart form fields file (required),
+//        user_id (required) and name (optional, defaults to the uploaded file name)
+// Outputs: JSON response with the stored artifact (201 Created) or error (400/404/413/415/500)
+func (s *Server) uploadArtifactHandler(c *gin.Context) {
+	taskID := c.Param("id")
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, s.config.MaxFileUploadSize+multipartOverhead)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": "file too large",
+				"max_size": s.config.MaxFileUploadSize,
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "file form field is required",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.PostForm("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "user_id form field is required",
+		})
+		return
+	}
+	if header.Size > s.config.MaxFileUploadSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": "file too large",
+			"max_size": s.config.MaxFileUploadSize,
+		})
+		return
+	}
+
+	name := c.PostForm("name")
+	if name == "" {
+		name = header.Filename
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to read upload",
+			"details": err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to read upload",
+			"details": err.Error(),
+		})
+		return
+	}
+	head = head[:n]
+
+	contentType := artifactContentType(head, header.Header.Get("Content-Type"))
+	if !s.isAllowedMIMEType(contentType) {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"error": "unsupported media type",
+			"content_type": contentType,
+			"allowed": s.config.AllowedMIMETypes,
+		})
+		return
+	}
+
+	content := io.MultiReader(bytes.NewReader(head), file)
+	artifact, err := s.orchestrator.AddArtifact(c.Request.Context(), taskID, name, contentType, content, userID)
+	if err != nil {
+		s.respondArtifactError(c, taskID, "failed to store artifact", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, artifact)
+}
+
+// listArtifactsHandler handles GET /tasks/:id/artifacts requests.
+// Purpose: Lists the files attached to a task with their types, sizes and checksums.
+// Inputs:
+//   - c: Gin context with task ID in URL parameter (:id)
+// Outputs: JSON response with task_id, artifacts and count (200 OK) or error (404/500)
+func (s *Server) listArtifactsHandler(c *gin.Context) {
+	taskID := c.Param("id")
+
+	artifacts, err := s.orchestrator.ListArtifacts(c.Request.Context(), taskID)
+	if err != nil {
+		s.respondArtifactError(c, taskID, "failed to list artifacts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task_id": taskID,
+		"artifacts": artifacts,
+		"count": len(artifacts),
+	})
+}
+
+// downloadArtifactHandler handles GET /tasks/:id/artifacts/:name requests.
+// Purpose: Streams an artifact to the client as an attachment without buffering it in memory.
+//          The ETag header carries the artifact's checksum.
+// Inputs:
+//   - c: Gin context with task ID (:id) and artifact name (:name)
+// Outputs: The artifact content with its stored content type (200 OK) or JSON error (400/404/500)
+func (s *Server) downloadArtifactHandler(c *gin.Context) {
+	taskID := c.Param("id")
+
+	artifact, content, err := s.orchestrator.GetArtifact(c.Request.Context(), taskID, c.Param("name"))
+	if err != nil {
+		s.respondArtifactError(c, taskID, "failed to get artifact", err)
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, artifact.Size, artifact.ContentType, content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": artifact.Name}),
+		"ETag": strconv.Quote(artifact.Checksum),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// deleteArtifactHandler handles DELETE /tasks/:id/artifacts/:name requests.
+// Purpose: Removes an artifact from a task.
+// Inputs:
+//   - c: Gin context with task ID (:id), artifact name (:name) and required query parameter user_id
+// Outputs: JSON confirmation (200 OK) or error (400/404/500)
+func (s *Server) deleteArtifactHandler(c *gin.Context) {
+	taskID := c.Param("id")
+	name := c.Param("name")
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "user_id query parameter is required",
+		})
+		return
+	}
+
+	if err := s.orchestrator.DeleteArtifact(c.Request.Context(), taskID, name, userID); err != nil {
+		s.respondArtifactError(c, taskID, "failed to delete artifact", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task_id": taskID,
+		"name": name,
+		"deleted": true,
+	})
+}
+
+// respondArtifactError maps artifact errors to HTTP status codes.
+func (s *Server) respondArtifactError(c *gin.Context, taskID string, failure string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidArtifactName):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid artifact name",
+			"details": err.Error(),
+		})
+	case errors.Is(err, domain.ErrArtifactNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "artifact not found",
+			"task_id": taskID,
+			"name": c.Param("name"),
+		})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "task not found",
+			"task_id": taskID,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": failure,
+			"details": err.Error(),
+		})
+	}
+}
+
+// artifactContentType determines the media type of an upload from its first bytes.
+// Sniffing cannot tell text formats apart, so a text/plain result is refined by the
+// declared type of the part if that is a text type or JSON (e.g., text/csv). Binary
+// types are never taken from the client.
+func artifactContentType(head []byte, declared string) string {
+	sniffed, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream"
+	}
+
+	if sniffed == "text/plain" {
+		if refined, _, err := mime.ParseMediaType(declared); err == nil &&
+			(strings.HasPrefix(refined, "text/") || refined == "application/json") {
+			return refined
+		}
+	}
+
+	return sniffed
+}
+
+// isAllowedMIMEType reports whether uploads of a media type are allowed by AllowedMIMETypes.
+func (s *Server) isAllowedMIMEType(contentType string) bool {
+	for _, allowed := range s.config.AllowedMIMETypes {
+		if strings.EqualFold(allowed, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// listAgentsHandler handles GET /agents requests to list the available agents.
+// Purpose: Lets clients discover which agents they can name as target_agent.
+// Inputs:
+//   - c: Gin context containing request information
+// Outputs: JSON response with agents array (200 OK)
+func (s *Server) listAgentsHandler(c *gin.Context) {
+	agents := s.orchestrator.ListAgents(c.Request.Context())
+
+	c.JSON(http.StatusOK, gin.H{
+		"agents": agents,
+		"count": len(agents),
+	})
+}
+
+// listApprovalsHandler handles GET /approvals requests to list approval requests.
+// Purpose: Gives reviewers an inbox of approvals, optionally filtered by task owner and status.
+// Inputs:
+//   - c: Gin context with optional query parameters user_id and status (OPEN, APPROVED, REJECTED)
+// Outputs: JSON response with approvals array (200 OK) or error (400/500)
+func (s *Server) listApprovalsHandler(c *gin.Context) {
+	filter := domain.ApprovalFilter{
+		UserID: c.Query("user_id"),
+		Status: domain.ApprovalStatus(strings.ToUpper(c.Query("status"))),
+	}
+
+	switch filter.Status {
+	case "", domain.ApprovalStatusOpen, domain.ApprovalStatusApproved, domain.ApprovalStatusRejected:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid status filter",
+			"details": "status must be one of: OPEN, APPROVED, REJECTED",
+		})
+		return
+	}
+
+	approvals, err := s.orchestrator.ListApprovals(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to list approvals",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"approvals": approvals,
+		"count": len(approvals),
+	})
+}
+
+// getTaskApprovalsHandler handles GET /tasks/:id/approvals requests.
+// Purpose: Returns the approval history of a single task.
+// Inputs:
+//   - c: Gin context with task ID in URL parameter (:id)
+// Outputs: JSON response with approvals array (200 OK) or error (404/500)
+func (s *Server) getTaskApprovalsHandler(c *gin.Context) {
+	taskID := c.Param("id")
+
+	approvals, err := s.orchestrator.GetTaskApprovals(c.Request.Context(), taskID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "task not found",
+				"task_id": taskID,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get task approvals",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task_id": taskID,
+		"approvals": approvals,
+	})
+}
+
+// SubmitApprovalRequest represents the expected JSON payload for an approval decision.
+type SubmitApprovalRequest struct {
+	Approved *bool  `json:"approved" binding:"required"`
+	UserID   string `json:"user_id" binding:"required"`
+	Comment  string `json:"comment"`
+}
+
+// submitApprovalHandler handles POST /tasks/:id/steps/:stepId/approval requests.
+// Purpose: Records a reviewer's approve/reject decision and queues the suspended task to resume.
+//          Decisions for tasks that are not waiting, or for a different step, are conflicts.
+// Inputs:
+//   - c: Gin context with task ID (:id), step ID (:stepId) and body with approved, user_id, comment
+// Outputs: JSON response with the updated task (200 OK) or error (400/404/409/500)
+func (s *Server) submitApprovalHandler(c *gin.Context) {
+	taskID := c.Param("id")
+	stepID := c.Param("stepId")
+
+	var req SubmitApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	err := s.orchestrator.HandleApproval(c.Request.Context(), taskID, stepID, *req.Approved, req.UserID, req.Comment)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTaskNotWaitingApproval),
+			errors.Is(err, domain.ErrStepMismatch),
+			errors.Is(err, domain.ErrApprovalNotOpen),
+			errors.Is(err, domain.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "approval conflict",
+				"details": err.Error(),
+			})
+		case strings.Contains(err.Error(), "not found"):
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "task not found",
+				"task_id": taskID,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to handle approval",
+				"details": err.Error(),
+			})
+		}
+		return
+	}
+
+	task, err := s.orchestrator.GetTaskStatus(c.Request.Context(), taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get task status",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// CancelTaskRequest represents the expected JSON payload for canceling a task.
+type CancelTaskRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+// cancelTaskHandler handles POST /tasks/:id/cancel requests.
+// Purpose: Stops a running or suspended task, interrupting the step currently executing.
+// Inputs:
+//   - c: Gin context with task ID (:id) and body with user_id and optional reason
+// Outputs: JSON response with the canceled task (200 OK) or error (400/404/409/500)
+func (s *Server) cancelTaskHandler(c *gin.Context) {
+	taskID := c.Param("id")
+
+	var req CancelTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := s.orchestrator.CancelTask(c.Request.Context(), taskID, req.UserID, req.Reason); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTaskFinished),
+			errors.Is(err, domain.ErrInvalidTransition):
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "task already finished",
+				"details": err.Error(),
+			})
+		case strings.Contains(err.Error(), "not found"):
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "task not found",
+				"task_id": taskID,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "failed to cancel task",
+				"details": err.Error(),
+			})
+		}
+		return
+	}
+
+	task, err := s.orchestrator.GetTaskStatus(c.Request.Context(), taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to get task status",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// CreateScheduleRequest represents the expected JSON payload for creating a schedule.
+// Exactly one of CronExpr or RunAt must be provided.
+type CreateScheduleRequest struct {
+	Input           string     `json:"input" binding:"required"`
+	UserID          string     `json:"user_id" binding:"required"`
+	Priority        string     `json:"priority"`          // LOW, NORMAL (default), HIGH or URGENT
+	CronExpr        string     `json:"cron_expr"`         // e.g., "0 8 * * MON-FRI" or "@daily"
+	RunAt           *time.Time `json:"run_at"`            // RFC 3339 time of a one-shot run
+	Timezone        string     `json:"timezone"`          // IANA zone for cron_expr (default: UTC)
+	MissedRunPolicy string     `json:"missed_run_policy"` // SKIP (default) or CATCH_UP
+}
+
+// createScheduleHandler handles POST /schedules requests.
+// Purpose: Registers a recurring or one-shot schedule that starts tasks for the user.
+// Inputs:
+//   - c: Gin context with body containing input, user_id and cron_expr or run_at
+// Outputs: JSON response with the created schedule (201 Created) or error (400/500)
+func (s *Server) createScheduleHandler(c *gin.Context) {
+	var req CreateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	spec := domain.ScheduleSpec{
+		UserID:          req.UserID,
+		Input:           req.Input,
+		Priority:        domain.TaskPriority(strings.ToUpper(req.Priority)),
+		CronExpr:        req.CronExpr,
+		Timezone:        req.Timezone,
+		MissedRunPolicy: domain.MissedRunPolicy(strings.ToUpper(req.MissedRunPolicy)),
+	}
+	if req.RunAt != nil {
+		spec.RunAt = *req.RunAt
+	}
+
+	schedule, err := s.scheduler.CreateSchedule(c.Request.Context(), spec)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidSchedule) || errors.Is(err, domain.ErrInvalidPriority) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid schedule",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to create schedule",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// listSchedulesHandler handles GET /schedules requests.
+// Purpose: Lists schedules, optionally filtered by owner and status.
+// Inputs:
+//   - c: Gin context with optional query parameters user_id and status (ACTIVE, PAUSED, COMPLETED)
+// Outputs: JSON response with schedules array (200 OK) or error (400/500)
+func (s *Server) listSchedulesHandler(c *gin.Context) {
+	filter := domain.ScheduleFilter{
+		UserID: c.Query("user_id"),
+		Status: domain.ScheduleStatus(strings.ToUpper(c.Query("status"))),
+	}
+
+	switch filter.Status {
+	case "", domain.ScheduleStatusActive, domain.ScheduleStatusPaused, domain.ScheduleStatusCompleted:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid status filter",
+			"details": "status must be one of: ACTIVE, PAUSED, COMPLETED",
+		})
+		return
+	}
+
+	schedules, err := s.scheduler.ListSchedules(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "failed to list schedules",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"schedules": schedules,
+		"count": len(schedules),
+	})
+}
+
+// getScheduleHandler handles GET /schedules/:id requests.
+// Purpose: Returns a schedule with its next and last run.
+// Inputs:
+//   - c: Gin context with schedule ID in URL parameter (:id)
+// Outputs: JSON response with the schedule (200 OK) or error (404/500)
+func (s *Server) getScheduleHandler(c *gin.Context) {
+	scheduleID := c.Param("id")
+
+	schedule, err := s.scheduler.GetSchedule(c.Request.Context(), scheduleID)
+	if err != nil {
+		s.respondScheduleError(c, scheduleID, "failed to get schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// ScheduleActionRequest represents the expected JSON payload for pausing or resuming a schedule.
+type ScheduleActionRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// pauseScheduleHandler handles POST /schedules/:id/pause requests.
+// Purpose: Stops a schedule from starting tasks until it is resumed.
+// Inputs:
+//   - c: Gin context with schedule ID (:id) and body with user_id
+// Outputs: JSON response with the paused schedule (200 OK) or error (400/404/409/500)
+func (s *Server) pauseScheduleHandler(c *gin.Context) {
+	s.scheduleActionHandler(c, s.scheduler.PauseSchedule, "failed to pause schedule")
+}
+
+// resumeScheduleHandler handles POST /schedules/:id/resume requests.
+// Purpose: Reactivates a paused schedule from its next run after now.
+// Inputs:
+//   - c: Gin context with schedule ID (:id) and body with user_id
+// Outputs: JSON response with the resumed schedule (200 OK) or error (400/404/409/500)
+func (s *Server) resumeScheduleHandler(c *gin.Context) {
+	s.scheduleActionHandler(c, s.scheduler.ResumeSchedule, "failed to resume schedule")
+}
+
+// scheduleActionHandler binds the request of a pause/resume call and applies the action.
+func (s *Server) scheduleActionHandler(c *gin.Context, action func(ctx context.Context, scheduleID string, userID string) (*domain.Schedule, error), failure string) {
+	scheduleID := c.Param("id")
+
+	var req ScheduleActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	schedule, err := action(c.Request.Context(), scheduleID, req.UserID)
+	if err != nil {
+		s.respondScheduleError(c, scheduleID, failure, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// deleteScheduleHandler handles DELETE /schedules/:id requests.
+// Purpose: Removes a schedule permanently; tasks it already started keep running.
+// Inputs:
+//   - c: Gin context with schedule ID (:id) and required query parameter user_id
+// Outputs: JSON confirmation (200 OK) or error (400/404/500)
+func (s *Server) deleteScheduleHandler(c *gin.Context) {
+	scheduleID := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "user_id query parameter is required",
+		})
+		return
+	}
+
+	if err := s.scheduler.DeleteSchedule(c.Request.Context(), scheduleID, userID); err != nil {
+		s.respondScheduleError(c, scheduleID, "failed to delete schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"schedule_id": scheduleID,
+		"deleted": true,
+	})
+}
+
+// respondScheduleError maps scheduler errors to HTTP status codes.
+func (s *Server) respondScheduleError(c *gin.Context, scheduleID string, failure string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrScheduleCompleted):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "schedule already completed",
+			"details": err.Error(),
+		})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "schedule not found",
+			"schedule_id": scheduleID,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": failure,
+			"details": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package http
+
+import "testing"
+
+func TestArtifactContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		head     string
+		declared string
+		want     string
+	}{
+		{name: "PDF", head: "%PDF-1.7\n", declared: "application/pdf", want: "application/pdf"},
+		{name: "PNG ignores the declared type", head: "\x89PNG\r\n\x1a\n", declared: "text/plain", want: "image/png"},
+		{name: "binary cannot pose as PDF", head: "\x00\x01\x02\x03", declared: "application/pdf", want: "application/octet-stream"},
+		{name: "text refined to CSV", head: "name,amount\nrent,800\n", declared: "text/csv; charset=utf-8", want: "text/csv"},
+		{name: "text refined to JSON", head: `{"a": 1}`, declared: "application/json", want: "application/json"},
+		{name: "text cannot become binary", head: "plain words", declared: "application/pdf", want: "text/plain"},
+		{name: "missing declared type", head: "plain words", declared: "", want: "text/plain"},
+		{name: "malformed declared type", head: "plain words", declared: "text/;;", want: "text/plain"},
+		{name: "HTML stays HTML", head: "<html><body>hi</body></html>", declared: "text/plain", want: "text/html"},
+		{name: "empty upload", head: "", declared: "text/csv", want: "text/csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := artifactContentType([]byte(tt.head), tt.declared); got != tt.want {
+				t.Errorf("artifactContentType(%q, %q) = %q, want %q", tt.head, tt.declared, got, tt.want)
+			}
+		})
+	}
+}
@@ -4,7 +4,11 @@
 // strategy by keeping all configuration external to code.
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
 
 // Config holds all application configuration settings.
 // Purpose: Centralizes all configurable values to eliminate magic numbers
@@ -30,6 +34,40 @@ type Config struct {
 	// Logging - Log level configuration
 	LogLevel string // Log verbosity: debug, info, warn, error (default: "info")
 
+	// Approvals - Human-in-the-loop configuration
+	ApprovalRejectionPolicy string // Outcome of a rejected approval: SKIP_STEP or FAIL_TASK (default: "FAIL_TASK")
+
+	// Execution - Plan scheduling configuration
+	MaxParallelSteps      int           // Maximum independent plan steps executed concurrently per task (default: 4)
+	MaxSubTaskDepth       int           // Maximum nesting of tasks spawned by SUB_TASK steps; 0 disables sub-tasks (default: 3)
+	WorkerPoolSize        int           // Number of tasks executed concurrently in the background (default: 8)
+	TaskQueueCapacity     int           // Maximum tasks waiting for a worker before submissions are rejected (default: 1000)
+	TaskQueueAging        time.Duration // Wait after which a queued task is promoted one priority level; 0 disables (default: 1m)
+	DefaultTaskTimeout    time.Duration // Deadline of tasks created without one; 0 means none (default: 0)
+	DefaultStepTimeout    time.Duration // Per-attempt timeout of steps that set none; 0 means none (default: 10m)
+	DeadlineCheckInterval time.Duration // How often suspended tasks are checked for a passed deadline (default: 5s)
+
+	// Idempotency - Deduplication of retried task submissions (Idempotency-Key header)
+	IdempotencyKeyTTL time.Duration // How long an idempotency key returns its original task (default: 24h)
+
+	// Scheduling - Cron and one-shot schedules that start tasks
+	SchedulerPollInterval  time.Duration // How often due schedules are checked (default: 15s)
+	ScheduleMisfireGrace   time.Duration // How late a run may still start under the SKIP policy (default: 1m)
+	ScheduleMaxCatchUpRuns int           // Maximum missed runs started per schedule and check under CATCH_UP (default: 10)
+
+	// Verification - Goal checking and replanning before a task is reported DONE
+	MaxReplans            int     // Maximum revised plans requested after failed verification (default: 2)
+	VerifyMinGoalCoverage float64 // Fraction (0-1) of goal keywords the rule verifier requires in outputs; 0 disables (default: 0)
+	VerifyMaxOutputChars  int     // Maximum characters per step output sent to the LLM verifier (default: 2000)
+
+	// Step Retries - Default retry policy applied by the orchestrator around step execution
+	StepMaxAttempts        int           // Total attempts per step including the first (default: 3)
+	StepRetryBackoff       time.Duration // Delay before the first retry (default: 1s)
+	StepRetryMaxBackoff    time.Duration // Upper bound for any retry delay (default: 30s)
+	StepRetryMultiplier    float64       // Exponential backoff growth factor (default: 2.0)
+	StepRetryJitter        float64       // Fraction (0-1) of each delay that is randomized (default: 0.2)
+	StepRetryUnknownErrors bool          // Retry errors not classified as transient/permanent (default: true)
+
 	// Feature Flags - Optional features that can be toggled
 	EnableMetrics bool // Enable Prometheus metrics endpoint (default: false)
 
@@ -39,4 +77,25 @@ type Config struct {
 	DefaultLLMModel  string // Default LLM model to use (default: "gpt-4o-mini")
 	LLMTimeout       time.Duration // Maximum duration for LLM requests (default: 60s)
 	LLMMaxRetries    int    // Maximum retry attempts for LLM failures (default: 3)
+
+	// Accounting - Token usage pricing
+	LLMPrices domain.PriceTable // USD per million prompt/completion tokens by model; must cover DefaultLLMModel
+
+	// Budgets - Default spend caps of users without a stored budget (0 = unlimited)
+	DefaultUserDailyBudgetUSD   float64  // Maximum LLM cost per user and UTC day
+	DefaultUserMonthlyBudgetUSD float64  // Maximum LLM cost per user and UTC month
+	BudgetAdmins                []string // Users allowed to change user and task budgets (default: none, budgets are read-only)
+
+	// Artifacts - Files attached to and produced by tasks (uploads are limited by MaxFileUploadSize and AllowedMIMETypes)
+	ArtifactDir string // Directory of the disk-backed artifact store (default: "data/artifacts")
+
+	// Results - Step outputs too large to keep inline in the plan
+	StepResultInlineMaxBytes int    // Outputs above this size are moved to the result store and previewed inline; 0 keeps all inline (default: 64KB)
+	StepResultPreviewBytes   int    // Maximum size of the inline preview of a moved output; at most StepResultInlineMaxBytes (default: 1KB)
+	ResultDir                string // Directory of the disk-backed result store (default: "data/results")
+
+	// Event Streaming - Live task progress over Server-Sent Events (GET /tasks/:id/events)
+	TaskEventBufferSize  int           // Recent events kept per task for Last-Event-ID resume (default: 1000)
+	TaskEventMaxTasks    int           // Tasks whose event buffers are kept; the least recently active are dropped first (default: 1000)
+	SSEHeartbeatInterval time.Duration // Interval of keep-alive comments on idle event streams (default: 15s)
 }
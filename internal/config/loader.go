@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
 )
 
 // NewDefaultConfig creates a Config instance with sensible default values.
@@ -33,6 +35,7 @@ func NewDefaultConfig() *Config {
 			"application/json",
 			"application/pdf",
 			"text/plain",
+			"text/csv",
 			"image/png",
 			"image/jpeg",
 		},
@@ -40,6 +43,40 @@ func NewDefaultConfig() *Config {
 		// Logging defaults
 		LogLevel: "info",
 
+		// Approval defaults
+		ApprovalRejectionPolicy: "FAIL_TASK",
+
+		// Execution defaults
+		MaxParallelSteps:      4,
+		MaxSubTaskDepth:       3,
+		WorkerPoolSize:        8,
+		TaskQueueCapacity:     1000,
+		TaskQueueAging:        1 * time.Minute,
+		DefaultTaskTimeout:    0,
+		DefaultStepTimeout:    10 * time.Minute,
+		DeadlineCheckInterval: 5 * time.Second,
+
+		// Idempotency defaults
+		IdempotencyKeyTTL: 24 * time.Hour,
+
+		// Scheduling defaults
+		SchedulerPollInterval:  15 * time.Second,
+		ScheduleMisfireGrace:   1 * time.Minute,
+		ScheduleMaxCatchUpRuns: 10,
+
+		// Verification defaults
+		MaxReplans:            2,
+		VerifyMinGoalCoverage: 0,
+		VerifyMaxOutputChars:  2000,
+
+		// Step retry defaults
+		StepMaxAttempts:        3,
+		StepRetryBackoff:       1 * time.Second,
+		StepRetryMaxBackoff:    30 * time.Second,
+		StepRetryMultiplier:    2.0,
+		StepRetryJitter:        0.2,
+		StepRetryUnknownErrors: true,
+
 		// Feature flags defaults
 		EnableMetrics: false,
 
@@ -49,6 +86,33 @@ func NewDefaultConfig() *Config {
 		DefaultLLMModel: "gpt-4o-mini",
 		LLMTimeout:      60 * time.Second,
 		LLMMaxRetries:   3,
+
+		// Accounting defaults (USD per million tokens; the orchestrator is given this table as OrchestratorConfig.Prices)
+		LLMPrices: domain.PriceTable{
+			"gpt-4o-mini":      {PromptPerMillion: 0.15, CompletionPerMillion: 0.60},
+			"gpt-4o":           {PromptPerMillion: 2.50, CompletionPerMillion: 10.00},
+			"gpt-4.1-mini":     {PromptPerMillion: 0.40, CompletionPerMillion: 1.60},
+			"gpt-4.1":          {PromptPerMillion: 2.00, CompletionPerMillion: 8.00},
+			"claude-3-5-haiku": {PromptPerMillion: 0.80, CompletionPerMillion: 4.00},
+			"claude-sonnet-4":  {PromptPerMillion: 3.00, CompletionPerMillion: 15.00},
+		},
+
+		// Budget defaults (unlimited until an administrator sets caps)
+		DefaultUserDailyBudgetUSD:   0,
+		DefaultUserMonthlyBudgetUSD: 0,
+
+		// Artifact defaults
+		ArtifactDir: "data/artifacts",
+
+		// Result defaults
+		StepResultInlineMaxBytes: 64 * 1024, // 64KB
+		StepResultPreviewBytes:   1024,      // 1KB
+		ResultDir:                "data/results",
+
+		// Event streaming defaults
+		TaskEventBufferSize:  1000,
+		TaskEventMaxTasks:    1000,
+		SSEHeartbeatInterval: 15 * time.Second,
 	}
 }
 
@@ -124,6 +188,180 @@ func LoadFromEnv() (*Config, error) {
 		cfg.LogLevel = level
 	}
 
+	// Approvals
+	if policy := os.Getenv("APPROVAL_REJECTION_POLICY"); policy != "" {
+		cfg.ApprovalRejectionPolicy = policy
+	}
+
+	// Execution
+	if parallel := os.Getenv("MAX_PARALLEL_STEPS"); parallel != "" {
+		p, err := strconv.Atoi(parallel)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MAX_PARALLEL_STEPS: %w", err)
+		}
+		cfg.MaxParallelSteps = p
+	}
+
+	if depth := os.Getenv("MAX_SUBTASK_DEPTH"); depth != "" {
+		d, err := strconv.Atoi(depth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MAX_SUBTASK_DEPTH: %w", err)
+		}
+		cfg.MaxSubTaskDepth = d
+	}
+
+	if workers := os.Getenv("WORKER_POOL_SIZE"); workers != "" {
+		w, err := strconv.Atoi(workers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid WORKER_POOL_SIZE: %w", err)
+		}
+		cfg.WorkerPoolSize = w
+	}
+
+	if capacity := os.Getenv("TASK_QUEUE_CAPACITY"); capacity != "" {
+		c, err := strconv.Atoi(capacity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TASK_QUEUE_CAPACITY: %w", err)
+		}
+		cfg.TaskQueueCapacity = c
+	}
+
+	if aging := os.Getenv("TASK_QUEUE_AGING"); aging != "" {
+		d, err := time.ParseDuration(aging)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TASK_QUEUE_AGING: %w", err)
+		}
+		cfg.TaskQueueAging = d
+	}
+
+	if timeout := os.Getenv("DEFAULT_TASK_TIMEOUT"); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DEFAULT_TASK_TIMEOUT: %w", err)
+		}
+		cfg.DefaultTaskTimeout = d
+	}
+
+	if timeout := os.Getenv("DEFAULT_STEP_TIMEOUT"); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DEFAULT_STEP_TIMEOUT: %w", err)
+		}
+		cfg.DefaultStepTimeout = d
+	}
+
+	if interval := os.Getenv("DEADLINE_CHECK_INTERVAL"); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DEADLINE_CHECK_INTERVAL: %w", err)
+		}
+		cfg.DeadlineCheckInterval = d
+	}
+
+	// Idempotency
+	if ttl := os.Getenv("IDEMPOTENCY_KEY_TTL"); ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IDEMPOTENCY_KEY_TTL: %w", err)
+		}
+		cfg.IdempotencyKeyTTL = d
+	}
+
+	// Scheduling
+	if interval := os.Getenv("SCHEDULER_POLL_INTERVAL"); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SCHEDULER_POLL_INTERVAL: %w", err)
+		}
+		cfg.SchedulerPollInterval = d
+	}
+
+	if grace := os.Getenv("SCHEDULE_MISFIRE_GRACE"); grace != "" {
+		d, err := time.ParseDuration(grace)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SCHEDULE_MISFIRE_GRACE: %w", err)
+		}
+		cfg.ScheduleMisfireGrace = d
+	}
+
+	if runs := os.Getenv("SCHEDULE_MAX_CATCH_UP_RUNS"); runs != "" {
+		r, err := strconv.Atoi(runs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SCHEDULE_MAX_CATCH_UP_RUNS: %w", err)
+		}
+		cfg.ScheduleMaxCatchUpRuns = r
+	}
+
+	// Verification
+	if replans := os.Getenv("MAX_REPLANS"); replans != "" {
+		r, err := strconv.Atoi(replans)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MAX_REPLANS: %w", err)
+		}
+		cfg.MaxReplans = r
+	}
+
+	if coverage := os.Getenv("VERIFY_MIN_GOAL_COVERAGE"); coverage != "" {
+		c, err := strconv.ParseFloat(coverage, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid VERIFY_MIN_GOAL_COVERAGE: %w", err)
+		}
+		cfg.VerifyMinGoalCoverage = c
+	}
+
+	if chars := os.Getenv("VERIFY_MAX_OUTPUT_CHARS"); chars != "" {
+		c, err := strconv.Atoi(chars)
+		if err != nil {
+			return nil, fmt.Errorf("invalid VERIFY_MAX_OUTPUT_CHARS: %w", err)
+		}
+		cfg.VerifyMaxOutputChars = c
+	}
+
+	// Step retries
+	if attempts := os.Getenv("STEP_MAX_ATTEMPTS"); attempts != "" {
+		a, err := strconv.Atoi(attempts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid STEP_MAX_ATTEMPTS: %w", err)
+		}
+		cfg.StepMaxAttempts = a
+	}
+
+	if backoff := os.Getenv("STEP_RETRY_BACKOFF"); backoff != "" {
+		d, err := time.ParseDuration(backoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid STEP_RETRY_BACKOFF: %w", err)
+		}
+		cfg.StepRetryBackoff = d
+	}
+
+	if backoff := os.Getenv("STEP_RETRY_MAX_BACKOFF"); backoff != "" {
+		d, err := time.ParseDuration(backoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid STEP_RETRY_MAX_BACKOFF: %w", err)
+		}
+		cfg.StepRetryMaxBackoff = d
+	}
+
+	if multiplier := os.Getenv("STEP_RETRY_MULTIPLIER"); multiplier != "" {
+		m, err := strconv.ParseFloat(multiplier, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid STEP_RETRY_MULTIPLIER: %w", err)
+		}
+		cfg.StepRetryMultiplier = m
+	}
+
+	if jitter := os.Getenv("STEP_RETRY_JITTER"); jitter != "" {
+		j, err := strconv.ParseFloat(jitter, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid STEP_RETRY_JITTER: %w", err)
+		}
+		cfg.StepRetryJitter = j
+	}
+
+	if unknown := os.Getenv("STEP_RETRY_UNKNOWN_ERRORS"); unknown != "" {
+		cfg.StepRetryUnknownErrors = unknown == "true" || unknown == "1"
+	}
+
 	// Feature flags
 	if metrics := os.Getenv("ENABLE_METRICS"); metrics != "" {
 		cfg.EnableMetrics = metrics == "true" || metrics == "1"
@@ -158,6 +396,94 @@ func LoadFromEnv() (*Config, error) {
 		cfg.LLMMaxRetries = r
 	}
 
+	// Accounting: entries override or extend the default price table
+	if prices := os.Getenv("LLM_PRICES"); prices != "" {
+		table, err := domain.ParsePriceTable(prices)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LLM_PRICES: %w", err)
+		}
+		for model, price := range table {
+			cfg.LLMPrices[model] = price
+		}
+	}
+
+	// Budgets
+	if daily := os.Getenv("DEFAULT_USER_DAILY_BUDGET_USD"); daily != "" {
+		d, err := strconv.ParseFloat(daily, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DEFAULT_USER_DAILY_BUDGET_USD: %w", err)
+		}
+		cfg.DefaultUserDailyBudgetUSD = d
+	}
+
+	if monthly := os.Getenv("DEFAULT_USER_MONTHLY_BUDGET_USD"); monthly != "" {
+		m, err := strconv.ParseFloat(monthly, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DEFAULT_USER_MONTHLY_BUDGET_USD: %w", err)
+		}
+		cfg.DefaultUserMonthlyBudgetUSD = m
+	}
+
+	if admins := os.Getenv("BUDGET_ADMINS"); admins != "" {
+		cfg.BudgetAdmins = make([]string, 0)
+		for _, admin := range strings.Split(admins, ",") {
+			if admin = strings.TrimSpace(admin); admin != "" {
+				cfg.BudgetAdmins = append(cfg.BudgetAdmins, admin)
+			}
+		}
+	}
+
+	// Artifacts
+	if dir := os.Getenv("ARTIFACT_DIR"); dir != "" {
+		cfg.ArtifactDir = dir
+	}
+
+	// Results
+	if limit := os.Getenv("STEP_RESULT_INLINE_MAX_BYTES"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid STEP_RESULT_INLINE_MAX_BYTES: %w", err)
+		}
+		cfg.StepResultInlineMaxBytes = l
+	}
+
+	if preview := os.Getenv("STEP_RESULT_PREVIEW_BYTES"); preview != "" {
+		p, err := strconv.Atoi(preview)
+		if err != nil {
+			return nil, fmt.Errorf("invalid STEP_RESULT_PREVIEW_BYTES: %w", err)
+		}
+		cfg.StepResultPreviewBytes = p
+	}
+
+	if dir := os.Getenv("RESULT_DIR"); dir != "" {
+		cfg.ResultDir = dir
+	}
+
+	// Event streaming
+	if size := os.Getenv("TASK_EVENT_BUFFER_SIZE"); size != "" {
+		s, err := strconv.Atoi(size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TASK_EVENT_BUFFER_SIZE: %w", err)
+		}
+		cfg.TaskEventBufferSize = s
+	}
+
+	if tasks := os.Getenv("TASK_EVENT_MAX_TASKS"); tasks != "" {
+		t, err := strconv.Atoi(tasks)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TASK_EVENT_MAX_TASKS: %w", err)
+		}
+		cfg.TaskEventMaxTasks = t
+	}
+
+	if interval := os.Getenv("SSE_HEARTBEAT_INTERVAL"); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SSE_HEARTBEAT_INTERVAL: %w", err)
+		}
+		cfg.SSEHeartbeatInterval = d
+	}
+
 	// Validate the loaded configuration
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -211,6 +537,92 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid log level: %s (must be: debug, info, warn, error)", c.LogLevel)
 	}
 
+	// Approval validation
+	if c.ApprovalRejectionPolicy != "SKIP_STEP" && c.ApprovalRejectionPolicy != "FAIL_TASK" {
+		return fmt.Errorf("invalid approval rejection policy: %s (must be: SKIP_STEP, FAIL_TASK)", c.ApprovalRejectionPolicy)
+	}
+
+	// Execution validation
+	if c.MaxParallelSteps < 1 {
+		return fmt.Errorf("max parallel steps must be at least 1: %d", c.MaxParallelSteps)
+	}
+
+	if c.MaxSubTaskDepth < 0 {
+		return fmt.Errorf("max sub-task depth cannot be negative: %d", c.MaxSubTaskDepth)
+	}
+
+	if c.WorkerPoolSize < 1 {
+		return fmt.Errorf("worker pool size must be at least 1: %d", c.WorkerPoolSize)
+	}
+
+	if c.TaskQueueCapacity < 1 {
+		return fmt.Errorf("task queue capacity must be at least 1: %d", c.TaskQueueCapacity)
+	}
+
+	if c.TaskQueueAging < 0 {
+		return fmt.Errorf("task queue aging cannot be negative: %v", c.TaskQueueAging)
+	}
+
+	if c.DefaultTaskTimeout < 0 {
+		return fmt.Errorf("default task timeout cannot be negative: %v", c.DefaultTaskTimeout)
+	}
+
+	if c.DefaultStepTimeout < 0 {
+		return fmt.Errorf("default step timeout cannot be negative: %v", c.DefaultStepTimeout)
+	}
+
+	if c.DeadlineCheckInterval <= 0 {
+		return fmt.Errorf("deadline check interval must be positive: %v", c.DeadlineCheckInterval)
+	}
+
+	// Idempotency validation
+	if c.IdempotencyKeyTTL <= 0 {
+		return fmt.Errorf("idempotency key TTL must be positive: %v", c.IdempotencyKeyTTL)
+	}
+
+	// Scheduling validation
+	if c.SchedulerPollInterval <= 0 {
+		return fmt.Errorf("scheduler poll interval must be positive: %v", c.SchedulerPollInterval)
+	}
+
+	if c.ScheduleMisfireGrace < 0 {
+		return fmt.Errorf("schedule misfire grace cannot be negative: %v", c.ScheduleMisfireGrace)
+	}
+
+	if c.ScheduleMaxCatchUpRuns < 1 {
+		return fmt.Errorf("schedule max catch-up runs must be at least 1: %d", c.ScheduleMaxCatchUpRuns)
+	}
+
+	// Verification validation
+	if c.MaxReplans < 0 {
+		return fmt.Errorf("max replans cannot be negative: %d", c.MaxReplans)
+	}
+
+	if c.VerifyMinGoalCoverage < 0 || c.VerifyMinGoalCoverage > 1 {
+		return fmt.Errorf("verify min goal coverage must be between 0 and 1: %v", c.VerifyMinGoalCoverage)
+	}
+
+	if c.VerifyMaxOutputChars < 1 {
+		return fmt.Errorf("verify max output chars must be at least 1: %d", c.VerifyMaxOutputChars)
+	}
+
+	// Step retry validation
+	if c.StepMaxAttempts < 1 {
+		return fmt.Errorf("step max attempts must be at least 1: %d", c.StepMaxAttempts)
+	}
+
+	if c.StepRetryBackoff < 0 || c.StepRetryMaxBackoff < c.StepRetryBackoff {
+		return fmt.Errorf("invalid step retry backoff: %v (max %v)", c.StepRetryBackoff, c.StepRetryMaxBackoff)
+	}
+
+	if c.StepRetryMultiplier < 1 {
+		return fmt.Errorf("step retry multiplier must be at least 1: %v", c.StepRetryMultiplier)
+	}
+
+	if c.StepRetryJitter < 0 || c.StepRetryJitter > 1 {
+		return fmt.Errorf("step retry jitter must be between 0 and 1: %v", c.StepRetryJitter)
+	}
+
 	// LLM validation
 	if c.LLMTimeout < time.Second {
 		return fmt.Errorf("LLM timeout too short: %v (minimum 1s)", c.LLMTimeout)
@@ -220,6 +632,54 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("LLM max retries cannot be negative: %d", c.LLMMaxRetries)
 	}
 
+	// Accounting validation
+	if _, ok := c.LLMPrices.Price(c.DefaultLLMModel); !ok {
+		return fmt.Errorf("LLM price table has no entry for the default model %s (set LLM_PRICES)", c.DefaultLLMModel)
+	}
+
+	// Budget validation
+	if c.DefaultUserDailyBudgetUSD < 0 {
+		return fmt.Errorf("default user daily budget cannot be negative: %g", c.DefaultUserDailyBudgetUSD)
+	}
+	if c.DefaultUserMonthlyBudgetUSD < 0 {
+		return fmt.Errorf("default user monthly budget cannot be negative: %g", c.DefaultUserMonthlyBudgetUSD)
+	}
+
+	// Artifact validation
+	if c.ArtifactDir == "" {
+		return fmt.Errorf("artifact directory cannot be empty")
+	}
+
+	// Result validation
+	if c.StepResultInlineMaxBytes < 0 {
+		return fmt.Errorf("step result inline max bytes cannot be negative: %d", c.StepResultInlineMaxBytes)
+	}
+
+	if c.StepResultPreviewBytes < 0 {
+		return fmt.Errorf("step result preview bytes cannot be negative: %d", c.StepResultPreviewBytes)
+	}
+	if c.StepResultInlineMaxBytes > 0 && c.StepResultPreviewBytes > c.StepResultInlineMaxBytes {
+		return fmt.Errorf("step result preview bytes (%d) cannot exceed step result inline max bytes (%d)",
+			c.StepResultPreviewBytes, c.StepResultInlineMaxBytes)
+	}
+
+	if c.ResultDir == "" {
+		return fmt.Errorf("result directory cannot be empty")
+	}
+
+	// Event streaming validation
+	if c.TaskEventBufferSize < 1 {
+		return fmt.Errorf("task event buffer size must be at least 1: %d", c.TaskEventBufferSize)
+	}
+
+	if c.TaskEventMaxTasks < 1 {
+		return fmt.Errorf("task event max tasks must be at least 1: %d", c.TaskEventMaxTasks)
+	}
+
+	if c.SSEHeartbeatInterval <= 0 {
+		return fmt.Errorf("SSE heartbeat interval must be positive: %v", c.SSEHeartbeatInterval)
+	}
+
 	return nil
 }
 
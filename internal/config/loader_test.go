@@ -0,0 +1,102 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateStepResultSizes(t *testing.T) {
+	tests := []struct {
+		name      string
+		inlineMax int
+		preview   int
+		wantErr   bool
+	}{
+		{name: "defaults", inlineMax: 64 * 1024, preview: 1024},
+		{name: "preview equal to the threshold", inlineMax: 1024, preview: 1024},
+		{name: "no preview", inlineMax: 1024, preview: 0},
+		{name: "offloading disabled ignores the preview size", inlineMax: 0, preview: 4096},
+		{name: "preview above the threshold", inlineMax: 512, preview: 1024, wantErr: true},
+		{name: "negative threshold", inlineMax: -1, preview: 0, wantErr: true},
+		{name: "negative preview", inlineMax: 1024, preview: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.StepResultInlineMaxBytes = tt.inlineMax
+			cfg.StepResultPreviewBytes = tt.preview
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadFromEnvStepResultSizes(t *testing.T) {
+	t.Setenv("STEP_RESULT_INLINE_MAX_BYTES", "4096")
+	t.Setenv("STEP_RESULT_PREVIEW_BYTES", "256")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() = %v", err)
+	}
+	if cfg.StepResultInlineMaxBytes != 4096 || cfg.StepResultPreviewBytes != 256 {
+		t.Errorf("sizes = (%d, %d), want (4096, 256)", cfg.StepResultInlineMaxBytes, cfg.StepResultPreviewBytes)
+	}
+
+	t.Setenv("STEP_RESULT_PREVIEW_BYTES", "8192")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Error("LoadFromEnv() accepted a preview larger than the inline threshold")
+	}
+
+	t.Setenv("STEP_RESULT_PREVIEW_BYTES", "1KB")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Error("LoadFromEnv() accepted a non-numeric preview size")
+	}
+}
+
+func TestLoadFromEnvBudgetAdmins(t *testing.T) {
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() = %v", err)
+	}
+	if len(cfg.BudgetAdmins) != 0 {
+		t.Errorf("default BudgetAdmins = %v, want none", cfg.BudgetAdmins)
+	}
+
+	t.Setenv("BUDGET_ADMINS", " root, ops-lead ,,")
+	cfg, err = LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() = %v", err)
+	}
+	if len(cfg.BudgetAdmins) != 2 || cfg.BudgetAdmins[0] != "root" || cfg.BudgetAdmins[1] != "ops-lead" {
+		t.Errorf("BudgetAdmins = %q, want [root ops-lead]", cfg.BudgetAdmins)
+	}
+}
+
+func TestLoadFromEnvDeadlineCheckInterval(t *testing.T) {
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() = %v", err)
+	}
+	if cfg.DeadlineCheckInterval != 5*time.Second {
+		t.Errorf("default DeadlineCheckInterval = %v, want 5s", cfg.DeadlineCheckInterval)
+	}
+
+	t.Setenv("DEADLINE_CHECK_INTERVAL", "30s")
+	cfg, err = LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() = %v", err)
+	}
+	if cfg.DeadlineCheckInterval != 30*time.Second {
+		t.Errorf("DeadlineCheckInterval = %v, want 30s", cfg.DeadlineCheckInterval)
+	}
+
+	for _, invalid := range []string{"0s", "-1s", "soon"} {
+		t.Setenv("DEADLINE_CHECK_INTERVAL", invalid)
+		if _, err := LoadFromEnv(); err == nil {
+			t.Errorf("LoadFromEnv() accepted DEADLINE_CHECK_INTERVAL=%s", invalid)
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"time"
+)
+
 // ApprovalStatus represents the status of an approval request
 type ApprovalStatus string
 
@@ -10,17 +15,85 @@ const (
 	ApprovalStatusRejected ApprovalStatus = "REJECTED"
 )
 
+// RejectionPolicy determines what happens to a step when its approval is rejected
+type RejectionPolicy string
+
+// Rejection policy constants
+const (
+	RejectionPolicySkipStep RejectionPolicy = "SKIP_STEP" // Mark the step SKIPPED and continue the plan
+	RejectionPolicyFailTask RejectionPolicy = "FAIL_TASK" // Mark the step FAILED and cancel the task
+)
+
+// ToolCallStatus represents the outcome of a tool invocation
+type ToolCallStatus string
+
+// Tool call status constants
+const (
+	ToolCallStatusSucceeded ToolCallStatus = "SUCCEEDED"
+	ToolCallStatusFailed    ToolCallStatus = "FAILED"
+	ToolCallStatusRejected  ToolCallStatus = "REJECTED" // The agent may not use the tool; it was not run
+)
+
+// IsValid reports whether the status is one of the defined tool call statuses.
+func (s ToolCallStatus) IsValid() bool {
+	switch s {
+	case ToolCallStatusSucceeded, ToolCallStatusFailed, ToolCallStatusRejected:
+		return true
+	}
+	return false
+}
+
 // ToolCall represents a tool execution action
 type ToolCall struct {
-	ID            string `json:"id"`
-	TaskID        string `json:"task_id"`
-	StepID        string `json:"step_id"`
-	ToolName      string `json:"tool_name"`
-	InputPayload  string `json:"input_payload"`
-	OutputPayload string `json:"output_payload"`
-	Status        string `json:"status"`
-	ErrorMessage  string `json:"error_message"`
-	DurationMs    int64  `json:"duration_ms"`
+	ID            string         `json:"id"`
+	TaskID        string         `json:"task_id"`
+	StepID        string         `json:"step_id"`
+	Agent         string         `json:"agent"`   // Agent whose executor made the call
+	Attempt       int            `json:"attempt"` // Step attempt (1-based) during which the call was made
+	ToolName      string         `json:"tool_name"`
+	InputPayload  string         `json:"input_payload"`
+	OutputPayload string         `json:"output_payload"`
+	Status        ToolCallStatus `json:"status"`
+	ErrorMessage  string         `json:"error_message"`
+	DurationMs    int64          `json:"duration_ms"`
+	CreatedAt     time.Time      `json:"created_at"` // When the call finished
+}
+
+// ToolCallFilter selects tool calls. Empty fields match everything.
+type ToolCallFilter struct {
+	TaskID   string         `json:"task_id,omitempty"`
+	ToolName string         `json:"tool_name,omitempty"`
+	Status   ToolCallStatus `json:"status,omitempty"`
+	Since    time.Time      `json:"since,omitempty"` // Inclusive lower bound of CreatedAt
+	Limit    int            `json:"limit,omitempty"` // Keep only the most recent calls; 0 keeps all
+}
+
+// Validate checks the status and limit of the filter.
+func (f ToolCallFilter) Validate() error {
+	if f.Status != "" && !f.Status.IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidToolCallStatus, f.Status)
+	}
+	if f.Limit < 0 {
+		return fmt.Errorf("limit cannot be negative: %d", f.Limit)
+	}
+	return nil
+}
+
+// Matches reports whether the call satisfies every criterion of the filter except Limit.
+func (f ToolCallFilter) Matches(call *ToolCall) bool {
+	if f.TaskID != "" && call.TaskID != f.TaskID {
+		return false
+	}
+	if f.ToolName != "" && call.ToolName != f.ToolName {
+		return false
+	}
+	if f.Status != "" && call.Status != f.Status {
+		return false
+	}
+	if !f.Since.IsZero() && call.CreatedAt.Before(f.Since) {
+		return false
+	}
+	return true
 }
 
 // ApprovalRequest represents a request for user approval
@@ -28,8 +101,18 @@ type ApprovalRequest struct {
 	ID            string         `json:"id"`
 	TaskID        string         `json:"task_id"`
 	StepID        string         `json:"step_id"`
+	UserID        string         `json:"user_id"`
 	ActionSummary string         `json:"action_summary"`
 	RiskReason    string         `json:"risk_reason"`
 	Status        ApprovalStatus `json:"status"`
 	ApprovedBy    string         `json:"approved_by"`
+	Comment       string         `json:"comment,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	DecidedAt     time.Time      `json:"decided_at"`
+}
+
+// ApprovalFilter narrows an approval listing; empty fields match everything
+type ApprovalFilter struct {
+	UserID string         `json:"user_id,omitempty"` // Owner of the task the approval belongs to
+	Status ApprovalStatus `json:"status,omitempty"`
 }
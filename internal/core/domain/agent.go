@@ -0,0 +1,59 @@
+package domain
+
+import "strings"
+
+// DefaultAgentName is the agent that handles tasks no other agent was chosen for.
+// It is backed by the orchestrator's own Planner and Executor and may use every tool.
+const DefaultAgentName = "CORE"
+
+// AllToolsAllowed in AgentProfile.AllowedTools grants access to every registered tool
+const AllToolsAllowed = "*"
+
+// Agent routing methods recorded in TASK_ROUTED audit events
+const (
+	RoutingExplicit   = "EXPLICIT"   // The request named the agent (TaskOptions.TargetAgent)
+	RoutingClassified = "CLASSIFIED" // The AgentRouter chose the agent from the input
+	RoutingDefault    = "DEFAULT"    // No router, no match or routing failed: DefaultAgentName
+)
+
+// AgentProfile describes a specialized agent such as "research", "ops" or "finance".
+// The agent's Planner and Executor are registered alongside the profile (see ports.AgentRegistry).
+type AgentProfile struct {
+	Name         string   `json:"name"`               // Unique name, stored upper-case in Task.TargetAgent
+	Description  string   `json:"description"`        // What the agent is for; shown to routers and clients
+	AllowedTools []string `json:"allowed_tools"`      // Tools the agent may plan and call; "*" allows all, empty allows none
+	Keywords     []string `json:"keywords,omitempty"` // Hints for keyword-based routing
+	SystemPrompt string   `json:"-"`                  // Instructions for the agent's LLM components; never exposed by the API
+}
+
+// NormalizeAgentName returns the canonical (trimmed, upper-case) form of an agent name.
+func NormalizeAgentName(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+// AllowsTool reports whether the agent may use the named tool.
+func (a *AgentProfile) AllowsTool(name string) bool {
+	for _, allowed := range a.AllowedTools {
+		if allowed == AllToolsAllowed || allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterTools returns the tools of the catalogue the agent may use, in catalogue order.
+func (a *AgentProfile) FilterTools(tools []ToolMetadata) []ToolMetadata {
+	allowed := make([]ToolMetadata, 0, len(tools))
+	for _, tool := range tools {
+		if a.AllowsTool(tool.Name) {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed
+}
+
+// AgentRoute is an AgentRouter's choice of agent for a task
+type AgentRoute struct {
+	Agent  string `json:"agent"`  // Name of a registered agent, or empty if none fits
+	Reason string `json:"reason"` // Why the agent was chosen
+}
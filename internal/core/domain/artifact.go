@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MaxArtifactNameLength bounds artifact names so they fit in a file name on common filesystems.
+const MaxArtifactNameLength = 200
+
+// Artifact describes a file attached to or produced by a task, e.g., a user's PDF or an agent's CSV report.
+// The content itself lives in an ArtifactStore; Task.Artifacts maps each name to its Checksum.
+type Artifact struct {
+	TaskID      string    `json:"task_id"`
+	Name        string    `json:"name"`         // Unique per task; uploading the same name replaces the artifact
+	ContentType string    `json:"content_type"` // MIME type without parameters, e.g. "application/pdf"
+	Size        int64     `json:"size"`         // Content length in bytes
+	Checksum    string    `json:"checksum"`     // "sha256:" followed by the hex digest of the content
+	CreatedBy   string    `json:"created_by"`   // Uploading user, or the agent that produced it
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ValidateArtifactName checks that an artifact name is a plain file name.
+// Names must not be empty, "." or "..", contain path separators or control characters,
+// start with a dot, or exceed MaxArtifactNameLength bytes.
+func ValidateArtifactName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidArtifactName)
+	}
+	if len(name) > MaxArtifactNameLength {
+		return fmt.Errorf("%w: name longer than %d bytes", ErrInvalidArtifactName, MaxArtifactNameLength)
+	}
+	if strings.HasPrefix(name, ".") {
+		return fmt.Errorf("%w: name cannot start with a dot: %q", ErrInvalidArtifactName, name)
+	}
+	if strings.ContainsAny(name, `/\:`) {
+		return fmt.Errorf("%w: name cannot contain path separators: %q", ErrInvalidArtifactName, name)
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return fmt.Errorf("%w: name contains invalid characters: %q", ErrInvalidArtifactName, name)
+		}
+	}
+	return nil
+}
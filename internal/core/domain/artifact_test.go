@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateArtifactName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "plain file name", input: "report.pdf"},
+		{name: "spaces and unicode", input: "Izveštaj za mart 2026.csv"},
+		{name: "inner dots", input: "archive.tar.gz"},
+		{name: "longest allowed", input: strings.Repeat("a", MaxArtifactNameLength)},
+		{name: "empty", input: "", wantErr: true},
+		{name: "too long", input: strings.Repeat("a", MaxArtifactNameLength+1), wantErr: true},
+		{name: "current directory", input: ".", wantErr: true},
+		{name: "parent directory", input: "..", wantErr: true},
+		{name: "hidden file", input: ".env", wantErr: true},
+		{name: "parent traversal", input: "../secrets.txt", wantErr: true},
+		{name: "nested traversal", input: "docs/../../secrets.txt", wantErr: true},
+		{name: "absolute path", input: "/etc/passwd", wantErr: true},
+		{name: "windows traversal", input: `..\secrets.txt`, wantErr: true},
+		{name: "windows drive", input: `C:report.pdf`, wantErr: true},
+		{name: "NUL byte", input: "report.pdf\x00.png", wantErr: true},
+		{name: "newline", input: "report\n.pdf", wantErr: true},
+		{name: "invalid UTF-8", input: "report\xff.pdf", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateArtifactName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateArtifactName(%q) = %v, want error %v", tt.input, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidArtifactName) {
+				t.Errorf("ValidateArtifactName(%q) = %v, want it to wrap ErrInvalidArtifactName", tt.input, err)
+			}
+		})
+	}
+}
@@ -13,3 +13,28 @@ type AuditEvent struct {
 	Actor            string                 `json:"actor"`
 	BehaviorVersion  string                 `json:"behavior_version"`
 }
+
+// TaskEvent is a live progress notification of a task, streamed to clients as it happens.
+// Every audit event recorded for a task is published as a TaskEvent of the same type, as are
+// the text fragments of LLM calls (LLM_TOKEN_DELTA) that are not written to the audit log.
+type TaskEvent struct {
+	Seq        int64                  `json:"seq"` // Position in the task's event stream, starting at 1
+	TaskID     string                 `json:"task_id"`
+	Type       string                 `json:"type"`
+	TaskStatus TaskStatus             `json:"task_status"` // Status of the task when the event occurred
+	Timestamp  time.Time              `json:"timestamp"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// TaskEventSubscription is a client's view of a task's event stream.
+// Events older than the task's buffer are not replayed; clients that fell that far behind
+// reload the task instead.
+type TaskEventSubscription struct {
+	Backlog []*TaskEvent      // Buffered events after the requested position, oldest first
+	Events  <-chan *TaskEvent // Events published after Backlog; closed when the stream is closed, the subscriber falls behind or the subscription ends
+	Closed  bool              // The task's stream was already closed when subscribing (Events is closed)
+}
+
+// TaskEventLLMTokenDelta is the type of events carrying a fragment of text an LLM is generating
+// (data: step_id, phase, delta).
+const TaskEventLLMTokenDelta = "LLM_TOKEN_DELTA"
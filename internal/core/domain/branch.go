@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Branch condition operators understood by EvaluateCondition
+const (
+	ConditionDefault  = "default"  // Taken when no other branch matches
+	ConditionEquals   = "equals"   // Output equals the operand (case-insensitive, trimmed)
+	ConditionContains = "contains" // Output contains the operand (case-insensitive)
+	ConditionPrefix   = "prefix"   // Output starts with the operand (case-insensitive, trimmed)
+	ConditionRegex    = "regex"    // Output matches the regular expression operand
+)
+
+// Branch routes execution after a DECISION step.
+// Condition has the form "<operator>:<operand>" (e.g., "contains:billing") or "default".
+type Branch struct {
+	Condition string   `json:"condition"`
+	Next      []string `json:"next"` // IDs of the steps to run when the condition matches
+}
+
+// EvaluateCondition reports whether a branch condition matches a decision output.
+// It returns an error for unknown operators or invalid regular expressions.
+func EvaluateCondition(condition string, output string) (bool, error) {
+	if strings.TrimSpace(condition) == ConditionDefault {
+		return true, nil
+	}
+
+	operator, operand, found := strings.Cut(condition, ":")
+	if !found {
+		return false, fmt.Errorf("condition %q must have the form operator:operand or be %q", condition, ConditionDefault)
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(output))
+	switch strings.ToLower(strings.TrimSpace(operator)) {
+	case ConditionEquals:
+		return normalized == strings.ToLower(strings.TrimSpace(operand)), nil
+	case ConditionContains:
+		return strings.Contains(normalized, strings.ToLower(operand)), nil
+	case ConditionPrefix:
+		return strings.HasPrefix(normalized, strings.ToLower(strings.TrimSpace(operand))), nil
+	case ConditionRegex:
+		re, err := regexp.Compile(operand)
+		if err != nil {
+			return false, fmt.Errorf("condition %q has an invalid regular expression: %w", condition, err)
+		}
+		return re.MatchString(output), nil
+	default:
+		return false, fmt.Errorf("condition %q uses unknown operator %q", condition, operator)
+	}
+}
+
+// SelectBranch returns the index of the branch taken for a decision output.
+// Branches are evaluated in order and the first match wins; a "default" branch is only
+// taken if no other branch matches. It returns an error if no branch matches.
+func (s *Step) SelectBranch(output string) (int, error) {
+	fallback := -1
+	for i, branch := range s.Branches {
+		if strings.TrimSpace(branch.Condition) == ConditionDefault {
+			if fallback < 0 {
+				fallback = i
+			}
+			continue
+		}
+		matched, err := EvaluateCondition(branch.Condition, output)
+		if err != nil {
+			return -1, err
+		}
+		if matched {
+			return i, nil
+		}
+	}
+
+	if fallback >= 0 {
+		return fallback, nil
+	}
+	return -1, fmt.Errorf("no branch of decision %s matches output %q", s.ID, output)
+}
+
+// UntakenBranchSteps returns the IDs of the PENDING steps that must be skipped once the
+// decision step takes the given branch: targets of the other branches, plus every step
+// whose dependencies all lie on untaken paths. Targets of the taken branch are never skipped.
+// Only declared DependsOn edges propagate: in a plan without them the implicit chain through
+// the previous step says nothing about reachability, so only the untaken targets are skipped.
+func (p *Plan) UntakenBranchSteps(decisionID string, taken int) []string {
+	decision := p.step(decisionID)
+	if decision == nil || taken < 0 || taken >= len(decision.Branches) {
+		return nil
+	}
+
+	keep := make(map[string]bool)
+	for _, id := range decision.Branches[taken].Next {
+		keep[id] = true
+	}
+
+	skip := make(map[string]bool)
+	for i, branch := range decision.Branches {
+		if i == taken {
+			continue
+		}
+		for _, id := range branch.Next {
+			if !keep[id] {
+				skip[id] = true
+			}
+		}
+	}
+
+	// Propagate to steps that can only be reached through untaken branches
+	for changed := true; changed; {
+		changed = false
+		for i := range p.Steps {
+			id := p.Steps[i].ID
+			deps := p.Steps[i].DependsOn
+			if skip[id] || keep[id] || len(deps) == 0 {
+				continue
+			}
+			allSkipped := true
+			for _, dep := range deps {
+				if !skip[dep] {
+					allSkipped = false
+					break
+				}
+			}
+			if allSkipped {
+				skip[id] = true
+				changed = true
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(skip))
+	for i := range p.Steps {
+		if skip[p.Steps[i].ID] && p.Steps[i].Status == StepStatusPending {
+			ids = append(ids, p.Steps[i].ID)
+		}
+	}
+
+	return ids
+}
+
+// validateBranches checks that every branch condition parses and every branch target
+// exists and runs after its decision step.
+func (p *Plan) validateBranches() error {
+	for i := range p.Steps {
+		step := &p.Steps[i]
+		if len(step.Branches) == 0 {
+			continue
+		}
+		if step.Type != StepTypeDecision {
+			return fmt.Errorf("%w: step %s has branches but is not a %s step", ErrInvalidPlan, step.ID, StepTypeDecision)
+		}
+		for _, branch := range step.Branches {
+			if _, err := EvaluateCondition(branch.Condition, ""); err != nil {
+				return fmt.Errorf("%w: step %s: %v", ErrInvalidPlan, step.ID, err)
+			}
+			for _, target := range branch.Next {
+				if p.step(target) == nil {
+					return fmt.Errorf("%w: step %s branches to unknown step %s", ErrInvalidPlan, step.ID, target)
+				}
+				if !p.dependsOn(target, step.ID) {
+					return fmt.Errorf("%w: branch target %s must depend on decision %s", ErrInvalidPlan, target, step.ID)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// dependsOn reports whether step id transitively depends on step ancestor.
+func (p *Plan) dependsOn(id string, ancestor string) bool {
+	index := make(map[string]int, len(p.Steps))
+	for i := range p.Steps {
+		index[p.Steps[i].ID] = i
+	}
+
+	seen := make(map[string]bool)
+	stack := []string{id}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		i, exists := index[current]
+		if !exists {
+			continue
+		}
+		for _, dep := range p.DependenciesOf(i) {
+			if dep == ancestor {
+				return true
+			}
+			if !seen[dep] {
+				seen[dep] = true
+				stack = append(stack, dep)
+			}
+		}
+	}
+
+	return false
+}
+
+// step returns a pointer to the step with the given ID, or nil if absent.
+func (p *Plan) step(id string) *Step {
+	for i := range p.Steps {
+		if p.Steps[i].ID == id {
+			return &p.Steps[i]
+		}
+	}
+	return nil
+}
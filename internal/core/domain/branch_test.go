@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		output    string
+		want      bool
+		wantErr   bool
+	}{
+		{condition: "default", output: "anything", want: true},
+		{condition: " default ", output: "", want: true},
+		{condition: "equals:yes", output: "  YES\n", want: true},
+		{condition: "equals: yes ", output: "yes", want: true},
+		{condition: "equals:yes", output: "yes please", want: false},
+		{condition: "contains:error", output: "An ERROR occurred", want: true},
+		{condition: "contains:error", output: "all good", want: false},
+		{condition: "prefix:ok", output: "  OK, done", want: true},
+		{condition: "prefix:ok", output: "not ok", want: false},
+		{condition: "EQUALS:yes", output: "yes", want: true},
+		{condition: "regex:^[0-9]+$", output: "12345", want: true},
+		{condition: "regex:^[0-9]+$", output: "12a45", want: false},
+		{condition: "regex:(", output: "", wantErr: true},
+		{condition: "like:yes", output: "yes", wantErr: true},
+		{condition: "yes", output: "yes", wantErr: true},
+		{condition: "", output: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition+"/"+tt.output, func(t *testing.T) {
+			got, err := EvaluateCondition(tt.condition, tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EvaluateCondition(%q, %q) error = %v, wantErr %v", tt.condition, tt.output, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("EvaluateCondition(%q, %q) = %v, want %v", tt.condition, tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStepSelectBranch(t *testing.T) {
+	decision := &Step{ID: "decide", Type: StepTypeDecision, Branches: []Branch{
+		{Condition: ConditionDefault, Next: []string{"fallback"}},
+		{Condition: "equals:yes", Next: []string{"yes"}},
+		{Condition: "contains:y", Next: []string{"maybe"}},
+	}}
+	noDefault := &Step{ID: "decide", Type: StepTypeDecision, Branches: []Branch{
+		{Condition: "equals:yes", Next: []string{"yes"}},
+	}}
+	invalid := &Step{ID: "decide", Type: StepTypeDecision, Branches: []Branch{
+		{Condition: "regex:(", Next: []string{"yes"}},
+		{Condition: ConditionDefault, Next: []string{"fallback"}},
+	}}
+
+	tests := []struct {
+		name    string
+		step    *Step
+		output  string
+		want    int
+		wantErr bool
+	}{
+		{name: "first match wins", step: decision, output: "yes", want: 1},
+		{name: "later branch matches", step: decision, output: "maybe yesterday", want: 2},
+		{name: "default only without a match", step: decision, output: "no", want: 0},
+		{name: "no match without default", step: noDefault, output: "no", want: -1, wantErr: true},
+		{name: "invalid condition", step: invalid, output: "yes", want: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.step.SelectBranch(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectBranch(%q) error = %v, wantErr %v", tt.output, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SelectBranch(%q) = %d, want %d", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanUntakenBranchSteps(t *testing.T) {
+	decide := func(id string, targets ...[]string) Step {
+		step := Step{ID: id, Type: StepTypeDecision, Status: StepStatusCompleted}
+		for _, next := range targets {
+			step.Branches = append(step.Branches, Branch{Condition: ConditionDefault, Next: next})
+		}
+		return step
+	}
+	pending := func(id string, deps ...string) Step {
+		return Step{ID: id, Status: StepStatusPending, DependsOn: deps}
+	}
+
+	tests := []struct {
+		name     string
+		steps    []Step
+		decision string
+		taken    int
+		want     []string
+	}{
+		{
+			name: "linear plan skips only the untaken target",
+			steps: []Step{
+				decide("d", []string{"x"}, []string{"y"}),
+				{ID: "x", Status: StepStatusPending},
+				{ID: "y", Status: StepStatusPending},
+				{ID: "z", Status: StepStatusPending},
+			},
+			decision: "d",
+			taken:    0,
+			want:     []string{"y"},
+		},
+		{
+			name: "linear plan taking the last target",
+			steps: []Step{
+				decide("d", []string{"x"}, []string{"y"}),
+				{ID: "x", Status: StepStatusPending},
+				{ID: "y", Status: StepStatusPending},
+				{ID: "z", Status: StepStatusPending},
+			},
+			decision: "d",
+			taken:    1,
+			want:     []string{"x"},
+		},
+		{
+			name: "diamond join still runs",
+			steps: []Step{
+				decide("d", []string{"x"}, []string{"y"}),
+				pending("x", "d"),
+				pending("y", "d"),
+				pending("z", "x", "y"),
+			},
+			decision: "d",
+			taken:    0,
+			want:     []string{"y"},
+		},
+		{
+			name: "steps only behind the untaken branch are skipped",
+			steps: []Step{
+				decide("d", []string{"x"}, []string{"y"}),
+				pending("x", "d"),
+				pending("y", "d"),
+				pending("y2", "y"),
+				pending("y3", "y2"),
+				pending("z", "x", "y3"),
+			},
+			decision: "d",
+			taken:    0,
+			want:     []string{"y", "y2", "y3"},
+		},
+		{
+			name: "nested decision behind the untaken branch",
+			steps: []Step{
+				decide("d1", []string{"a"}, []string{"b"}),
+				{ID: "a", Type: StepTypeDecision, Status: StepStatusPending, DependsOn: []string{"d1"}, Branches: []Branch{
+					{Condition: ConditionDefault, Next: []string{"c"}},
+					{Condition: "equals:e", Next: []string{"e"}},
+				}},
+				pending("b", "d1"),
+				pending("c", "a"),
+				pending("e", "a"),
+				pending("f", "c", "e"),
+				pending("j", "b", "f"),
+			},
+			decision: "d1",
+			taken:    1,
+			want:     []string{"a", "c", "e", "f"},
+		},
+		{
+			name: "nested decision on the taken branch",
+			steps: []Step{
+				decide("d1", []string{"a"}, []string{"b"}),
+				{ID: "a", Type: StepTypeDecision, Status: StepStatusCompleted, DependsOn: []string{"d1"}, Branches: []Branch{
+					{Condition: ConditionDefault, Next: []string{"c"}},
+					{Condition: "equals:e", Next: []string{"e"}},
+				}},
+				{ID: "b", Status: StepStatusSkipped, DependsOn: []string{"d1"}},
+				pending("c", "a"),
+				pending("e", "a"),
+				pending("f", "c", "e"),
+			},
+			decision: "a",
+			taken:    1,
+			want:     []string{"c"},
+		},
+		{
+			name: "target shared with the taken branch is kept",
+			steps: []Step{
+				decide("d", []string{"x", "y"}, []string{"y"}),
+				pending("x", "d"),
+				pending("y", "d"),
+			},
+			decision: "d",
+			taken:    1,
+			want:     []string{"x"},
+		},
+		{
+			name: "finished steps are not reported",
+			steps: []Step{
+				decide("d", []string{"x"}, []string{"y"}),
+				pending("x", "d"),
+				{ID: "y", Status: StepStatusSkipped, DependsOn: []string{"d"}},
+			},
+			decision: "d",
+			taken:    0,
+			want:     []string{},
+		},
+		{
+			name:     "unknown decision",
+			steps:    []Step{pending("x")},
+			decision: "missing",
+			taken:    0,
+			want:     nil,
+		},
+		{
+			name: "branch index out of range",
+			steps: []Step{
+				decide("d", []string{"x"}),
+				pending("x", "d"),
+			},
+			decision: "d",
+			taken:    1,
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &Plan{ID: "plan-1", Steps: tt.steps}
+			if got := plan.UntakenBranchSteps(tt.decision, tt.taken); !slices.Equal(got, tt.want) {
+				t.Errorf("UntakenBranchSteps(%s, %d) = %v, want %v", tt.decision, tt.taken, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MetadataBudgetExceeded is the Task.Metadata key holding why a task was paused in BUDGET_EXCEEDED.
+// It is removed when the task resumes.
+const MetadataBudgetExceeded = "budget_exceeded_reason"
+
+// BudgetLimit caps LLM spend. Zero fields are unlimited.
+type BudgetLimit struct {
+	MaxCostUSD float64 `json:"max_cost_usd,omitempty"`
+	MaxTokens  int64   `json:"max_tokens,omitempty"`
+}
+
+// IsZero reports whether the limit caps nothing.
+func (l BudgetLimit) IsZero() bool {
+	return l.MaxCostUSD == 0 && l.MaxTokens == 0
+}
+
+// Validate checks that no cap is negative.
+func (l BudgetLimit) Validate() error {
+	if l.MaxCostUSD < 0 {
+		return fmt.Errorf("%w: max cost cannot be negative: %g", ErrInvalidBudget, l.MaxCostUSD)
+	}
+	if l.MaxTokens < 0 {
+		return fmt.Errorf("%w: max tokens cannot be negative: %d", ErrInvalidBudget, l.MaxTokens)
+	}
+	return nil
+}
+
+// Exceeded describes the first cap the spend has reached, or returns "" if it is within the limit.
+// Reaching a cap counts as exceeding it: the next LLM call would overspend.
+func (l BudgetLimit) Exceeded(spent UsageTotals) string {
+	if l.MaxCostUSD > 0 && spent.CostUSD >= l.MaxCostUSD {
+		return fmt.Sprintf("cost $%.4f reached the limit of $%.4f", spent.CostUSD, l.MaxCostUSD)
+	}
+	if l.MaxTokens > 0 && spent.TotalTokens >= l.MaxTokens {
+		return fmt.Sprintf("%d tokens reached the limit of %d", spent.TotalTokens, l.MaxTokens)
+	}
+	return ""
+}
+
+// Remaining returns what is left of each cap after spent, e.g., to hand down to sub-tasks.
+// A cap that is already reached stays at its smallest positive value: zero would lift it.
+func (l BudgetLimit) Remaining(spent UsageTotals) BudgetLimit {
+	var remaining BudgetLimit
+	if l.MaxCostUSD > 0 {
+		remaining.MaxCostUSD = max(l.MaxCostUSD-spent.CostUSD, math.SmallestNonzeroFloat64)
+	}
+	if l.MaxTokens > 0 {
+		remaining.MaxTokens = max(l.MaxTokens-spent.TotalTokens, 1)
+	}
+	return remaining
+}
+
+// UserBudget caps the LLM spend of a user's tasks per calendar day and month (UTC)
+type UserBudget struct {
+	UserID    string      `json:"user_id"`
+	Daily     BudgetLimit `json:"daily"`
+	Monthly   BudgetLimit `json:"monthly"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	UpdatedBy string      `json:"updated_by"`
+}
+
+// Validate checks the daily and monthly limits.
+func (b *UserBudget) Validate() error {
+	if err := b.Daily.Validate(); err != nil {
+		return fmt.Errorf("daily: %w", err)
+	}
+	if err := b.Monthly.Validate(); err != nil {
+		return fmt.Errorf("monthly: %w", err)
+	}
+	return nil
+}
+
+// BudgetPeriods returns the start of the UTC day and month that contain now.
+func BudgetPeriods(now time.Time) (dayStart time.Time, monthStart time.Time) {
+	now = now.UTC()
+	dayStart = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return dayStart, monthStart
+}
+
+// BudgetStatus reports a user's budget together with the spend counted against it
+type BudgetStatus struct {
+	UserBudget
+	IsDefault    bool        `json:"is_default"` // No budget is stored for the user; the configured default applies
+	DayStart     time.Time   `json:"day_start"`
+	MonthStart   time.Time   `json:"month_start"`
+	DailySpent   UsageTotals `json:"daily_spent"`
+	MonthlySpent UsageTotals `json:"monthly_spent"`
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "testing"
+
+func TestBudgetLimitExceeded(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit BudgetLimit
+		spent UsageTotals
+		want  bool
+	}{
+		{name: "unlimited", limit: BudgetLimit{}, spent: UsageTotals{CostUSD: 100, TotalTokens: 1e9}, want: false},
+		{name: "below the cost cap", limit: BudgetLimit{MaxCostUSD: 1}, spent: UsageTotals{CostUSD: 0.99}, want: false},
+		{name: "cost cap reached", limit: BudgetLimit{MaxCostUSD: 1}, spent: UsageTotals{CostUSD: 1}, want: true},
+		{name: "below the token cap", limit: BudgetLimit{MaxTokens: 100}, spent: UsageTotals{TotalTokens: 99}, want: false},
+		{name: "token cap reached", limit: BudgetLimit{MaxTokens: 100}, spent: UsageTotals{TotalTokens: 100}, want: true},
+		{name: "either cap", limit: BudgetLimit{MaxCostUSD: 1, MaxTokens: 100}, spent: UsageTotals{CostUSD: 0.5, TotalTokens: 150}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limit.Exceeded(tt.spent) != ""; got != tt.want {
+				t.Errorf("Exceeded(%+v) = %v, want %v", tt.spent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBudgetLimitRemaining(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit BudgetLimit
+		spent UsageTotals
+		want  BudgetLimit
+	}{
+		{name: "unlimited stays unlimited", limit: BudgetLimit{}, spent: UsageTotals{CostUSD: 5, TotalTokens: 500}, want: BudgetLimit{}},
+		{name: "nothing spent", limit: BudgetLimit{MaxCostUSD: 2, MaxTokens: 1000}, spent: UsageTotals{}, want: BudgetLimit{MaxCostUSD: 2, MaxTokens: 1000}},
+		{name: "partly spent", limit: BudgetLimit{MaxCostUSD: 2, MaxTokens: 1000}, spent: UsageTotals{CostUSD: 0.5, TotalTokens: 400}, want: BudgetLimit{MaxCostUSD: 1.5, MaxTokens: 600}},
+		{name: "only capped fields are handed down", limit: BudgetLimit{MaxTokens: 1000}, spent: UsageTotals{CostUSD: 3, TotalTokens: 250}, want: BudgetLimit{MaxTokens: 750}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limit.Remaining(tt.spent); got != tt.want {
+				t.Errorf("Remaining(%+v) = %+v, want %+v", tt.spent, got, tt.want)
+			}
+		})
+	}
+
+	// A used-up cap must stay a cap: a zero field would mean unlimited
+	used := BudgetLimit{MaxCostUSD: 1, MaxTokens: 100}.Remaining(UsageTotals{CostUSD: 1.5, TotalTokens: 100})
+	if used.MaxCostUSD <= 0 || used.MaxTokens != 1 {
+		t.Fatalf("Remaining of a used-up budget = %+v, want the smallest positive caps", used)
+	}
+	if used.Exceeded(UsageTotals{CostUSD: 0.01, TotalTokens: 1}) == "" {
+		t.Error("the remainder of a used-up budget allows further spend")
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "* * * * *"},
+		{expr: "*/15 9-17 * * MON-FRI"},
+		{expr: "0-30/10 0,12 1,15 JAN-jun 0-7"},
+		{expr: "  0 0 1 1 *  "},
+		{expr: "@hourly"},
+		{expr: "@DAILY"},
+		{expr: "@midnight"},
+		{expr: "@weekly"},
+		{expr: "@monthly"},
+		{expr: "@yearly"},
+		{expr: "@annually"},
+		{expr: "", wantErr: true},
+		{expr: "* * * *", wantErr: true},
+		{expr: "* * * * * *", wantErr: true},
+		{expr: "@every", wantErr: true},
+		{expr: "60 * * * *", wantErr: true},
+		{expr: "* 24 * * *", wantErr: true},
+		{expr: "* * 0 * *", wantErr: true},
+		{expr: "* * 32 * *", wantErr: true},
+		{expr: "* * * 13 *", wantErr: true},
+		{expr: "* * * * 8", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "0-30/x * * * *", wantErr: true},
+		{expr: "5-1 * * * *", wantErr: true},
+		{expr: "abc * * * *", wantErr: true},
+		{expr: "* * * FOO *", wantErr: true},
+		{expr: "* * * * MON-FOO", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCron(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronExpressionNext(t *testing.T) {
+	// Friday, 16 October 2026
+	after := time.Date(2026, 10, 16, 10, 0, 30, 0, time.UTC)
+	plus2 := time.FixedZone("UTC+2", 2*60*60)
+
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{name: "every minute drops seconds", expr: "* * * * *", after: after, want: time.Date(2026, 10, 16, 10, 1, 0, 0, time.UTC)},
+		{name: "minute step", expr: "*/15 * * * *", after: after, want: time.Date(2026, 10, 16, 10, 15, 0, 0, time.UTC)},
+		{name: "minute list", expr: "5,45 * * * *", after: after, want: time.Date(2026, 10, 16, 10, 5, 0, 0, time.UTC)},
+		{name: "hourly descriptor", expr: "@hourly", after: after, want: time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC)},
+		{name: "strictly after a matching time", expr: "0 10 16 10 *", after: time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC), want: time.Date(2027, 10, 16, 10, 0, 0, 0, time.UTC)},
+		{name: "weekdays skip the weekend", expr: "30 9 * * MON-FRI", after: after, want: time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)},
+		{name: "7 is Sunday", expr: "0 12 * * 7", after: after, want: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)},
+		{name: "day of month only", expr: "0 0 13 * *", after: after, want: time.Date(2026, 11, 13, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month or day of week", expr: "0 0 13 * 5", after: after, want: time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)},
+		{name: "month range wraps the year", expr: "0 0 1 JAN-MAR *", after: after, want: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "yearly descriptor", expr: "@yearly", after: after, want: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "leap day", expr: "0 0 29 2 *", after: after, want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "impossible date", expr: "0 0 30 2 *", after: after, want: time.Time{}},
+		{name: "evaluated in the location of after", expr: "0 9 * * *", after: time.Date(2026, 10, 16, 10, 0, 0, 0, plus2), want: time.Date(2026, 10, 17, 9, 0, 0, 0, plus2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) = %v", tt.expr, err)
+			}
+			if got := cron.Next(tt.after); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.after, got, tt.want)
+			}
+		})
+	}
+}
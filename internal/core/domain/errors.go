@@ -0,0 +1,105 @@
+package domain
+
+import "errors"
+
+// Sentinel errors returned (wrapped) by the core so adapters can map them to protocol codes.
+// Callers should test for them with errors.Is.
+var (
+	// ErrTaskNotWaitingApproval is returned when an approval decision targets a task
+	// that is not in WAITING_APPROVAL status.
+	ErrTaskNotWaitingApproval = errors.New("task is not waiting for approval")
+
+	// ErrStepMismatch is returned when an approval decision targets a step other than
+	// the one the task is currently suspended on.
+	ErrStepMismatch = errors.New("step mismatch")
+
+	// ErrApprovalNotOpen is returned when the targeted step has no OPEN approval request.
+	ErrApprovalNotOpen = errors.New("no open approval request")
+
+	// ErrTaskFinished is returned when an operation requires a running task but the task
+	// has already reached a terminal status.
+	ErrTaskFinished = errors.New("task already finished")
+
+	// ErrInvalidTransition is returned (as a *TransitionError) when a task or step is moved
+	// to a status its state machine does not allow, e.g., a DONE task back to EXECUTING.
+	ErrInvalidTransition = errors.New("invalid status transition")
+
+	// ErrQueueFull is returned when a task cannot be accepted because the task queue is at capacity.
+	ErrQueueFull = errors.New("task queue is full")
+
+	// ErrInvalidPriority is returned when a task is submitted with an unknown priority level.
+	ErrInvalidPriority = errors.New("invalid task priority")
+
+	// ErrInvalidSchedule is returned when a schedule definition is malformed
+	// (e.g., a bad cron expression or both cron_expr and run_at set).
+	ErrInvalidSchedule = errors.New("invalid schedule")
+
+	// ErrScheduleCompleted is returned when pausing or resuming a one-shot schedule that has already fired.
+	ErrScheduleCompleted = errors.New("schedule already completed")
+
+	// ErrInvalidDeadline is returned when a task is submitted with a deadline that has already passed
+	// or a negative timeout.
+	ErrInvalidDeadline = errors.New("invalid task deadline")
+
+	// ErrStepTimeout is returned (wrapped) when a step attempt exceeds its timeout.
+	ErrStepTimeout = errors.New("step timed out")
+
+	// ErrDeadlineExceeded is the cause of interrupted executions whose task deadline has passed.
+	ErrDeadlineExceeded = errors.New("task deadline exceeded")
+
+	// ErrUnknownAgent is returned when a task names an agent that is not registered.
+	ErrUnknownAgent = errors.New("unknown agent")
+
+	// ErrToolNotAllowed is returned when a step calls a tool outside its agent's allowed set.
+	ErrToolNotAllowed = errors.New("tool not allowed for agent")
+
+	// ErrIdempotencyKeyReused is returned when an idempotency key is repeated with a request
+	// that differs from the one that first used it.
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different request")
+
+	// ErrIdempotencyKeyInUse is returned when an idempotency key is repeated while the task
+	// of the first request is still being created.
+	ErrIdempotencyKeyInUse = errors.New("idempotency key in use by a request in progress")
+
+	// ErrInvalidBudget is returned when a task or user budget has a negative limit.
+	ErrInvalidBudget = errors.New("invalid budget")
+
+	// ErrTaskNotOverBudget is returned when a task budget is updated for a task that is not
+	// paused in BUDGET_EXCEEDED status.
+	ErrTaskNotOverBudget = errors.New("task is not paused over budget")
+
+	// ErrNotBudgetAdmin is returned when a budget is changed by an actor that is not one of
+	// the configured budget administrators.
+	ErrNotBudgetAdmin = errors.New("not a budget administrator")
+
+	// ErrInvalidArtifactName is returned when an artifact name is not a plain file name
+	// (e.g., it is empty or contains path separators).
+	ErrInvalidArtifactName = errors.New("invalid artifact name")
+
+	// ErrArtifactNotFound is returned when a task has no artifact with the requested name.
+	ErrArtifactNotFound = errors.New("artifact not found")
+
+	// ErrInvalidToolCallStatus is returned when tool calls are filtered by an unknown status.
+	ErrInvalidToolCallStatus = errors.New("invalid tool call status")
+
+	// ErrInvalidTimeRange is returned when a query's time range ends before it starts.
+	ErrInvalidTimeRange = errors.New("invalid time range")
+
+	// ErrInvalidPlan is returned when a plan is malformed (e.g., dependency cycles or unknown steps).
+	ErrInvalidPlan = errors.New("invalid plan")
+
+	// ErrTransient marks step failures worth retrying (rate limits, flaky network, timeouts).
+	// Executors and tools should wrap such errors with it (fmt.Errorf("...: %w", ErrTransient)).
+	ErrTransient = errors.New("transient failure")
+
+	// ErrPermanent marks step failures that cannot succeed on retry (invalid input, access denied).
+	ErrPermanent = errors.New("permanent failure")
+)
+
+// Error codes stored in StepResult.ErrorCode and Task.Metadata["failure_code"]
+const (
+	ErrorCodeStepTimeout      = "STEP_TIMEOUT"           // A step attempt ran longer than its timeout
+	ErrorCodeDeadlineExceeded = "TASK_DEADLINE_EXCEEDED" // The task deadline passed while the step or task was running
+	ErrorCodeSubTaskFailed    = "SUBTASK_FAILED"         // A child task of a SUB_TASK step did not finish DONE
+	ErrorCodeToolNotAllowed   = "TOOL_NOT_ALLOWED"       // The step called a tool its agent may not use
+)
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// IdempotencyRecord remembers which task a client's idempotency key created.
+// Keys are scoped per user: different users may use the same key independently.
+type IdempotencyRecord struct {
+	UserID      string    `json:"user_id"`
+	Key         string    `json:"key"`         // Client-chosen key (Idempotency-Key header)
+	Fingerprint string    `json:"fingerprint"` // Hash of the creation request (see TaskRequestFingerprint)
+	TaskID      string    `json:"task_id"`     // Task created by the first request with this key
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"` // After this the key may be reused for a new task
+}
+
+// IsExpired reports whether the record no longer protects its key at the given time.
+func (r *IdempotencyRecord) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// TaskRequestFingerprint hashes a task creation request so repeats can be told apart
+// from different requests reusing the same idempotency key. The key itself is excluded.
+func TaskRequestFingerprint(input string, opts TaskOptions) string {
+	opts.IdempotencyKey = ""
+	request := struct {
+		Input   string      `json:"input"`
+		Options TaskOptions `json:"options"`
+	}{Input: input, Options: opts}
+
+	// Marshaling cannot fail: TaskOptions holds only strings, times and a string map
+	// (map keys are sorted, so equal requests hash equally).
+	data, _ := json.Marshal(request)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EntityType classifies a parameter extracted from a task input
+type EntityType string
+
+const (
+	EntityTypeDate     EntityType = "DATE"     // Calendar date, normalized to YYYY-MM-DD when resolvable
+	EntityTypeAmount   EntityType = "AMOUNT"   // Money amount, normalized to "<number> <ISO currency>"
+	EntityTypeLocation EntityType = "LOCATION" // Place name (city, district, address)
+)
+
+// Metadata keys under which the intent normalization stage stores its results in Task.Metadata
+const (
+	MetadataIntentLanguage   = "intent_language"   // ISO 639-1 code of the input language, empty if unknown
+	MetadataIntentConfidence = "intent_confidence" // Normalizer confidence formatted with two decimals
+	MetadataIntentEntities   = "intent_entities"   // JSON array of Entity
+)
+
+// Entity is a structured parameter extracted from a task input,
+// e.g., the "800 EUR" budget or the "Vracar" district of an apartment search.
+type Entity struct {
+	Type  EntityType `json:"type"`
+	Value string     `json:"value"` // Normalized value (e.g., "800 EUR", "2026-10-17")
+	Text  string     `json:"text"`  // The span of the input the entity was read from
+}
+
+// Intent is the result of normalizing a task input before planning
+type Intent struct {
+	Text       string   `json:"text"`       // Cleaned-up request; stored in Task.NormalizedIntent
+	Language   string   `json:"language"`   // ISO 639-1 code, empty if unknown
+	Entities   []Entity `json:"entities"`   // Extracted parameters in input order
+	Confidence float64  `json:"confidence"` // 0-1: how reliable the normalization is
+}
+
+// Validate checks that an intent can be applied to a task.
+func (i *Intent) Validate() error {
+	if strings.TrimSpace(i.Text) == "" {
+		return fmt.Errorf("intent text cannot be empty")
+	}
+	if i.Confidence < 0 || i.Confidence > 1 {
+		return fmt.Errorf("intent confidence must be between 0 and 1: %v", i.Confidence)
+	}
+	for _, entity := range i.Entities {
+		if entity.Type == "" || entity.Value == "" {
+			return fmt.Errorf("intent entities need a type and a value")
+		}
+	}
+	return nil
+}
+
+// ApplyTo stores the intent on a task: the text as NormalizedIntent and the language,
+// confidence and entities in Metadata (see the MetadataIntent* keys).
+func (i *Intent) ApplyTo(task *Task) {
+	entities := i.Entities
+	if entities == nil {
+		entities = []Entity{}
+	}
+	// Marshaling cannot fail: Entity holds only strings
+	encoded, _ := json.Marshal(entities)
+
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]string)
+	}
+	task.NormalizedIntent = i.Text
+	task.Metadata[MetadataIntentLanguage] = i.Language
+	task.Metadata[MetadataIntentConfidence] = fmt.Sprintf("%.2f", i.Confidence)
+	task.Metadata[MetadataIntentEntities] = string(encoded)
+}
+
+// IntentEntities returns the entities the intent normalization stage stored on the task.
+// It returns nil if the task has not been normalized or the stored value is malformed.
+func (t *Task) IntentEntities() []Entity {
+	var entities []Entity
+	if err := json.Unmarshal([]byte(t.Metadata[MetadataIntentEntities]), &entities); err != nil {
+		return nil
+	}
+	return entities
+}
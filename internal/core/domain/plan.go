@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"time"
+)
+
 // StepType represents the type of a plan step
 type StepType string
 
@@ -10,6 +15,7 @@ const (
 	StepTypeDecision     StepType = "DECISION"
 	StepTypeApprovalGate StepType = "APPROVAL_GATE"
 	StepTypeVerify       StepType = "VERIFY"
+	StepTypeSubTask      StepType = "SUB_TASK"
 )
 
 // StepStatus represents the current status of a step
@@ -35,17 +41,23 @@ const (
 
 // Step represents a single step in a plan
 type Step struct {
-	ID               string     `json:"id"`
-	Title            string     `json:"title"`
-	Description      string     `json:"description"`
-	Type             StepType   `json:"type"`
-	Status           StepStatus `json:"status"`
-	ToolName         string     `json:"tool_name,omitempty"`
-	ToolInput        string     `json:"tool_input"`
-	RiskLevel        RiskLevel  `json:"risk_level"`
-	RequiresApproval bool       `json:"requires_approval"`
-	RetryCount       int        `json:"retry_count"`
-	ResultRef        string     `json:"result_ref"`
+	ID               string        `json:"id"`
+	Title            string        `json:"title"`
+	Description      string        `json:"description"`
+	Type             StepType      `json:"type"`
+	Status           StepStatus    `json:"status"`
+	ToolName         string        `json:"tool_name,omitempty"`
+	ToolInput        string        `json:"tool_input"`
+	RiskLevel        RiskLevel     `json:"risk_level"`
+	RequiresApproval bool          `json:"requires_approval"`
+	RetryCount       int           `json:"retry_count"`
+	ResultRef        string        `json:"result_ref"`               // ResultStore reference of an output too large to keep inline
+	RetryPolicy      *RetryPolicy  `json:"retry_policy,omitempty"`   // Overrides the orchestrator default when set
+	DependsOn        []string      `json:"depends_on,omitempty"`     // IDs of steps that must finish before this one
+	Branches         []Branch      `json:"branches,omitempty"`       // DECISION only: routes evaluated against the step output
+	TimeoutMs        int64         `json:"timeout_ms,omitempty"`     // Per-attempt execution timeout; 0 uses the orchestrator default
+	SubTasks         []SubTaskSpec `json:"sub_tasks,omitempty"`      // SUB_TASK only: child tasks to spawn
+	ChildTaskIDs     []string      `json:"child_task_ids,omitempty"` // SUB_TASK only: IDs of the spawned child tasks
 }
 
 // Plan represents an execution plan for a task
@@ -55,4 +67,147 @@ type Plan struct {
 	Goal        string `json:"goal"`
 	Steps       []Step `json:"steps"`
 	RiskSummary string `json:"risk_summary"`
+
+	// Revisions - Set on plans produced by replanning after failed verification
+	Revision       int    `json:"revision,omitempty"`         // 0 for the original plan, incremented per replan
+	PreviousPlanID string `json:"previous_plan_id,omitempty"` // Plan this revision replaces
+	RevisionReason string `json:"revision_reason,omitempty"`  // Verification failure that triggered the revision
+}
+
+// NeedsApproval reports whether the step must be approved by a human before it runs.
+// A step is gated if it is explicitly flagged, classified as high risk, or is an approval gate.
+func (s *Step) NeedsApproval() bool {
+	return s.RequiresApproval || s.RiskLevel == RiskLevelHigh || s.Type == StepTypeApprovalGate
+}
+
+// Timeout returns the per-attempt execution timeout of the step, or fallback if TimeoutMs is not set.
+// A zero result means the step is not time-limited.
+func (s *Step) Timeout(fallback time.Duration) time.Duration {
+	if s.TimeoutMs > 0 {
+		return time.Duration(s.TimeoutMs) * time.Millisecond
+	}
+	return fallback
+}
+
+// HasExplicitDependencies reports whether any step of the plan declares DependsOn.
+func (p *Plan) HasExplicitDependencies() bool {
+	for i := range p.Steps {
+		if len(p.Steps[i].DependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// DependenciesOf returns the IDs of the steps that must finish before the step at index i.
+// Plans that declare no dependencies at all keep their original linear semantics:
+// every step depends on its predecessor.
+func (p *Plan) DependenciesOf(i int) []string {
+	if p.HasExplicitDependencies() {
+		return p.Steps[i].DependsOn
+	}
+	if i == 0 {
+		return nil
+	}
+	return []string{p.Steps[i-1].ID}
+}
+
+// Validate checks that the plan forms a directed acyclic graph of uniquely identified steps.
+// It returns an error wrapping ErrInvalidPlan on duplicate or empty step IDs, unknown or
+// self-referencing dependencies, dependency cycles, and malformed DECISION branches.
+func (p *Plan) Validate() error {
+	index := make(map[string]int, len(p.Steps))
+	for i := range p.Steps {
+		id := p.Steps[i].ID
+		if id == "" {
+			return fmt.Errorf("%w: step %d has an empty ID", ErrInvalidPlan, i)
+		}
+		if _, exists := index[id]; exists {
+			return fmt.Errorf("%w: duplicate step ID %s", ErrInvalidPlan, id)
+		}
+		index[id] = i
+	}
+
+	// Kahn's algorithm: every step must become reachable once its dependencies are removed
+	inDegree := make([]int, len(p.Steps))
+	dependents := make([][]int, len(p.Steps))
+	for i := range p.Steps {
+		for _, dep := range p.Steps[i].DependsOn {
+			j, exists := index[dep]
+			if !exists {
+				return fmt.Errorf("%w: step %s depends on unknown step %s", ErrInvalidPlan, p.Steps[i].ID, dep)
+			}
+			if j == i {
+				return fmt.Errorf("%w: step %s depends on itself", ErrInvalidPlan, p.Steps[i].ID)
+			}
+			inDegree[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	queue := make([]int, 0, len(p.Steps))
+	for i, degree := range inDegree {
+		if degree == 0 {
+			queue = append(queue, i)
+		}
+	}
+	visited := 0
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		visited++
+		for _, j := range dependents[i] {
+			inDegree[j]--
+			if inDegree[j] == 0 {
+				queue = append(queue, j)
+			}
+		}
+	}
+	if visited != len(p.Steps) {
+		return fmt.Errorf("%w: dependency cycle detected", ErrInvalidPlan)
+	}
+
+	if err := p.validateBranches(); err != nil {
+		return err
+	}
+
+	return p.validateSubTasks()
+}
+
+// ReadySteps returns the PENDING steps whose dependencies have all finished
+// (COMPLETED or SKIPPED), in plan order.
+func (p *Plan) ReadySteps() []*Step {
+	status := make(map[string]StepStatus, len(p.Steps))
+	for i := range p.Steps {
+		status[p.Steps[i].ID] = p.Steps[i].Status
+	}
+
+	ready := make([]*Step, 0)
+	for i := range p.Steps {
+		if p.Steps[i].Status != StepStatusPending {
+			continue
+		}
+		satisfied := true
+		for _, dep := range p.DependenciesOf(i) {
+			if status[dep] != StepStatusCompleted && status[dep] != StepStatusSkipped {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			ready = append(ready, &p.Steps[i])
+		}
+	}
+
+	return ready
+}
+
+// HasPendingSteps reports whether any step of the plan is still PENDING.
+func (p *Plan) HasPendingSteps() bool {
+	for i := range p.Steps {
+		if p.Steps[i].Status == StepStatusPending {
+			return true
+		}
+	}
+	return false
 }
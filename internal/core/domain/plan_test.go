@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestPlanValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []Step
+		wantErr bool
+	}{
+		{
+			name:  "linear plan without dependencies",
+			steps: []Step{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+		},
+		{
+			name: "diamond DAG",
+			steps: []Step{
+				{ID: "a"},
+				{ID: "b", DependsOn: []string{"a"}},
+				{ID: "c", DependsOn: []string{"a"}},
+				{ID: "d", DependsOn: []string{"b", "c"}},
+			},
+		},
+		{
+			name:  "empty plan",
+			steps: nil,
+		},
+		{
+			name:    "empty step ID",
+			steps:   []Step{{ID: "a"}, {ID: ""}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate step ID",
+			steps:   []Step{{ID: "a"}, {ID: "a"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown dependency",
+			steps:   []Step{{ID: "a"}, {ID: "b", DependsOn: []string{"missing"}}},
+			wantErr: true,
+		},
+		{
+			name:    "self dependency",
+			steps:   []Step{{ID: "a", DependsOn: []string{"a"}}},
+			wantErr: true,
+		},
+		{
+			name: "dependency cycle",
+			steps: []Step{
+				{ID: "a", DependsOn: []string{"c"}},
+				{ID: "b", DependsOn: []string{"a"}},
+				{ID: "c", DependsOn: []string{"b"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decision branches to dependent steps",
+			steps: []Step{
+				{ID: "decide", Type: StepTypeDecision, Branches: []Branch{
+					{Condition: "equals:yes", Next: []string{"yes"}},
+					{Condition: ConditionDefault, Next: []string{"no"}},
+				}},
+				{ID: "yes", DependsOn: []string{"decide"}},
+				{ID: "no", DependsOn: []string{"decide"}},
+			},
+		},
+		{
+			name: "branches on a non-decision step",
+			steps: []Step{
+				{ID: "a", Type: StepTypeThink, Branches: []Branch{{Condition: ConditionDefault, Next: []string{"b"}}}},
+				{ID: "b", DependsOn: []string{"a"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "branch with unknown operator",
+			steps: []Step{
+				{ID: "decide", Type: StepTypeDecision, Branches: []Branch{{Condition: "like:yes", Next: []string{"b"}}}},
+				{ID: "b", DependsOn: []string{"decide"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "branch target does not depend on the decision",
+			steps: []Step{
+				{ID: "decide", Type: StepTypeDecision, Branches: []Branch{{Condition: ConditionDefault, Next: []string{"b"}}}},
+				{ID: "b", DependsOn: []string{"c"}},
+				{ID: "c"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sub-task step without sub-tasks",
+			steps: []Step{
+				{ID: "spawn", Type: StepTypeSubTask},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sub-task with an unknown priority",
+			steps: []Step{
+				{ID: "spawn", Type: StepTypeSubTask, SubTasks: []SubTaskSpec{{Input: "child", Priority: "CRITICAL"}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &Plan{ID: "plan-1", Steps: tt.steps}
+			err := plan.Validate()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPlan) {
+					t.Fatalf("Validate() = %v, want an error wrapping ErrInvalidPlan", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestPlanReadySteps(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []Step
+		want  []string
+	}{
+		{
+			name: "linear plan runs the first pending step",
+			steps: []Step{
+				{ID: "a", Status: StepStatusPending},
+				{ID: "b", Status: StepStatusPending},
+			},
+			want: []string{"a"},
+		},
+		{
+			name: "linear plan waits for the predecessor",
+			steps: []Step{
+				{ID: "a", Status: StepStatusCompleted},
+				{ID: "b", Status: StepStatusInProgress},
+				{ID: "c", Status: StepStatusPending},
+			},
+			want: nil,
+		},
+		{
+			name: "linear plan continues after a skipped step",
+			steps: []Step{
+				{ID: "a", Status: StepStatusSkipped},
+				{ID: "b", Status: StepStatusPending},
+			},
+			want: []string{"b"},
+		},
+		{
+			name: "independent roots are ready together",
+			steps: []Step{
+				{ID: "a", Status: StepStatusPending},
+				{ID: "b", Status: StepStatusPending},
+				{ID: "c", Status: StepStatusPending, DependsOn: []string{"a", "b"}},
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "join waits for every dependency",
+			steps: []Step{
+				{ID: "a", Status: StepStatusCompleted},
+				{ID: "b", Status: StepStatusInProgress},
+				{ID: "c", Status: StepStatusPending, DependsOn: []string{"a", "b"}},
+			},
+			want: nil,
+		},
+		{
+			name: "completed and skipped dependencies are satisfied",
+			steps: []Step{
+				{ID: "a", Status: StepStatusCompleted},
+				{ID: "b", Status: StepStatusSkipped},
+				{ID: "c", Status: StepStatusPending, DependsOn: []string{"a", "b"}},
+			},
+			want: []string{"c"},
+		},
+		{
+			name: "failed dependency blocks",
+			steps: []Step{
+				{ID: "a", Status: StepStatusFailed},
+				{ID: "b", Status: StepStatusPending, DependsOn: []string{"a"}},
+			},
+			want: nil,
+		},
+		{
+			name: "finished steps are not ready",
+			steps: []Step{
+				{ID: "a", Status: StepStatusCompleted},
+				{ID: "b", Status: StepStatusCompleted},
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &Plan{ID: "plan-1", Steps: tt.steps}
+			var got []string
+			for _, step := range plan.ReadySteps() {
+				got = append(got, step.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ReadySteps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// RetryPolicy describes how a failed step is retried
+type RetryPolicy struct {
+	MaxAttempts        int     `json:"max_attempts"`         // Total attempts including the first (1 = no retries)
+	InitialBackoffMs   int64   `json:"initial_backoff_ms"`   // Delay before the first retry
+	MaxBackoffMs       int64   `json:"max_backoff_ms"`       // Upper bound for any single delay
+	Multiplier         float64 `json:"multiplier"`           // Exponential growth factor per attempt
+	Jitter             float64 `json:"jitter"`               // Fraction (0-1) of the delay that is randomized
+	RetryUnknownErrors bool    `json:"retry_unknown_errors"` // Retry errors marked neither transient nor permanent
+}
+
+// Backoff returns the delay to wait after the given failed attempt (1-based).
+// random must be in [0,1); it spreads the delay by ±Jitter so concurrent retries do not align.
+// Passing a fixed value (or using Jitter 0) makes the delay deterministic.
+func (p RetryPolicy) Backoff(attempt int, random float64) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoffMs) * math.Pow(multiplier, float64(attempt-1))
+	if p.Jitter > 0 {
+		delay *= 1 + p.Jitter*(2*random-1)
+	}
+	if p.MaxBackoffMs > 0 && delay > float64(p.MaxBackoffMs) {
+		delay = float64(p.MaxBackoffMs)
+	}
+	if delay < 0 {
+		delay = 0
+	}
+
+	return time.Duration(delay) * time.Millisecond
+}
+
+// ShouldRetry reports whether a step that failed on the given attempt (1-based) with err
+// may be attempted again under this policy.
+func (p RetryPolicy) ShouldRetry(attempt int, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	return IsRetryable(err, p.RetryUnknownErrors)
+}
+
+// IsRetryable classifies a step failure.
+// Errors wrapping ErrPermanent or context.Canceled are never retried, errors wrapping
+// ErrTransient always are, and anything else is retried only if retryUnknown is true.
+func IsRetryable(err error, retryUnknown bool) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, ErrPermanent), errors.Is(err, context.Canceled):
+		return false
+	case errors.Is(err, ErrTransient):
+		return true
+	default:
+		return retryUnknown
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoffMs: 100, MaxBackoffMs: 1000, Multiplier: 2}
+
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		random  float64
+		want    time.Duration
+	}{
+		{name: "first retry", policy: policy, attempt: 1, random: 0.5, want: 100 * time.Millisecond},
+		{name: "grows exponentially", policy: policy, attempt: 3, random: 0.5, want: 400 * time.Millisecond},
+		{name: "capped at max", policy: policy, attempt: 10, random: 0.5, want: time.Second},
+		{name: "attempt below 1 counts as first", policy: policy, attempt: 0, random: 0.5, want: 100 * time.Millisecond},
+		{name: "multiplier below 1 keeps delay constant", policy: RetryPolicy{InitialBackoffMs: 100, Multiplier: 0.5}, attempt: 4, random: 0.5, want: 100 * time.Millisecond},
+		{name: "no max backoff", policy: RetryPolicy{InitialBackoffMs: 100, Multiplier: 10}, attempt: 4, random: 0.5, want: 100 * time.Second},
+		{name: "jitter ignored when disabled", policy: policy, attempt: 2, random: 0, want: 200 * time.Millisecond},
+		{name: "jitter lower bound", policy: RetryPolicy{InitialBackoffMs: 100, Multiplier: 2, Jitter: 0.5}, attempt: 2, random: 0, want: 100 * time.Millisecond},
+		{name: "jitter midpoint", policy: RetryPolicy{InitialBackoffMs: 100, Multiplier: 2, Jitter: 0.5}, attempt: 2, random: 0.5, want: 200 * time.Millisecond},
+		{name: "jitter upper range", policy: RetryPolicy{InitialBackoffMs: 100, Multiplier: 2, Jitter: 0.5}, attempt: 2, random: 0.75, want: 250 * time.Millisecond},
+		{name: "jitter still capped", policy: RetryPolicy{InitialBackoffMs: 800, MaxBackoffMs: 1000, Multiplier: 1, Jitter: 0.5}, attempt: 1, random: 0.99, want: time.Second},
+		{name: "jitter above 1 never negative", policy: RetryPolicy{InitialBackoffMs: 100, Multiplier: 1, Jitter: 2}, attempt: 1, random: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Backoff(tt.attempt, tt.random); got != tt.want {
+				t.Errorf("Backoff(%d, %v) = %v, want %v", tt.attempt, tt.random, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3}
+	unknown := errors.New("boom")
+
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		err     error
+		want    bool
+	}{
+		{name: "transient error retried", policy: policy, attempt: 1, err: fmt.Errorf("rate limited: %w", ErrTransient), want: true},
+		{name: "last attempt not retried", policy: policy, attempt: 3, err: ErrTransient, want: false},
+		{name: "single attempt policy", policy: RetryPolicy{MaxAttempts: 1}, attempt: 1, err: ErrTransient, want: false},
+		{name: "permanent error not retried", policy: policy, attempt: 1, err: fmt.Errorf("bad input: %w", ErrPermanent), want: false},
+		{name: "permanent wins over transient", policy: policy, attempt: 1, err: fmt.Errorf("%w: %w", ErrTransient, ErrPermanent), want: false},
+		{name: "canceled not retried", policy: RetryPolicy{MaxAttempts: 3, RetryUnknownErrors: true}, attempt: 1, err: context.Canceled, want: false},
+		{name: "unknown error not retried by default", policy: policy, attempt: 1, err: unknown, want: false},
+		{name: "unknown error retried when enabled", policy: RetryPolicy{MaxAttempts: 3, RetryUnknownErrors: true}, attempt: 1, err: unknown, want: true},
+		{name: "nil error not retried", policy: policy, attempt: 1, err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.ShouldRetry(tt.attempt, tt.err); got != tt.want {
+				t.Errorf("ShouldRetry(%d, %v) = %v, want %v", tt.attempt, tt.err, got, tt.want)
+			}
+		})
+	}
+}
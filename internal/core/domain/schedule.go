@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ScheduleStatus represents the current status of a schedule
+type ScheduleStatus string
+
+// Schedule status constants
+const (
+	ScheduleStatusActive    ScheduleStatus = "ACTIVE"
+	ScheduleStatusPaused    ScheduleStatus = "PAUSED"
+	ScheduleStatusCompleted ScheduleStatus = "COMPLETED" // One-shot schedule that has fired (or was skipped)
+)
+
+// MissedRunPolicy decides what happens to runs that were due while the scheduler was not running
+type MissedRunPolicy string
+
+// Missed run policy constants
+const (
+	MissedRunSkip    MissedRunPolicy = "SKIP"     // Drop missed runs; only a run still within the misfire grace period starts
+	MissedRunCatchUp MissedRunPolicy = "CATCH_UP" // Start one task per missed run, oldest first (bounded by the scheduler)
+)
+
+// ScheduleSpec is the user-supplied definition of a schedule.
+// Exactly one of CronExpr (recurring) or RunAt (one-shot) must be set.
+type ScheduleSpec struct {
+	UserID          string          `json:"user_id"`
+	Input           string          `json:"input"`
+	Priority        TaskPriority    `json:"priority"`
+	CronExpr        string          `json:"cron_expr,omitempty"` // 5-field cron expression or descriptor such as @daily
+	RunAt           time.Time       `json:"run_at,omitempty"`    // One-shot start time
+	Timezone        string          `json:"timezone"`            // IANA zone the cron expression is evaluated in (default: UTC)
+	MissedRunPolicy MissedRunPolicy `json:"missed_run_policy"`   // Default: SKIP
+}
+
+// Schedule starts tasks at fixed times on behalf of a user
+type Schedule struct {
+	ID string `json:"id"`
+	ScheduleSpec
+	Status     ScheduleStatus `json:"status"`
+	NextRunAt  time.Time      `json:"next_run_at"`  // Zero once no further run is due
+	LastRunAt  time.Time      `json:"last_run_at"`  // Due time of the last run that started a task
+	LastTaskID string         `json:"last_task_id"` // Task started by the last run
+	RunCount   int            `json:"run_count"`    // Tasks started so far
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// ScheduleFilter narrows a schedule listing; empty fields match everything
+type ScheduleFilter struct {
+	UserID string         `json:"user_id,omitempty"`
+	Status ScheduleStatus `json:"status,omitempty"`
+	DueBy  time.Time      `json:"due_by,omitempty"` // Only schedules whose NextRunAt is set and not after DueBy
+}
+
+// Matches reports whether the schedule satisfies every criterion of the filter.
+func (f ScheduleFilter) Matches(schedule *Schedule) bool {
+	if f.UserID != "" && schedule.UserID != f.UserID {
+		return false
+	}
+	if f.Status != "" && schedule.Status != f.Status {
+		return false
+	}
+	if !f.DueBy.IsZero() && (schedule.NextRunAt.IsZero() || schedule.NextRunAt.After(f.DueBy)) {
+		return false
+	}
+	return true
+}
+
+// IsRecurring reports whether the schedule is driven by a cron expression.
+func (s *ScheduleSpec) IsRecurring() bool {
+	return s.CronExpr != ""
+}
+
+// Normalize fills defaults and validates the spec. It returns an error wrapping
+// ErrInvalidSchedule if the definition cannot be scheduled.
+func (s *ScheduleSpec) Normalize() error {
+	s.CronExpr = strings.TrimSpace(s.CronExpr)
+	if s.Input == "" {
+		return fmt.Errorf("%w: input cannot be empty", ErrInvalidSchedule)
+	}
+	if s.UserID == "" {
+		return fmt.Errorf("%w: user_id cannot be empty", ErrInvalidSchedule)
+	}
+	if s.IsRecurring() == !s.RunAt.IsZero() {
+		return fmt.Errorf("%w: exactly one of cron_expr or run_at must be set", ErrInvalidSchedule)
+	}
+
+	if s.Priority == "" {
+		s.Priority = TaskPriorityNormal
+	}
+	if !s.Priority.IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidPriority, s.Priority)
+	}
+
+	if s.MissedRunPolicy == "" {
+		s.MissedRunPolicy = MissedRunSkip
+	}
+	if s.MissedRunPolicy != MissedRunSkip && s.MissedRunPolicy != MissedRunCatchUp {
+		return fmt.Errorf("%w: unknown missed run policy %q", ErrInvalidSchedule, s.MissedRunPolicy)
+	}
+
+	if s.Timezone == "" {
+		s.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidSchedule, s.Timezone)
+	}
+
+	if s.IsRecurring() {
+		if _, err := ParseCron(s.CronExpr); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+		}
+	}
+
+	return nil
+}
+
+// NextRunAfter returns the first run strictly after the given time, or the zero time if
+// the schedule has no further runs. One-shot schedules return RunAt while it is still ahead.
+func (s *ScheduleSpec) NextRunAfter(after time.Time) (time.Time, error) {
+	if !s.IsRecurring() {
+		if s.RunAt.After(after) {
+			return s.RunAt, nil
+		}
+		return time.Time{}, nil
+	}
+
+	cron, err := ParseCron(s.CronExpr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return cron.Next(after.In(loc)), nil
+}
@@ -0,0 +1,110 @@
+package domain
+
+import "fmt"
+
+// taskTransitions lists the statuses a task may move to from each non-terminal status.
+// Terminal statuses (DONE, FAILED, CANCELED) have no outgoing transitions, and no status
+// may move to itself: re-saving a task without a status change is not a transition.
+var taskTransitions = map[TaskStatus][]TaskStatus{
+	TaskStatusNew:             {TaskStatusPlanning, TaskStatusFailed, TaskStatusCanceled},
+	TaskStatusPlanning:        {TaskStatusExecuting, TaskStatusBudgetExceeded, TaskStatusFailed, TaskStatusCanceled},
+	TaskStatusExecuting:       {TaskStatusWaitingApproval, TaskStatusWaitingSubTasks, TaskStatusBudgetExceeded, TaskStatusVerifying, TaskStatusPlanning, TaskStatusFailed, TaskStatusCanceled},
+	TaskStatusWaitingApproval: {TaskStatusExecuting, TaskStatusFailed, TaskStatusCanceled},
+	TaskStatusWaitingSubTasks: {TaskStatusExecuting, TaskStatusFailed, TaskStatusCanceled},
+	TaskStatusBudgetExceeded:  {TaskStatusPlanning, TaskStatusExecuting, TaskStatusFailed, TaskStatusCanceled},
+	TaskStatusVerifying:       {TaskStatusDone, TaskStatusPlanning, TaskStatusFailed, TaskStatusCanceled},
+}
+
+// stepTransitions lists the statuses a step may move to from each non-terminal status.
+// PENDING may jump straight to COMPLETED (approved gates) or FAILED (rejected approvals);
+// IN_PROGRESS returns to PENDING only when crash recovery re-queues an interrupted step.
+var stepTransitions = map[StepStatus][]StepStatus{
+	StepStatusPending:    {StepStatusInProgress, StepStatusCompleted, StepStatusFailed, StepStatusSkipped},
+	StepStatusInProgress: {StepStatusCompleted, StepStatusFailed, StepStatusSkipped, StepStatusPending},
+}
+
+// TransitionError is returned when a task or step is moved to a status that is not
+// reachable from its current one. It matches ErrInvalidTransition with errors.Is.
+type TransitionError struct {
+	Entity string // "task" or "step"
+	ID     string
+	From   string
+	To     string
+}
+
+// Error implements the error interface.
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: %s %s cannot move from %s to %s", ErrInvalidTransition, e.Entity, e.ID, e.From, e.To)
+}
+
+// Unwrap lets errors.Is(err, ErrInvalidTransition) match.
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// CanTransitionTo reports whether a task may move from s to next.
+// Moving to the same status is not allowed; callers that re-save a task without changing
+// its status must skip the check.
+func (s TaskStatus) CanTransitionTo(next TaskStatus) bool {
+	for _, allowed := range taskTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTaskTransition validates a task status change without applying it.
+// It returns a *TransitionError if the change is not allowed.
+func CheckTaskTransition(taskID string, from TaskStatus, to TaskStatus) error {
+	if !from.CanTransitionTo(to) {
+		return &TransitionError{Entity: "task", ID: taskID, From: string(from), To: string(to)}
+	}
+	return nil
+}
+
+// Transition moves the task to next if the state machine allows it.
+// It returns a *TransitionError and leaves the task unchanged otherwise.
+func (t *Task) Transition(next TaskStatus) error {
+	if err := CheckTaskTransition(t.ID, t.Status, next); err != nil {
+		return err
+	}
+	t.Status = next
+	return nil
+}
+
+// IsTerminal reports whether the step status is final (COMPLETED, FAILED or SKIPPED).
+func (s StepStatus) IsTerminal() bool {
+	return s == StepStatusCompleted || s == StepStatusFailed || s == StepStatusSkipped
+}
+
+// CanTransitionTo reports whether a step may move from s to next.
+// Moving to the same status is not allowed; callers that re-save a step without changing
+// its status must skip the check.
+func (s StepStatus) CanTransitionTo(next StepStatus) bool {
+	for _, allowed := range stepTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckStepTransition validates a step status change without applying it.
+// It returns a *TransitionError if the change is not allowed.
+func CheckStepTransition(stepID string, from StepStatus, to StepStatus) error {
+	if !from.CanTransitionTo(to) {
+		return &TransitionError{Entity: "step", ID: stepID, From: string(from), To: string(to)}
+	}
+	return nil
+}
+
+// Transition moves the step to next if the state machine allows it.
+// It returns a *TransitionError and leaves the step unchanged otherwise.
+func (s *Step) Transition(next StepStatus) error {
+	if err := CheckStepTransition(s.ID, s.Status, next); err != nil {
+		return err
+	}
+	s.Status = next
+	return nil
+}
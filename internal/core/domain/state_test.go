@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+var allTaskStatuses = []TaskStatus{
+	TaskStatusNew, TaskStatusPlanning, TaskStatusExecuting, TaskStatusWaitingApproval,
+	TaskStatusWaitingSubTasks, TaskStatusBudgetExceeded, TaskStatusVerifying,
+	TaskStatusDone, TaskStatusFailed, TaskStatusCanceled,
+}
+
+var allStepStatuses = []StepStatus{
+	StepStatusPending, StepStatusInProgress, StepStatusCompleted, StepStatusFailed, StepStatusSkipped,
+}
+
+func TestTaskStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from TaskStatus
+		to   TaskStatus
+		want bool
+	}{
+		{TaskStatusNew, TaskStatusPlanning, true},
+		{TaskStatusNew, TaskStatusExecuting, false},
+		{TaskStatusNew, TaskStatusCanceled, true},
+		{TaskStatusPlanning, TaskStatusExecuting, true},
+		{TaskStatusPlanning, TaskStatusBudgetExceeded, true},
+		{TaskStatusPlanning, TaskStatusDone, false},
+		{TaskStatusExecuting, TaskStatusWaitingApproval, true},
+		{TaskStatusExecuting, TaskStatusWaitingSubTasks, true},
+		{TaskStatusExecuting, TaskStatusVerifying, true},
+		{TaskStatusExecuting, TaskStatusPlanning, true},
+		{TaskStatusExecuting, TaskStatusDone, false},
+		{TaskStatusWaitingApproval, TaskStatusExecuting, true},
+		{TaskStatusWaitingApproval, TaskStatusVerifying, false},
+		{TaskStatusWaitingSubTasks, TaskStatusExecuting, true},
+		{TaskStatusWaitingSubTasks, TaskStatusPlanning, false},
+		{TaskStatusBudgetExceeded, TaskStatusPlanning, true},
+		{TaskStatusBudgetExceeded, TaskStatusExecuting, true},
+		{TaskStatusBudgetExceeded, TaskStatusVerifying, false},
+		{TaskStatusVerifying, TaskStatusDone, true},
+		{TaskStatusVerifying, TaskStatusPlanning, true},
+		{TaskStatusVerifying, TaskStatusExecuting, false},
+		{TaskStatusDone, TaskStatusPlanning, false},
+		{TaskStatusFailed, TaskStatusExecuting, false},
+		{TaskStatusCanceled, TaskStatusNew, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskStatusTransitionInvariants(t *testing.T) {
+	for _, from := range allTaskStatuses {
+		if from.CanTransitionTo(from) {
+			t.Errorf("%s may move to itself", from)
+		}
+		for _, to := range allTaskStatuses {
+			if from.IsTerminal() && from.CanTransitionTo(to) {
+				t.Errorf("terminal status %s may move to %s", from, to)
+			}
+			if !from.IsTerminal() && to != from && (to == TaskStatusFailed || to == TaskStatusCanceled) && !from.CanTransitionTo(to) {
+				t.Errorf("active status %s cannot move to %s", from, to)
+			}
+		}
+	}
+}
+
+func TestStepStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from StepStatus
+		to   StepStatus
+		want bool
+	}{
+		{StepStatusPending, StepStatusInProgress, true},
+		{StepStatusPending, StepStatusCompleted, true},
+		{StepStatusPending, StepStatusFailed, true},
+		{StepStatusPending, StepStatusSkipped, true},
+		{StepStatusPending, StepStatusPending, false},
+		{StepStatusInProgress, StepStatusCompleted, true},
+		{StepStatusInProgress, StepStatusFailed, true},
+		{StepStatusInProgress, StepStatusSkipped, true},
+		{StepStatusInProgress, StepStatusPending, true},
+		{StepStatusInProgress, StepStatusInProgress, false},
+		{StepStatusCompleted, StepStatusPending, false},
+		{StepStatusCompleted, StepStatusCompleted, false},
+		{StepStatusFailed, StepStatusInProgress, false},
+		{StepStatusSkipped, StepStatusPending, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStepStatusTerminalHasNoExits(t *testing.T) {
+	for _, from := range allStepStatuses {
+		if !from.IsTerminal() {
+			continue
+		}
+		for _, to := range allStepStatuses {
+			if from.CanTransitionTo(to) {
+				t.Errorf("terminal status %s may move to %s", from, to)
+			}
+		}
+	}
+}
+
+func TestTransitionApply(t *testing.T) {
+	task := &Task{ID: "task-1", Status: TaskStatusExecuting}
+	if err := task.Transition(TaskStatusVerifying); err != nil {
+		t.Fatalf("Transition(VERIFYING) = %v, want nil", err)
+	}
+	if task.Status != TaskStatusVerifying {
+		t.Fatalf("status = %s, want %s", task.Status, TaskStatusVerifying)
+	}
+
+	err := task.Transition(TaskStatusVerifying)
+	if !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("Transition(VERIFYING) again = %v, want ErrInvalidTransition", err)
+	}
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) || transitionErr.Entity != "task" || transitionErr.ID != "task-1" {
+		t.Fatalf("error = %#v, want a task TransitionError for task-1", err)
+	}
+	if task.Status != TaskStatusVerifying {
+		t.Fatalf("status changed to %s after a rejected transition", task.Status)
+	}
+
+	step := &Step{ID: "step-1", Status: StepStatusCompleted}
+	err = step.Transition(StepStatusInProgress)
+	if !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("step Transition(IN_PROGRESS) = %v, want ErrInvalidTransition", err)
+	}
+	if step.Status != StepStatusCompleted {
+		t.Fatalf("step status changed to %s after a rejected transition", step.Status)
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import "fmt"
+
+// SubTaskSpec describes a child task spawned by a SUB_TASK step
+type SubTaskSpec struct {
+	Input       string       `json:"input"`
+	TargetAgent string       `json:"target_agent,omitempty"` // Agent handling the child (default: the parent's agent)
+	Priority    TaskPriority `json:"priority,omitempty"`     // Default: the parent's priority
+}
+
+// SubTaskResult summarizes a finished child task.
+// The output of a SUB_TASK step is the JSON array of the results of its children.
+type SubTaskResult struct {
+	TaskID      string     `json:"task_id"`
+	Input       string     `json:"input"`
+	TargetAgent string     `json:"target_agent"`
+	Status      TaskStatus `json:"status"`
+	Output      string     `json:"output,omitempty"` // Output of the child's last completed step
+	Error       string     `json:"error,omitempty"`  // Failure reason of a child that did not finish DONE
+}
+
+// WaitingSubTaskSteps returns the SUB_TASK steps that have spawned children and are
+// still IN_PROGRESS waiting for them, in plan order.
+func (p *Plan) WaitingSubTaskSteps() []*Step {
+	waiting := make([]*Step, 0)
+	for i := range p.Steps {
+		if p.Steps[i].Type == StepTypeSubTask && p.Steps[i].Status == StepStatusInProgress {
+			waiting = append(waiting, &p.Steps[i])
+		}
+	}
+	return waiting
+}
+
+// validateSubTasks checks that every SUB_TASK step declares at least one child with an
+// input and a known priority, and that no other step declares children.
+func (p *Plan) validateSubTasks() error {
+	for i := range p.Steps {
+		step := &p.Steps[i]
+		if step.Type != StepTypeSubTask {
+			if len(step.SubTasks) > 0 {
+				return fmt.Errorf("%w: step %s has sub_tasks but is not a %s step", ErrInvalidPlan, step.ID, StepTypeSubTask)
+			}
+			continue
+		}
+		if len(step.SubTasks) == 0 {
+			return fmt.Errorf("%w: %s step %s declares no sub_tasks", ErrInvalidPlan, StepTypeSubTask, step.ID)
+		}
+		for j, spec := range step.SubTasks {
+			if spec.Input == "" {
+				return fmt.Errorf("%w: sub-task %d of step %s has an empty input", ErrInvalidPlan, j, step.ID)
+			}
+			if spec.Priority != "" && !spec.Priority.IsValid() {
+				return fmt.Errorf("%w: sub-task %d of step %s has unknown priority %s", ErrInvalidPlan, j, step.ID, spec.Priority)
+			}
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"maps"
+	"time"
+)
 
 // TaskStatus represents the current status of a task
 type TaskStatus string
@@ -11,12 +14,25 @@ const (
 	TaskStatusPlanning         TaskStatus = "PLANNING"
 	TaskStatusExecuting        TaskStatus = "EXECUTING"
 	TaskStatusWaitingApproval  TaskStatus = "WAITING_APPROVAL"
+	TaskStatusWaitingSubTasks  TaskStatus = "WAITING_SUBTASKS"
+	TaskStatusBudgetExceeded   TaskStatus = "BUDGET_EXCEEDED"
 	TaskStatusVerifying        TaskStatus = "VERIFYING"
 	TaskStatusDone             TaskStatus = "DONE"
 	TaskStatusFailed           TaskStatus = "FAILED"
 	TaskStatusCanceled         TaskStatus = "CANCELED"
 )
 
+// TaskPriority orders queued tasks; higher priorities are picked up by workers first
+type TaskPriority string
+
+// Task priority constants
+const (
+	TaskPriorityLow    TaskPriority = "LOW"
+	TaskPriorityNormal TaskPriority = "NORMAL"
+	TaskPriorityHigh   TaskPriority = "HIGH"
+	TaskPriorityUrgent TaskPriority = "URGENT"
+)
+
 // Task represents a user task/request in the system
 type Task struct {
 	ID                string            `json:"id"`
@@ -24,16 +40,109 @@ type Task struct {
 	UpdatedAt         time.Time         `json:"updated_at"`
 	FinishedAt        time.Time         `json:"finished_at"`
 	Status            TaskStatus        `json:"status"`
+	Priority          TaskPriority      `json:"priority"`
+	Deadline          time.Time         `json:"deadline,omitempty"` // Task fails if still running after this time; zero means none
 	Input             string            `json:"input"`
 	NormalizedIntent  string            `json:"normalized_intent"`
 	UserID            string            `json:"user_id"`
 	Channel           string            `json:"channel"`
 	Role              string            `json:"role"`
 	TargetAgent       string            `json:"target_agent"`
+	ParentTaskID      string            `json:"parent_task_id,omitempty"` // Task whose SUB_TASK step spawned this one
+	ParentStepID      string            `json:"parent_step_id,omitempty"` // The spawning SUB_TASK step of the parent
 	PlanID            string            `json:"plan_id"`
 	CurrentStepID     string            `json:"current_step_id"`
-	Artifacts         map[string]string `json:"artifacts"`
+	Artifacts         map[string]string `json:"artifacts"` // Artifact name → checksum of each stored artifact; refreshed from the ArtifactStore
 	Metadata          map[string]string `json:"metadata"`
 	UsageTokens       int               `json:"usage_tokens"`
 	CostEstimate      float64           `json:"cost_estimate"`
+	Budget            BudgetLimit       `json:"budget"` // Caps UsageTokens/CostEstimate; zero fields are unlimited
+	QueuePosition     int               `json:"queue_position,omitempty"` // 1-based position while NEW; computed on read, not persisted
+}
+
+// Clone returns a copy of the task that shares no maps with it.
+// Repositories store and hand out clones, so a task mutated by a worker never races with
+// readers of the same task (e.g., an HTTP poller encoding its Metadata).
+func (t *Task) Clone() *Task {
+	clone := *t
+	clone.Artifacts = maps.Clone(t.Artifacts)
+	clone.Metadata = maps.Clone(t.Metadata)
+	return &clone
+}
+
+// TaskOptions holds optional settings supplied when a task is created. Zero values select defaults.
+type TaskOptions struct {
+	Priority TaskPriority      `json:"priority,omitempty"` // Defaults to NORMAL
+	Metadata map[string]string `json:"metadata,omitempty"` // Copied into Task.Metadata (e.g., the originating schedule)
+	Deadline time.Time         `json:"deadline,omitempty"` // Absolute deadline; must be in the future
+	Timeout  time.Duration     `json:"timeout,omitempty"`  // Deadline relative to creation; the earlier of both wins
+	Budget   BudgetLimit       `json:"budget,omitempty"`   // Maximum LLM cost and tokens of the task
+
+	// Idempotency - Repeats with the same key (per user) return the original task
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+
+	// Sub-tasks - Set when a SUB_TASK step spawns a child task
+	TargetAgent  string `json:"target_agent,omitempty"`   // Defaults to CORE
+	ParentTaskID string `json:"parent_task_id,omitempty"` // Parent task waiting on the child
+	ParentStepID string `json:"parent_step_id,omitempty"` // SUB_TASK step of the parent
+}
+
+// TaskFilter selects tasks in repository listings. Empty fields match everything.
+type TaskFilter struct {
+	UserID       string       `json:"user_id,omitempty"`
+	ParentTaskID string       `json:"parent_task_id,omitempty"` // Only children of this task
+	Statuses     []TaskStatus `json:"statuses,omitempty"`       // Matches any of the listed statuses
+	ActiveOnly   bool         `json:"active_only,omitempty"`    // Excludes tasks in a terminal status
+}
+
+// Matches reports whether the task satisfies every criterion of the filter.
+func (f TaskFilter) Matches(task *Task) bool {
+	if f.UserID != "" && task.UserID != f.UserID {
+		return false
+	}
+	if f.ParentTaskID != "" && task.ParentTaskID != f.ParentTaskID {
+		return false
+	}
+	if f.ActiveOnly && task.Status.IsTerminal() {
+		return false
+	}
+	if len(f.Statuses) == 0 {
+		return true
+	}
+	for _, status := range f.Statuses {
+		if task.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal reports whether the status is final (DONE, FAILED or CANCELED).
+// Terminal tasks never execute again.
+func (s TaskStatus) IsTerminal() bool {
+	return s == TaskStatusDone || s == TaskStatusFailed || s == TaskStatusCanceled
+}
+
+// IsValid reports whether the priority is one of the known levels.
+func (p TaskPriority) IsValid() bool {
+	switch p {
+	case TaskPriorityLow, TaskPriorityNormal, TaskPriorityHigh, TaskPriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// Rank returns the numeric weight of the priority (LOW=0 … URGENT=3).
+// Unknown or empty priorities rank as NORMAL so tasks stored before priorities existed
+// are scheduled like any other default task.
+func (p TaskPriority) Rank() int {
+	switch p {
+	case TaskPriorityLow:
+		return 0
+	case TaskPriorityHigh:
+		return 2
+	case TaskPriorityUrgent:
+		return 3
+	}
+	return 1
 }
@@ -24,5 +24,15 @@ type StepResult struct {
 	Success      bool   `json:"success"`
 	Output       string `json:"output"`
 	ErrorMessage string `json:"error_message,omitempty"`
+	ErrorCode    string `json:"error_code,omitempty"` // Machine-readable failure class (e.g., ErrorCodeStepTimeout)
 	DurationMs   int64  `json:"duration_ms"`
+
+	// Offloading - Set when the output exceeded the inline size limit and was moved to the ResultStore
+	OutputRef  string `json:"output_ref,omitempty"`  // ResultStore reference of the full output (mirrored in Step.ResultRef)
+	OutputSize int64  `json:"output_size,omitempty"` // Size in bytes of the full output; Output then holds a preview
+}
+
+// Offloaded reports whether Output is a preview of a full output kept in the ResultStore.
+func (r *StepResult) Offloaded() bool {
+	return r.OutputRef != ""
 }
@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TokenUsage is the token count of a single LLM call
+type TokenUsage struct {
+	Model            string `json:"model"`
+	PromptTokens     int64  `json:"prompt_tokens"`
+	CompletionTokens int64  `json:"completion_tokens"`
+}
+
+// TotalTokens returns the prompt and completion tokens of the call combined.
+func (u TokenUsage) TotalTokens() int64 {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// LLMResponse is the text generated by an LLM together with the tokens it consumed
+type LLMResponse struct {
+	Text  string     `json:"text"`
+	Usage TokenUsage `json:"usage"`
+}
+
+// Usage phases recorded in UsageRecord.Phase: the part of the task lifecycle an LLM call served
+const (
+	UsagePhaseNormalization = "NORMALIZATION" // Intent normalization before planning
+	UsagePhaseRouting       = "ROUTING"       // Agent classification by the AgentRouter
+	UsagePhasePlanning      = "PLANNING"      // Initial plan creation
+	UsagePhaseReplanning    = "REPLANNING"    // Plan revision after failed verification
+	UsagePhaseExecution     = "EXECUTION"     // Step execution (including retries)
+	UsagePhaseVerification  = "VERIFICATION"  // VERIFY steps and the final verification
+)
+
+// UsageRecord is the accounted usage of one LLM call made for a task
+type UsageRecord struct {
+	ID               string    `json:"id"`
+	TaskID           string    `json:"task_id"`
+	StepID           string    `json:"step_id,omitempty"` // Set for calls made while a step ran
+	UserID           string    `json:"user_id"`           // Owner of the task, for per-user reporting
+	Agent            string    `json:"agent,omitempty"`   // Agent handling the task at the time of the call
+	Phase            string    `json:"phase"`             // One of the UsagePhase constants
+	Model            string    `json:"model"`
+	PromptTokens     int64     `json:"prompt_tokens"`
+	CompletionTokens int64     `json:"completion_tokens"`
+	CostUSD          float64   `json:"cost_usd"`
+	Unpriced         bool      `json:"unpriced,omitempty"` // The model has no entry in the price table; CostUSD is 0
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// ModelPrice is the price of an LLM model in USD per million tokens
+type ModelPrice struct {
+	PromptPerMillion     float64 `json:"prompt_per_million"`
+	CompletionPerMillion float64 `json:"completion_per_million"`
+}
+
+// PriceTable maps model names to their prices. Lookups ignore case.
+type PriceTable map[string]ModelPrice
+
+// Price returns the price of a model.
+func (t PriceTable) Price(model string) (ModelPrice, bool) {
+	if price, ok := t[model]; ok {
+		return price, true
+	}
+	for name, price := range t {
+		if strings.EqualFold(name, model) {
+			return price, true
+		}
+	}
+	return ModelPrice{}, false
+}
+
+// Cost returns the USD cost of a call, and false if the model has no price.
+func (t PriceTable) Cost(usage TokenUsage) (float64, bool) {
+	price, ok := t.Price(usage.Model)
+	if !ok {
+		return 0, false
+	}
+	return (float64(usage.PromptTokens)*price.PromptPerMillion +
+		float64(usage.CompletionTokens)*price.CompletionPerMillion) / 1e6, true
+}
+
+// ParsePriceTable parses a price table written as comma-separated
+// "model=prompt/completion" entries in USD per million tokens,
+// e.g., "gpt-4o-mini=0.15/0.60,gpt-4o=2.50/10.00".
+func ParsePriceTable(spec string) (PriceTable, error) {
+	table := make(PriceTable)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		model, prices, found := strings.Cut(entry, "=")
+		prompt, completion, ok := strings.Cut(prices, "/")
+		if !found || !ok || strings.TrimSpace(model) == "" {
+			return nil, fmt.Errorf("price entry %q must look like model=prompt/completion", entry)
+		}
+
+		var price ModelPrice
+		var err error
+		if price.PromptPerMillion, err = strconv.ParseFloat(strings.TrimSpace(prompt), 64); err != nil {
+			return nil, fmt.Errorf("invalid prompt price of %s: %w", model, err)
+		}
+		if price.CompletionPerMillion, err = strconv.ParseFloat(strings.TrimSpace(completion), 64); err != nil {
+			return nil, fmt.Errorf("invalid completion price of %s: %w", model, err)
+		}
+		if price.PromptPerMillion < 0 || price.CompletionPerMillion < 0 {
+			return nil, fmt.Errorf("prices of %s cannot be negative", model)
+		}
+		table[strings.TrimSpace(model)] = price
+	}
+	return table, nil
+}
+
+// UsageFilter selects usage records. Empty fields match everything.
+type UsageFilter struct {
+	UserID string    `json:"user_id,omitempty"`
+	TaskID string    `json:"task_id,omitempty"`
+	From   time.Time `json:"from,omitempty"` // Inclusive lower bound of CreatedAt
+	To     time.Time `json:"to,omitempty"`   // Exclusive upper bound of CreatedAt
+}
+
+// Validate checks that the time range of the filter is not inverted.
+func (f UsageFilter) Validate() error {
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return fmt.Errorf("%w: from %s must be before to %s", ErrInvalidTimeRange,
+			f.From.Format(time.RFC3339), f.To.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Matches reports whether the record satisfies every criterion of the filter.
+func (f UsageFilter) Matches(record *UsageRecord) bool {
+	if f.UserID != "" && record.UserID != f.UserID {
+		return false
+	}
+	if f.TaskID != "" && record.TaskID != f.TaskID {
+		return false
+	}
+	if !f.From.IsZero() && record.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !record.CreatedAt.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+// UsageTotals accumulates the usage of a group of LLM calls
+type UsageTotals struct {
+	Calls            int     `json:"calls"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	TotalTokens      int64   `json:"total_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+	UnpricedCalls    int     `json:"unpriced_calls,omitempty"` // Calls of models missing from the price table
+}
+
+// add accumulates one record.
+func (t *UsageTotals) add(record *UsageRecord) {
+	t.Calls++
+	t.PromptTokens += record.PromptTokens
+	t.CompletionTokens += record.CompletionTokens
+	t.TotalTokens += record.PromptTokens + record.CompletionTokens
+	t.CostUSD += record.CostUSD
+	if record.Unpriced {
+		t.UnpricedCalls++
+	}
+}
+
+// UsageSummary aggregates usage records for reporting
+type UsageSummary struct {
+	UsageTotals
+	TaskCount int                     `json:"task_count"`
+	ByModel   map[string]*UsageTotals `json:"by_model"`
+	ByPhase   map[string]*UsageTotals `json:"by_phase"`
+	ByUser    map[string]*UsageTotals `json:"by_user,omitempty"` // Only when the filter spans all users and tasks
+	ByStep    map[string]*UsageTotals `json:"by_step,omitempty"` // Only for a single task; step-less calls are omitted
+}
+
+// SummarizeUsage aggregates the records that match the filter.
+func SummarizeUsage(records []*UsageRecord, filter UsageFilter) *UsageSummary {
+	summary := &UsageSummary{
+		ByModel: make(map[string]*UsageTotals),
+		ByPhase: make(map[string]*UsageTotals),
+	}
+	if filter.UserID == "" && filter.TaskID == "" {
+		summary.ByUser = make(map[string]*UsageTotals)
+	}
+	if filter.TaskID != "" {
+		summary.ByStep = make(map[string]*UsageTotals)
+	}
+
+	group := func(groups map[string]*UsageTotals, key string, record *UsageRecord) {
+		if groups[key] == nil {
+			groups[key] = &UsageTotals{}
+		}
+		groups[key].add(record)
+	}
+
+	tasks := make(map[string]bool)
+	for _, record := range records {
+		if !filter.Matches(record) {
+			continue
+		}
+		summary.add(record)
+		tasks[record.TaskID] = true
+		group(summary.ByModel, record.Model, record)
+		group(summary.ByPhase, record.Phase, record)
+		if summary.ByUser != nil {
+			group(summary.ByUser, record.UserID, record)
+		}
+		if summary.ByStep != nil && record.StepID != "" {
+			group(summary.ByStep, record.StepID, record)
+		}
+	}
+	summary.TaskCount = len(tasks)
+
+	return summary
+}
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParsePriceTable(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    PriceTable
+		wantErr bool
+	}{
+		{spec: "", want: PriceTable{}},
+		{spec: " , ,", want: PriceTable{}},
+		{spec: "gpt-4o-mini=0.15/0.60", want: PriceTable{"gpt-4o-mini": {PromptPerMillion: 0.15, CompletionPerMillion: 0.60}}},
+		{
+			spec: " gpt-4o = 2.50 / 10 ,local=0/0,",
+			want: PriceTable{
+				"gpt-4o": {PromptPerMillion: 2.50, CompletionPerMillion: 10},
+				"local":  {},
+			},
+		},
+		{spec: "m=1/2,m=3/4", want: PriceTable{"m": {PromptPerMillion: 3, CompletionPerMillion: 4}}},
+		{spec: "gpt-4o", wantErr: true},
+		{spec: "gpt-4o=2.50", wantErr: true},
+		{spec: "=1/2", wantErr: true},
+		{spec: "gpt-4o=abc/1", wantErr: true},
+		{spec: "gpt-4o=1/abc", wantErr: true},
+		{spec: "gpt-4o=-1/1", wantErr: true},
+		{spec: "gpt-4o=1/-1", wantErr: true},
+		{spec: "ok=1/1,bad", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParsePriceTable(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePriceTable(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParsePriceTable(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+			for model, price := range tt.want {
+				if got[model] != price {
+					t.Errorf("ParsePriceTable(%q)[%s] = %+v, want %+v", tt.spec, model, got[model], price)
+				}
+			}
+		})
+	}
+}
+
+func TestPriceTableCost(t *testing.T) {
+	table := PriceTable{
+		"gpt-4o-mini": {PromptPerMillion: 0.15, CompletionPerMillion: 0.60},
+		"free":        {},
+	}
+
+	tests := []struct {
+		name       string
+		usage      TokenUsage
+		want       float64
+		wantPriced bool
+	}{
+		{name: "prompt and completion", usage: TokenUsage{Model: "gpt-4o-mini", PromptTokens: 1_000_000, CompletionTokens: 500_000}, want: 0.45, wantPriced: true},
+		{name: "single token keeps sub-cent precision", usage: TokenUsage{Model: "gpt-4o-mini", PromptTokens: 1}, want: 0.00000015, wantPriced: true},
+		{name: "model lookup ignores case", usage: TokenUsage{Model: "GPT-4o-Mini", CompletionTokens: 1000}, want: 0.0006, wantPriced: true},
+		{name: "no tokens", usage: TokenUsage{Model: "gpt-4o-mini"}, want: 0, wantPriced: true},
+		{name: "zero price", usage: TokenUsage{Model: "free", PromptTokens: 1000, CompletionTokens: 1000}, want: 0, wantPriced: true},
+		{name: "unknown model", usage: TokenUsage{Model: "mystery", PromptTokens: 1000}, want: 0, wantPriced: false},
+		{name: "empty model", usage: TokenUsage{PromptTokens: 1000}, want: 0, wantPriced: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, priced := table.Cost(tt.usage)
+			if priced != tt.wantPriced {
+				t.Fatalf("Cost(%+v) priced = %v, want %v", tt.usage, priced, tt.wantPriced)
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("Cost(%+v) = %v, want %v", tt.usage, got, tt.want)
+			}
+		})
+	}
+
+	var empty PriceTable
+	if _, priced := empty.Cost(TokenUsage{Model: "gpt-4o-mini", PromptTokens: 1}); priced {
+		t.Error("a nil price table priced a call")
+	}
+}
+
+func TestSummarizeUsage(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	records := []*UsageRecord{
+		{TaskID: "t1", StepID: "s1", UserID: "alice", Phase: UsagePhaseExecution, Model: "gpt-4o-mini", PromptTokens: 100, CompletionTokens: 50, CostUSD: 0.1, CreatedAt: day},
+		{TaskID: "t1", StepID: "s2", UserID: "alice", Phase: UsagePhaseExecution, Model: "gpt-4o", PromptTokens: 200, CompletionTokens: 100, CostUSD: 0.2, CreatedAt: day.Add(time.Hour)},
+		{TaskID: "t1", UserID: "alice", Phase: UsagePhasePlanning, Model: "gpt-4o-mini", PromptTokens: 10, CompletionTokens: 5, CostUSD: 0.01, CreatedAt: day.Add(2 * time.Hour)},
+		{TaskID: "t2", StepID: "s1", UserID: "bob", Phase: UsagePhaseExecution, Model: "mystery", PromptTokens: 1000, Unpriced: true, CreatedAt: day.Add(24 * time.Hour)},
+	}
+
+	t.Run("no filter groups by user", func(t *testing.T) {
+		summary := SummarizeUsage(records, UsageFilter{})
+		assertTotals(t, "total", &summary.UsageTotals, UsageTotals{Calls: 4, PromptTokens: 1310, CompletionTokens: 155, TotalTokens: 1465, CostUSD: 0.31, UnpricedCalls: 1})
+		if summary.TaskCount != 2 {
+			t.Errorf("TaskCount = %d, want 2", summary.TaskCount)
+		}
+		if summary.ByStep != nil {
+			t.Errorf("ByStep = %v, want nil without a task filter", summary.ByStep)
+		}
+		assertTotals(t, "alice", summary.ByUser["alice"], UsageTotals{Calls: 3, PromptTokens: 310, CompletionTokens: 155, TotalTokens: 465, CostUSD: 0.31})
+		assertTotals(t, "bob", summary.ByUser["bob"], UsageTotals{Calls: 1, PromptTokens: 1000, TotalTokens: 1000, UnpricedCalls: 1})
+		assertTotals(t, "gpt-4o-mini", summary.ByModel["gpt-4o-mini"], UsageTotals{Calls: 2, PromptTokens: 110, CompletionTokens: 55, TotalTokens: 165, CostUSD: 0.11})
+		assertTotals(t, "planning", summary.ByPhase[UsagePhasePlanning], UsageTotals{Calls: 1, PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15, CostUSD: 0.01})
+	})
+
+	t.Run("task filter groups by step", func(t *testing.T) {
+		summary := SummarizeUsage(records, UsageFilter{TaskID: "t1"})
+		assertTotals(t, "total", &summary.UsageTotals, UsageTotals{Calls: 3, PromptTokens: 310, CompletionTokens: 155, TotalTokens: 465, CostUSD: 0.31})
+		if summary.ByUser != nil {
+			t.Errorf("ByUser = %v, want nil for a single task", summary.ByUser)
+		}
+		if len(summary.ByStep) != 2 {
+			t.Fatalf("ByStep = %v, want s1 and s2 only (step-less calls are omitted)", summary.ByStep)
+		}
+		assertTotals(t, "s2", summary.ByStep["s2"], UsageTotals{Calls: 1, PromptTokens: 200, CompletionTokens: 100, TotalTokens: 300, CostUSD: 0.2})
+	})
+
+	t.Run("user and time range", func(t *testing.T) {
+		summary := SummarizeUsage(records, UsageFilter{UserID: "alice", From: day.Add(time.Hour), To: day.Add(2 * time.Hour)})
+		assertTotals(t, "total", &summary.UsageTotals, UsageTotals{Calls: 1, PromptTokens: 200, CompletionTokens: 100, TotalTokens: 300, CostUSD: 0.2})
+		if summary.ByUser != nil || summary.ByStep != nil {
+			t.Errorf("ByUser = %v, ByStep = %v, want both nil for a user filter", summary.ByUser, summary.ByStep)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		summary := SummarizeUsage(records, UsageFilter{UserID: "carol"})
+		assertTotals(t, "total", &summary.UsageTotals, UsageTotals{})
+		if summary.TaskCount != 0 || len(summary.ByModel) != 0 || len(summary.ByPhase) != 0 {
+			t.Errorf("summary = %+v, want empty groups", summary)
+		}
+	})
+
+	t.Run("no records", func(t *testing.T) {
+		summary := SummarizeUsage(nil, UsageFilter{})
+		if summary.Calls != 0 || summary.ByModel == nil || summary.ByPhase == nil || summary.ByUser == nil {
+			t.Errorf("summary = %+v, want zero totals with empty groups", summary)
+		}
+	})
+}
+
+func TestUsageFilterValidate(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		filter  UsageFilter
+		wantErr bool
+	}{
+		{name: "empty", filter: UsageFilter{}},
+		{name: "open ended", filter: UsageFilter{From: day}},
+		{name: "ordered", filter: UsageFilter{From: day, To: day.Add(time.Hour)}},
+		{name: "empty range", filter: UsageFilter{From: day, To: day}, wantErr: true},
+		{name: "inverted", filter: UsageFilter{From: day.Add(time.Hour), To: day}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// assertTotals compares usage totals, allowing for float rounding in CostUSD.
+func assertTotals(t *testing.T, name string, got *UsageTotals, want UsageTotals) {
+	t.Helper()
+	if got == nil {
+		t.Fatalf("%s totals missing", name)
+	}
+	costOff := math.Abs(got.CostUSD - want.CostUSD)
+	rest := *got
+	rest.CostUSD = want.CostUSD
+	if rest != want || costOff > 1e-9 {
+		t.Errorf("%s totals = %+v, want %+v", name, *got, want)
+	}
+}
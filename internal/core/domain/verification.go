@@ -0,0 +1,8 @@
+package domain
+
+// Verification is the outcome of checking step results against a plan goal.
+type Verification struct {
+	Passed bool     `json:"passed"`
+	Reason string   `json:"reason"`           // Short explanation of the verdict
+	Issues []string `json:"issues,omitempty"` // Concrete gaps found (e.g., missing outputs, unmet goal parts)
+}
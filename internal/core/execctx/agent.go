@@ -0,0 +1,37 @@
+// Package execctx carries the per-execution collaborators of a task through context.Context.
+// The orchestrator attaches them around every component call; adapters read them back.
+// Only the context plumbing lives here: the collaborators' contracts are interfaces in ports.
+package execctx
+
+import (
+	"context"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// agentContextKey is the context key under which the active agent profile is stored.
+type agentContextKey struct{}
+
+// WithAgentProfile returns a copy of ctx carrying the profile of the agent handling a task.
+// Purpose: Set by the orchestrator for every Planner and Executor call so shared
+//          implementations can apply the agent's SystemPrompt and tool restrictions.
+// Inputs:
+//   - ctx: Context of the component call
+//   - profile: Profile of the agent handling the task
+// Outputs:
+//   - context.Context: Copy of ctx carrying the profile
+func WithAgentProfile(ctx context.Context, profile domain.AgentProfile) context.Context {
+	return context.WithValue(ctx, agentContextKey{}, profile)
+}
+
+// AgentProfileFromContext returns the agent profile stored by WithAgentProfile, if any.
+// Purpose: Lets planners and executors shared between agents act as the calling agent.
+// Inputs:
+//   - ctx: Context of the component call
+// Outputs:
+//   - domain.AgentProfile: The active agent's profile
+//   - bool: False if ctx carries no profile (e.g., outside task execution)
+func AgentProfileFromContext(ctx context.Context) (domain.AgentProfile, bool) {
+	profile, ok := ctx.Value(agentContextKey{}).(domain.AgentProfile)
+	return profile, ok
+}
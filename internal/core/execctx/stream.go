@@ -0,0 +1,35 @@
+package execctx
+
+import (
+	"context"
+
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// llmStreamListenerContextKey is the context key under which the active stream listener is stored.
+type llmStreamListenerContextKey struct{}
+
+// WithLLMStreamListener returns a copy of ctx carrying the listener that generated text is reported to.
+// Purpose: Set by the orchestrator next to every usage meter so the text of LLM calls made
+//          for a task is published as it is generated.
+// Inputs:
+//   - ctx: Context of the component call
+//   - listener: Listener publishing the fragments
+// Outputs:
+//   - context.Context: Copy of ctx carrying the listener
+func WithLLMStreamListener(ctx context.Context, listener ports.LLMStreamListener) context.Context {
+	return context.WithValue(ctx, llmStreamListenerContextKey{}, listener)
+}
+
+// RecordLLMDelta reports a fragment of text an LLM is generating to the listener in ctx.
+// Purpose: Callers of StreamingLLMProvider.StreamText should report every fragment; without
+//          a listener (e.g., outside task execution) the fragment is dropped.
+// Inputs:
+//   - ctx: Context of the component call
+//   - delta: The next fragment of the response text
+// Outputs: None
+func RecordLLMDelta(ctx context.Context, delta string) {
+	if listener, ok := ctx.Value(llmStreamListenerContextKey{}).(ports.LLMStreamListener); ok {
+		listener.RecordDelta(delta)
+	}
+}
@@ -0,0 +1,35 @@
+package execctx
+
+import (
+	"context"
+
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// toolInvokerContextKey is the context key under which the active tool invoker is stored.
+type toolInvokerContextKey struct{}
+
+// WithToolInvoker returns a copy of ctx carrying the invoker that tools are run through.
+// Purpose: Set by the orchestrator for every step attempt so tool calls are checked against
+//          the agent's AllowedTools and recorded in the tool call ledger.
+// Inputs:
+//   - ctx: Context of the step attempt
+//   - invoker: Invoker bound to the task, step, agent and attempt
+// Outputs:
+//   - context.Context: Copy of ctx carrying the invoker
+func WithToolInvoker(ctx context.Context, invoker ports.ToolInvoker) context.Context {
+	return context.WithValue(ctx, toolInvokerContextKey{}, invoker)
+}
+
+// ToolInvokerFromContext returns the tool invoker stored by WithToolInvoker, if any.
+// Purpose: Executors must run every tool through it; without one (e.g., outside task
+//          execution) tools are run directly.
+// Inputs:
+//   - ctx: Context of the step attempt
+// Outputs:
+//   - ports.ToolInvoker: The active invoker
+//   - bool: False if ctx carries no invoker
+func ToolInvokerFromContext(ctx context.Context) (ports.ToolInvoker, bool) {
+	invoker, ok := ctx.Value(toolInvokerContextKey{}).(ports.ToolInvoker)
+	return invoker, ok
+}
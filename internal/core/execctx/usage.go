@@ -0,0 +1,36 @@
+package execctx
+
+import (
+	"context"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// usageMeterContextKey is the context key under which the active usage meter is stored.
+type usageMeterContextKey struct{}
+
+// WithUsageMeter returns a copy of ctx carrying the meter that LLM usage is reported to.
+// Purpose: Set by the orchestrator around every component call made for a task so the
+//          usage of its LLM calls is attributed to the task, step and phase.
+// Inputs:
+//   - ctx: Context of the component call
+//   - meter: Meter collecting the usage
+// Outputs:
+//   - context.Context: Copy of ctx carrying the meter
+func WithUsageMeter(ctx context.Context, meter ports.UsageMeter) context.Context {
+	return context.WithValue(ctx, usageMeterContextKey{}, meter)
+}
+
+// RecordLLMUsage reports the usage of an LLM call to the meter in ctx.
+// Purpose: Every caller of LLMProvider.GenerateText must report the usage of the response;
+//          without a meter (e.g., outside task execution) the usage is not accounted.
+// Inputs:
+//   - ctx: Context of the component call
+//   - usage: Model and prompt/completion tokens of the call
+// Outputs: None
+func RecordLLMUsage(ctx context.Context, usage domain.TokenUsage) {
+	if meter, ok := ctx.Value(usageMeterContextKey{}).(ports.UsageMeter); ok {
+		meter.RecordUsage(usage)
+	}
+}
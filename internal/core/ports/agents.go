@@ -0,0 +1,69 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// Agent is a specialized agent: its profile plus the components that do its work.
+// The orchestrator plans a task with the Planner of its Task.TargetAgent and runs the
+// steps with that agent's Executor.
+type Agent interface {
+	// Profile returns the agent's name, description, allowed tools and system prompt.
+	// Purpose: Drives routing, tool filtering and the prompts of shared components.
+	// Inputs: None
+	// Outputs:
+	//   - domain.AgentProfile: The agent's profile
+	Profile() domain.AgentProfile
+
+	// Planner returns the component producing the agent's plans.
+	// Purpose: Plans and revises the plans of tasks routed to the agent.
+	// Inputs: None
+	// Outputs:
+	//   - Planner: The agent's planner
+	Planner() Planner
+
+	// Executor returns the component running the agent's steps.
+	// Purpose: Executes the steps of tasks routed to the agent.
+	// Inputs: None
+	// Outputs:
+	//   - Executor: The agent's executor
+	Executor() Executor
+}
+
+// AgentRegistry manages the specialized agents tasks can be routed to.
+// The default agent (domain.DefaultAgentName) is provided by the orchestrator itself
+// and is not registered here.
+type AgentRegistry interface {
+	// GetAgent retrieves an agent by name.
+	// Purpose: Resolves Task.TargetAgent to the planner and executor that handle the task.
+	// Inputs:
+	//   - name: Agent name (matched case-insensitively)
+	// Outputs:
+	//   - Agent: The registered agent
+	//   - error: Returns error wrapping domain.ErrUnknownAgent if no such agent is registered
+	GetAgent(name string) (Agent, error)
+
+	// ListAgents returns the profiles of all registered agents.
+	// Purpose: Feeds routers and agent discovery endpoints.
+	// Inputs: None
+	// Outputs:
+	//   - []domain.AgentProfile: Profiles sorted by name
+	ListAgents() []domain.AgentProfile
+}
+
+// AgentRouter chooses the agent for tasks that do not name one.
+type AgentRouter interface {
+	// Route classifies a task's input and picks the best-suited agent.
+	// Purpose: Lets clients submit work without knowing which agent handles it.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - task: The task to route (Input and NormalizedIntent are classified)
+	//   - agents: Candidate agents (the default agent is not among them)
+	// Outputs:
+	//   - *domain.AgentRoute: The chosen agent, or an empty Agent if none fits
+	//                         (the task then goes to the default agent)
+	//   - error: Returns error if classification could not be performed
+	Route(ctx context.Context, task *domain.Task, agents []domain.AgentProfile) (*domain.AgentRoute, error)
+}
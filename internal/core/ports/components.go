@@ -6,6 +6,21 @@ import (
 	"github.com/JAROBOTAI/jaro/internal/core/domain"
 )
 
+// IntentNormalizer turns a raw task input into a structured intent before planning.
+// Its output feeds agent routing and the Planner via Task.NormalizedIntent and Task.Metadata.
+type IntentNormalizer interface {
+	// Normalize cleans up a task input and extracts its parameters.
+	// Purpose: Gives planners a concise request plus structured entities (dates, amounts,
+	//          locations) instead of free-form text.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - task: The task to normalize (Input, CreatedAt for relative dates)
+	// Outputs:
+	//   - *domain.Intent: Cleaned text, detected language, entities and a 0-1 confidence
+	//   - error: Returns error if normalization could not be performed (e.g., LLM unavailable)
+	Normalize(ctx context.Context, task *domain.Task) (*domain.Intent, error)
+}
+
 // Planner is responsible for generating execution plans from task requirements.
 // It uses LLM capabilities to decompose complex tasks into executable steps.
 type Planner interface {
@@ -17,9 +32,42 @@ type Planner interface {
 	//   - task: The task requiring a plan (contains user input and normalized intent)
 	//   - tools: Available tools that can be used in the plan steps
 	// Outputs:
-	//   - *domain.Plan: Generated plan with steps, dependencies, and risk summary
+	//   - *domain.Plan: Generated plan with steps, dependencies (Step.DependsOn), and risk summary.
+	//                   Plans must be acyclic; if no step declares DependsOn, steps run in order.
+	//                   DECISION steps may declare Branches whose targets depend on the decision.
 	//   - error: Returns error if LLM fails, task is malformed, or no viable plan exists
 	CreatePlan(ctx context.Context, task *domain.Task, tools []domain.ToolMetadata) (*domain.Plan, error)
+
+	// RevisePlan generates a replacement plan after verification found the goal unmet.
+	// Purpose: Lets the orchestrator recover from plans that ran but did not achieve the goal.
+	//          The revised plan should address the verification issues rather than repeat
+	//          the previous plan verbatim.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - task: The task being executed
+	//   - previous: The executed plan, with step statuses as they ended
+	//   - verification: The failed verification describing what is missing
+	//   - tools: Available tools that can be used in the plan steps
+	// Outputs:
+	//   - *domain.Plan: New plan with fresh step IDs (revision fields are set by the orchestrator)
+	//   - error: Returns error if LLM fails or no viable revision exists
+	RevisePlan(ctx context.Context, task *domain.Task, previous *domain.Plan, verification *domain.Verification, tools []domain.ToolMetadata) (*domain.Plan, error)
+}
+
+// Verifier checks whether the results of a plan actually achieve its goal.
+// It backs VERIFY steps and the verification phase that runs before a task is marked DONE.
+type Verifier interface {
+	// Verify evaluates the accumulated step results against Plan.Goal.
+	// Purpose: Prevents reporting DONE when steps succeeded but the user's goal was not met.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - task: The task being verified (provides the original input)
+	//   - plan: The plan whose goal is checked, with current step statuses
+	//   - results: Results of the steps that have run so far, in plan order
+	// Outputs:
+	//   - *domain.Verification: Verdict with reason and issues (Passed false if the goal is unmet)
+	//   - error: Returns error if verification could not be performed (e.g., LLM unavailable)
+	Verify(ctx context.Context, task *domain.Task, plan *domain.Plan, results []*domain.StepResult) (*domain.Verification, error)
 }
 
 // Executor is responsible for executing individual plan steps.
@@ -27,11 +75,13 @@ type Planner interface {
 type Executor interface {
 	// ExecuteStep runs a single step from a plan and returns the result.
 	// Purpose: Executes one atomic unit of work (tool call, LLM reasoning, decision).
-	//          Handles retries, error capture, and duration tracking.
+	//          Handles error capture and duration tracking; retries are applied by the
+	//          orchestrator, so errors should wrap domain.ErrTransient or domain.ErrPermanent
+	//          when the failure class is known.
 	// Inputs:
 	//   - ctx: Context for cancellation and timeout control
 	//   - task: The parent task (provides context and metadata)
-	//   - step: The step to execute (contains type, tool name, input, etc.)
+	//   - step: The step to execute (contains type, tool name, input, RetryCount, etc.)
 	// Outputs:
 	//   - *domain.StepResult: Execution result including success status, output, and metrics
 	//   - error: Returns error if step execution fails critically or context is cancelled
@@ -13,6 +13,14 @@ type Clock interface {
 	// Outputs:
 	//   - time.Time: Current time (real or mocked)
 	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	// Purpose: Lets services wait (e.g., retry backoff) without real sleeps in tests.
+	// Inputs:
+	//   - d: Duration to wait
+	// Outputs:
+	//   - <-chan time.Time: Channel that receives the time once d has elapsed (real or mocked)
+	After(d time.Duration) <-chan time.Time
 }
 
 // IDGenerator provides unique identifier generation for entities.
@@ -28,6 +36,18 @@ type IDGenerator interface {
 	Generate() string
 }
 
+// RandomSource provides random numbers for non-deterministic policies.
+// Purpose: Abstracts randomness (e.g., retry backoff jitter) to enable deterministic testing.
+// Why: Direct math/rand calls make retry delays unpredictable in tests and replays.
+type RandomSource interface {
+	// Float64 returns a random number.
+	// Purpose: Supplies the jitter applied to retry backoff delays.
+	// Inputs: None
+	// Outputs:
+	//   - float64: Pseudo-random number in [0,1) (fixed in tests)
+	Float64() float64
+}
+
 // Logger provides structured logging capabilities.
 // Purpose: Abstracts logging to enable testing, custom log formats, and centralized logging.
 //          Separates concerns between business logic and log output format/destination.
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"io"
 
 	"github.com/JAROBOTAI/jaro/internal/core/domain"
 )
@@ -15,7 +16,9 @@ type TaskRepository interface {
 	//   - ctx: Context for cancellation and timeout control
 	//   - task: The task to save (must have a valid ID)
 	// Outputs:
-	//   - error: Returns error if database is unavailable or task data is invalid
+	//   - error: Returns error if database is unavailable or task data is invalid.
+	//            Implementations must reject status changes forbidden by the task state
+	//            machine with an error wrapping domain.ErrInvalidTransition.
 	SaveTask(ctx context.Context, task *domain.Task) error
 
 	// GetTask retrieves a task by its unique identifier.
@@ -27,6 +30,389 @@ type TaskRepository interface {
 	//   - *domain.Task: The retrieved task with all fields populated
 	//   - error: Returns error if task is not found or database is unavailable
 	GetTask(ctx context.Context, id string) (*domain.Task, error)
+
+	// ListTasks returns all tasks matching the filter, oldest first.
+	// Purpose: Lets the orchestrator find unfinished tasks after a restart (crash recovery).
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - filter: Criteria to match (empty fields match everything)
+	// Outputs:
+	//   - []*domain.Task: Matching tasks ordered by CreatedAt
+	//   - error: Returns error if database is unavailable
+	ListTasks(ctx context.Context, filter domain.TaskFilter) ([]*domain.Task, error)
+}
+
+// PlanRepository provides persistence operations for execution plans and their steps.
+// This is a secondary port (infrastructure) that keeps step progress durable while a plan runs.
+type PlanRepository interface {
+	// SavePlan persists a complete plan including all of its steps (insert or update).
+	// Purpose: Stores the plan produced by the Planner so it can be inspected and resumed.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - plan: The plan to save (must have a valid ID and TaskID)
+	// Outputs:
+	//   - error: Returns error if storage is unavailable or plan data is invalid.
+	//            Replacing a plan must not change step statuses in ways the step state
+	//            machine forbids (error wraps domain.ErrInvalidTransition).
+	SavePlan(ctx context.Context, plan *domain.Plan) error
+
+	// GetPlan retrieves a plan by its unique identifier.
+	// Purpose: Loads a plan with current step statuses for display or resumption.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - id: Unique identifier of the plan to retrieve
+	// Outputs:
+	//   - *domain.Plan: The retrieved plan with all steps populated
+	//   - error: Returns error if plan is not found or storage is unavailable
+	GetPlan(ctx context.Context, id string) (*domain.Plan, error)
+
+	// ListTaskPlans returns all plans of a task, including replaced revisions, in creation order.
+	// Purpose: Exposes the revision history produced by replanning.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	// Outputs:
+	//   - []*domain.Plan: Plans of the task (empty if none)
+	//   - error: Returns error if storage is unavailable
+	ListTaskPlans(ctx context.Context, taskID string) ([]*domain.Plan, error)
+
+	// UpdateStep replaces the stored state of a single step within a plan.
+	// Purpose: Persists step progress (Status, RetryCount, ResultRef) without rewriting the plan.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - planID: Unique identifier of the plan owning the step
+	//   - step: The step with updated fields (matched by ID)
+	// Outputs:
+	//   - error: Returns error if plan or step is not found or storage is unavailable.
+	//            Forbidden status changes are rejected (error wraps domain.ErrInvalidTransition).
+	UpdateStep(ctx context.Context, planID string, step *domain.Step) error
+
+	// SaveStepResult persists the execution result of a step.
+	// Purpose: Keeps step outputs available after execution for display and later steps.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - planID: Unique identifier of the plan owning the step
+	//   - result: The step result to save (StepID must reference a step of the plan)
+	// Outputs:
+	//   - error: Returns error if plan or step is not found or storage is unavailable
+	SaveStepResult(ctx context.Context, planID string, result *domain.StepResult) error
+
+	// GetStepResult retrieves the stored execution result of a step.
+	// Purpose: Loads a step output for display or as input to dependent steps.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - planID: Unique identifier of the plan owning the step
+	//   - stepID: Unique identifier of the step
+	// Outputs:
+	//   - *domain.StepResult: The stored result
+	//   - error: Returns error if no result is stored or storage is unavailable
+	GetStepResult(ctx context.Context, planID string, stepID string) (*domain.StepResult, error)
+}
+
+// ApprovalRepository provides persistence operations for human approval requests.
+// This is a secondary port that keeps approval gates durable while a task is suspended.
+type ApprovalRepository interface {
+	// SaveApproval persists an approval request (insert or update).
+	// Purpose: Records a pending approval and, later, the reviewer's decision.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - approval: The approval request to save (must have a valid ID and TaskID)
+	// Outputs:
+	//   - error: Returns error if storage is unavailable or approval data is invalid
+	SaveApproval(ctx context.Context, approval *domain.ApprovalRequest) error
+
+	// GetApproval retrieves an approval request by its unique identifier.
+	// Purpose: Loads a single approval request for decision handling.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - id: Unique identifier of the approval request
+	// Outputs:
+	//   - *domain.ApprovalRequest: The retrieved approval request
+	//   - error: Returns error if approval is not found or storage is unavailable
+	GetApproval(ctx context.Context, id string) (*domain.ApprovalRequest, error)
+
+	// ListTaskApprovals returns all approval requests of a task in creation order.
+	// Purpose: Allows the orchestrator to find the approval for a step and clients to see history.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	// Outputs:
+	//   - []*domain.ApprovalRequest: Approval requests of the task (empty if none)
+	//   - error: Returns error if storage is unavailable
+	ListTaskApprovals(ctx context.Context, taskID string) ([]*domain.ApprovalRequest, error)
+
+	// ListApprovals returns approval requests across all tasks that match the filter.
+	// Purpose: Feeds reviewer inboxes (e.g., all OPEN approvals for a user's tasks).
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - filter: Criteria to match (empty fields match everything)
+	// Outputs:
+	//   - []*domain.ApprovalRequest: Matching approval requests ordered by creation time
+	//   - error: Returns error if storage is unavailable
+	ListApprovals(ctx context.Context, filter domain.ApprovalFilter) ([]*domain.ApprovalRequest, error)
+}
+
+// IdempotencyRepository remembers the tasks created for client idempotency keys.
+// This is a secondary port (infrastructure); records expire after their ExpiresAt.
+type IdempotencyRepository interface {
+	// ClaimKey atomically reserves an idempotency key for a new task.
+	// Purpose: Lets exactly one of several concurrent requests with the same key create a task.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - record: The record to store (UserID, Key, TaskID and ExpiresAt must be set)
+	// Outputs:
+	//   - *domain.IdempotencyRecord: The unexpired record already holding the key, or nil
+	//                                if the key was claimed by this call
+	//   - error: Returns error if the record is invalid or storage is unavailable
+	ClaimKey(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error)
+
+	// ReleaseKey deletes the record of an idempotency key.
+	// Purpose: Frees a claimed key when its task could not be created, so the client can retry.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - userID: Owner of the key
+	//   - key: The idempotency key
+	// Outputs:
+	//   - error: Returns error if storage is unavailable (releasing an unknown key is not an error)
+	ReleaseKey(ctx context.Context, userID string, key string) error
+}
+
+// UsageRepository provides persistence operations for LLM usage records.
+// This is a secondary port (infrastructure) backing per-task and per-user cost reporting.
+type UsageRepository interface {
+	// SaveUsage persists the usage of one LLM call.
+	// Purpose: Keeps an append-only ledger of token usage and cost.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - record: The usage record to save (must have a valid ID and TaskID)
+	// Outputs:
+	//   - error: Returns error if storage is unavailable or record data is invalid
+	SaveUsage(ctx context.Context, record *domain.UsageRecord) error
+
+	// ListUsage returns all usage records matching the filter, oldest first.
+	// Purpose: Feeds task usage totals and usage reports.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - filter: Criteria to match (empty fields match everything)
+	// Outputs:
+	//   - []*domain.UsageRecord: Matching records ordered by CreatedAt
+	//   - error: Returns error if storage is unavailable
+	ListUsage(ctx context.Context, filter domain.UsageFilter) ([]*domain.UsageRecord, error)
+}
+
+// ToolCallRepository provides persistence operations for tool invocations.
+// This is a secondary port (infrastructure) backing per-task and cross-task tool call analysis.
+type ToolCallRepository interface {
+	// SaveToolCall persists one tool invocation.
+	// Purpose: Keeps an append-only log of tool inputs, outputs and outcomes.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - call: The tool call to save (must have a valid ID and TaskID)
+	// Outputs:
+	//   - error: Returns error if storage is unavailable or call data is invalid
+	SaveToolCall(ctx context.Context, call *domain.ToolCall) error
+
+	// ListToolCalls returns the tool calls matching the filter, oldest first.
+	// Purpose: Shows what tools a task ran and supports analysis across tasks.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - filter: Criteria to match (empty fields match everything); with a Limit only
+	//             the most recent matching calls are returned
+	// Outputs:
+	//   - []*domain.ToolCall: Matching calls ordered by CreatedAt
+	//   - error: Returns error if storage is unavailable
+	ListToolCalls(ctx context.Context, filter domain.ToolCallFilter) ([]*domain.ToolCall, error)
+}
+
+// BudgetRepository provides persistence operations for per-user budgets.
+// This is a secondary port (infrastructure); users without a stored budget get the default.
+type BudgetRepository interface {
+	// SaveBudget persists a user budget, replacing any previous one.
+	// Purpose: Stores the daily and monthly caps an administrator set for a user.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - budget: The budget to save (must have a UserID)
+	// Outputs:
+	//   - error: Returns error if storage is unavailable or budget data is invalid
+	SaveBudget(ctx context.Context, budget *domain.UserBudget) error
+
+	// GetBudget retrieves the budget of a user.
+	// Purpose: Looked up before each LLM-consuming step of the user's tasks.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - userID: Unique identifier of the user
+	// Outputs:
+	//   - *domain.UserBudget: The user's budget, or nil if none is stored
+	//   - error: Returns error if storage is unavailable
+	GetBudget(ctx context.Context, userID string) (*domain.UserBudget, error)
+}
+
+// ArtifactStore provides blob storage for files attached to or produced by tasks.
+// This is a secondary port (infrastructure) implemented by filesystem or object storage adapters.
+// Artifacts are addressed by task ID and name; names are validated with domain.ValidateArtifactName.
+type ArtifactStore interface {
+	// PutArtifact stores the content of an artifact, replacing any artifact with the same name.
+	// Purpose: Persists user uploads and agent outputs. The store computes Size and Checksum
+	//          while writing, so callers never hold the whole content in memory.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - artifact: Metadata of the artifact (TaskID, Name, ContentType, CreatedBy, CreatedAt)
+	//   - content: The artifact content, read until EOF
+	// Outputs:
+	//   - *domain.Artifact: The stored metadata including Size and Checksum
+	//   - error: Returns error if the name is invalid (wraps domain.ErrInvalidArtifactName),
+	//            reading content fails or storage is unavailable; nothing is stored then
+	PutArtifact(ctx context.Context, artifact *domain.Artifact, content io.Reader) (*domain.Artifact, error)
+
+	// GetArtifact opens an artifact for reading.
+	// Purpose: Streams downloads without loading the content into memory.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	//   - name: Name of the artifact
+	// Outputs:
+	//   - *domain.Artifact: Metadata of the artifact
+	//   - io.ReadCloser: The content; the caller must close it
+	//   - error: Returns error wrapping domain.ErrArtifactNotFound if the task has no such artifact
+	GetArtifact(ctx context.Context, taskID string, name string) (*domain.Artifact, io.ReadCloser, error)
+
+	// ListArtifacts returns the metadata of all artifacts of a task.
+	// Purpose: Supports artifact listings and Task.Artifacts.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	// Outputs:
+	//   - []*domain.Artifact: Artifacts sorted by name (empty if the task has none)
+	//   - error: Returns error if storage is unavailable
+	ListArtifacts(ctx context.Context, taskID string) ([]*domain.Artifact, error)
+
+	// DeleteArtifact removes an artifact and its content.
+	// Purpose: Lets users replace attachments by removing outdated ones.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	//   - name: Name of the artifact
+	// Outputs:
+	//   - error: Returns error wrapping domain.ErrArtifactNotFound if the task has no such artifact
+	DeleteArtifact(ctx context.Context, taskID string, name string) error
+}
+
+// ResultStore holds step outputs too large to keep inline in domain.StepResult.Output.
+// This is a secondary port (infrastructure); the orchestrator offloads outputs above the
+// configured threshold and keeps only a preview and the returned reference in the result.
+type ResultStore interface {
+	// PutResult stores the full output of a step, replacing any output stored for the same step.
+	// Purpose: Keeps large tool outputs (scraped pages, file contents) out of task and plan reads.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	//   - planID: Unique identifier of the plan owning the step
+	//   - stepID: Unique identifier of the step
+	//   - output: The full step output
+	// Outputs:
+	//   - string: Opaque reference stored in StepResult.OutputRef and Step.ResultRef
+	//   - error: Returns error if the identifiers cannot be stored or storage is unavailable
+	PutResult(ctx context.Context, taskID string, planID string, stepID string, output string) (string, error)
+
+	// OpenResult opens a stored output for reading.
+	// Purpose: Serves full outputs, including byte ranges, without loading them into memory.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - ref: Reference returned by PutResult
+	// Outputs:
+	//   - io.ReadSeekCloser: The output; the caller must close it
+	//   - error: Returns error if the reference is unknown or storage is unavailable
+	OpenResult(ctx context.Context, ref string) (io.ReadSeekCloser, error)
+}
+
+// TaskQueue buffers tasks that have been accepted but not yet picked up for execution.
+// This is a secondary port that decouples task submission from the worker pool running tasks.
+// Implementations decide the dequeue order (e.g., by Task.Priority with fairness across users).
+type TaskQueue interface {
+	// Enqueue adds a task to the queue.
+	// Purpose: Hands a freshly created task to the worker pool without blocking the caller.
+	//          The task's Priority and UserID are captured for scheduling.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - task: The task to queue (must have a valid ID)
+	// Outputs:
+	//   - error: Returns error wrapping domain.ErrQueueFull if the queue is at capacity
+	Enqueue(ctx context.Context, task *domain.Task) error
+
+	// Dequeue removes the next task from the queue, blocking until one is available.
+	// Purpose: Feeds idle workers of the worker pool.
+	// Inputs:
+	//   - ctx: Context for cancellation; Dequeue returns when it is done
+	// Outputs:
+	//   - string: Unique identifier of the dequeued task
+	//   - error: Returns ctx.Err() if the context ends before a task is available
+	Dequeue(ctx context.Context) (string, error)
+
+	// Len returns the number of tasks waiting in the queue.
+	// Purpose: Supports monitoring and backpressure decisions.
+	// Inputs: None
+	// Outputs:
+	//   - int: Number of queued tasks
+	Len() int
+
+	// Position reports where a task currently stands in the dequeue order.
+	// Purpose: Lets clients see how many tasks will be picked up before theirs.
+	// Inputs:
+	//   - taskID: Unique identifier of the queued task
+	// Outputs:
+	//   - int: 1-based position (1 = next to be dequeued)
+	//   - bool: False if the task is not in the queue
+	Position(taskID string) (int, bool)
+
+	// Remove drops a task from the queue before a worker picks it up.
+	// Purpose: Keeps canceled tasks from occupying queue capacity and positions.
+	// Inputs:
+	//   - taskID: Unique identifier of the task to remove
+	// Outputs:
+	//   - bool: True if the task was queued and has been removed
+	Remove(taskID string) bool
+}
+
+// ScheduleRepository provides persistence operations for schedules.
+// This is a secondary port that the scheduler uses to store definitions and run bookkeeping.
+type ScheduleRepository interface {
+	// SaveSchedule persists a schedule (insert or update).
+	// Purpose: Stores schedule definitions and their next/last run state.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - schedule: The schedule to save (must have a valid ID)
+	// Outputs:
+	//   - error: Returns error if persistence fails
+	SaveSchedule(ctx context.Context, schedule *domain.Schedule) error
+
+	// GetSchedule retrieves a schedule by its unique identifier.
+	// Purpose: Loads a schedule for inspection or modification.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - id: Unique identifier of the schedule
+	// Outputs:
+	//   - *domain.Schedule: The retrieved schedule
+	//   - error: Returns error if the schedule is not found
+	GetSchedule(ctx context.Context, id string) (*domain.Schedule, error)
+
+	// ListSchedules returns schedules that match the filter.
+	// Purpose: Supports user listings and finding schedules that are due.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - filter: Criteria such as owner, status and due time
+	// Outputs:
+	//   - []*domain.Schedule: Matching schedules in creation order
+	//   - error: Returns error if retrieval fails
+	ListSchedules(ctx context.Context, filter domain.ScheduleFilter) ([]*domain.Schedule, error)
+
+	// DeleteSchedule removes a schedule.
+	// Purpose: Stops a schedule permanently; tasks it already started are unaffected.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - id: Unique identifier of the schedule
+	// Outputs:
+	//   - error: Returns error if the schedule is not found
+	DeleteSchedule(ctx context.Context, id string) error
 }
 
 // AuditRepository provides persistence operations for audit events.
@@ -43,6 +429,38 @@ type AuditRepository interface {
 	SaveEvent(ctx context.Context, event *domain.AuditEvent) error
 }
 
+// TaskEventBus buffers the recent events of each task and fans them out to live subscribers.
+// This is a secondary port backing the live progress stream of tasks. Implementations must
+// never block publishers on slow subscribers.
+type TaskEventBus interface {
+	// Publish appends an event to the stream of its task.
+	// Purpose: Delivers the event to current subscribers and keeps it for clients resuming
+	//          later. Events are shared with subscribers and must not be modified afterwards.
+	// Inputs:
+	//   - event: The event to publish (Seq is assigned in place)
+	// Outputs: None
+	Publish(event *domain.TaskEvent)
+
+	// CloseTask ends the live stream of a task after its final event.
+	// Purpose: Lets subscribers of a finished task stop waiting; their Events channels are
+	//          closed. Events published afterwards are still buffered.
+	// Inputs:
+	//   - taskID: Unique identifier of the task
+	// Outputs: None
+	CloseTask(taskID string)
+
+	// Subscribe starts receiving the events of a task.
+	// Purpose: Replays the buffered events a client has missed and delivers new ones live.
+	// Inputs:
+	//   - ctx: Lifetime of the subscription; Events is closed once it is done
+	//   - taskID: Unique identifier of the task
+	//   - afterSeq: Seq of the last event the client has seen (0: none). A position beyond
+	//               the stream (e.g., from before a restart) replays the whole buffer
+	// Outputs:
+	//   - *domain.TaskEventSubscription: Buffered events after afterSeq and the live channel
+	Subscribe(ctx context.Context, taskID string, afterSeq int64) *domain.TaskEventSubscription
+}
+
 // LLMProvider is the interface to external Large Language Model services.
 // This is a secondary port that abstracts LLM provider implementations (OpenAI, Anthropic, etc.).
 type LLMProvider interface {
@@ -53,7 +471,26 @@ type LLMProvider interface {
 	//   - ctx: Context for cancellation and timeout control
 	//   - prompt: The text prompt to send to the LLM (includes instructions and context)
 	// Outputs:
-	//   - string: The generated text response from the LLM
+	//   - *domain.LLMResponse: The generated text and the model and prompt/completion tokens
+	//                          of the call (callers report them with execctx.RecordLLMUsage)
+	//   - error: Returns error if LLM service is unavailable, rate-limited, or prompt is invalid
+	GenerateText(ctx context.Context, prompt string) (*domain.LLMResponse, error)
+}
+
+// StreamingLLMProvider is an LLMProvider that can deliver generated text while it is produced.
+// Callers prefer StreamText when a provider implements it and report each fragment with
+// execctx.RecordLLMDelta, so clients can follow the model's output live.
+type StreamingLLMProvider interface {
+	LLMProvider
+
+	// StreamText sends a prompt to the LLM and reports the response text as it is generated.
+	// Purpose: Same as GenerateText, with the text also delivered incrementally.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - prompt: The text prompt to send to the LLM
+	//   - onDelta: Called with each fragment of generated text, in order
+	// Outputs:
+	//   - *domain.LLMResponse: The complete text and the model and tokens of the call
 	//   - error: Returns error if LLM service is unavailable, rate-limited, or prompt is invalid
-	GenerateText(ctx context.Context, prompt string) (string, error)
+	StreamText(ctx context.Context, prompt string, onDelta func(delta string)) (*domain.LLMResponse, error)
 }
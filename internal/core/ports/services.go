@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"io"
 
 	"github.com/JAROBOTAI/jaro/internal/core/domain"
 )
@@ -10,16 +11,36 @@ import (
 // It serves as the main entry point for task management and orchestration operations.
 // All external clients (HTTP handlers, CLI, gRPC) should interact through this interface.
 type Orchestrator interface {
-	// StartTask initializes a new task based on user input and creates an execution plan.
+	// StartTask initializes a new task based on user input and queues it for execution.
 	// Purpose: This is the primary entry point for submitting work to the JARO system.
+	//          Returns immediately; the worker pool plans and executes the task.
 	// Inputs:
 	//   - ctx: Context for cancellation and timeout control
 	//   - input: Raw user request in natural language
 	//   - userID: Unique identifier of the user submitting the task
+	//   - opts: Optional creation settings such as Priority, Deadline/Timeout, Budget,
+	//           TargetAgent (zero values select defaults; no agent means routing) and
+	//           IdempotencyKey (repeats per user return the original task)
 	// Outputs:
-	//   - *domain.Task: The created task with status NEW or PLANNING
-	//   - error: Returns error if input validation fails or system is unavailable
-	StartTask(ctx context.Context, input string, userID string) (*domain.Task, error)
+	//   - *domain.Task: The accepted task in status NEW, or the original task for a repeat
+	//   - error: Returns error if input validation fails or system is unavailable.
+	//            Wraps domain.ErrInvalidPriority for unknown priorities,
+	//            domain.ErrInvalidDeadline for past deadlines or negative timeouts,
+	//            domain.ErrInvalidBudget for negative budget limits,
+	//            domain.ErrUnknownAgent for an unregistered TargetAgent,
+	//            domain.ErrIdempotencyKeyReused / domain.ErrIdempotencyKeyInUse for a key
+	//            used by a different or still running request and
+	//            domain.ErrQueueFull if the task queue is at capacity.
+	StartTask(ctx context.Context, input string, userID string, opts domain.TaskOptions) (*domain.Task, error)
+
+	// RunTask plans and executes a queued task until it finishes or suspends.
+	// Purpose: Invoked by the worker pool for each task taken from the TaskQueue.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control (owned by the worker)
+	//   - taskID: Unique identifier of the task to run
+	// Outputs:
+	//   - error: Returns error if the task is not found or its state could not be persisted
+	RunTask(ctx context.Context, taskID string) error
 
 	// GetTaskStatus retrieves the current state and progress of a task.
 	// Purpose: Allows clients to poll for task status and results.
@@ -28,9 +49,243 @@ type Orchestrator interface {
 	//   - taskID: Unique identifier of the task to query
 	// Outputs:
 	//   - *domain.Task: Current task state including status, steps, and artifacts
+	//                   (QueuePosition is set while the task is waiting in the queue;
+	//                   UsageTokens and CostEstimate are totals of the usage ledger)
 	//   - error: Returns error if task is not found or access is denied
 	GetTaskStatus(ctx context.Context, taskID string) (*domain.Task, error)
 
+	// GetTaskPlan retrieves the execution plan of a task with current step statuses.
+	// Purpose: Shows clients what the agent intends to do and how far it has got.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task whose plan is requested
+	// Outputs:
+	//   - *domain.Plan: The task's plan including step statuses, retry counts and result refs
+	//   - error: Returns error if the task is not found or has no plan yet
+	GetTaskPlan(ctx context.Context, taskID string) (*domain.Plan, error)
+
+	// GetTaskPlans retrieves every plan revision of a task.
+	// Purpose: Shows how the plan evolved when failed verification triggered replanning.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	// Outputs:
+	//   - []*domain.Plan: Plans of the task ordered by revision (the last one is current)
+	//   - error: Returns error if the task is not found or plans cannot be loaded
+	GetTaskPlans(ctx context.Context, taskID string) ([]*domain.Plan, error)
+
+	// ListAgents returns the profiles of every agent tasks can be routed to.
+	// Purpose: Agent discovery for clients that name an agent in TaskOptions.TargetAgent.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	// Outputs:
+	//   - []domain.AgentProfile: The default agent (CORE) followed by registered agents by name
+	ListAgents(ctx context.Context) []domain.AgentProfile
+
+	// GetTaskToolCalls returns the tool calls made by a task's steps.
+	// Purpose: Shows the exact tool inputs and outputs of a task for debugging misbehaving agents.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	// Outputs:
+	//   - []*domain.ToolCall: Calls of the task, oldest first
+	//   - error: Returns error if the task is not found or calls cannot be loaded
+	GetTaskToolCalls(ctx context.Context, taskID string) ([]*domain.ToolCall, error)
+
+	// ListToolCalls returns the tool calls matching the filter across tasks.
+	// Purpose: Supports cross-task analysis, e.g., failed calls of a tool since a given time.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - filter: Optional TaskID, ToolName, Status, Since and Limit (most recent calls)
+	// Outputs:
+	//   - []*domain.ToolCall: Matching calls, oldest first
+	//   - error: Returns error if calls cannot be loaded; wraps domain.ErrInvalidToolCallStatus
+	//            for unknown statuses
+	ListToolCalls(ctx context.Context, filter domain.ToolCallFilter) ([]*domain.ToolCall, error)
+
+	// SubscribeTaskEvents starts following the live progress of a task.
+	// Purpose: Streams status changes, step progress, approval requests and LLM text as it is
+	//          generated, so clients need not poll. Buffered events after afterSeq are replayed
+	//          first; the stream of a finished task ends after them.
+	// Inputs:
+	//   - ctx: Lifetime of the subscription (e.g., the client's request)
+	//   - taskID: Unique identifier of the task
+	//   - afterSeq: Seq of the last event the client has seen (0: none)
+	// Outputs:
+	//   - *domain.TaskEventSubscription: Buffered events and the channel of live events
+	//   - error: Returns error if the task is not found or event streaming is not configured
+	SubscribeTaskEvents(ctx context.Context, taskID string, afterSeq int64) (*domain.TaskEventSubscription, error)
+
+	// GetStepOutput opens the full output of an executed step.
+	// Purpose: Serves step outputs of any size for debugging; outputs above the inline size
+	//          limit are kept in the ResultStore and only previewed in the step result.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	//   - stepID: Unique identifier of the step
+	//   - planID: Plan revision owning the step (empty: the task's current plan)
+	// Outputs:
+	//   - *domain.StepResult: The stored result (Output is a preview if OutputRef is set)
+	//   - io.ReadSeekCloser: The full output, seekable for range requests; the caller must close it
+	//   - error: Returns error if the task, plan or step result is not found
+	GetStepOutput(ctx context.Context, taskID string, stepID string, planID string) (*domain.StepResult, io.ReadSeekCloser, error)
+
+	// AddArtifact stores a file as an artifact of a task, replacing one with the same name.
+	// Purpose: Attaches user uploads (e.g., PDFs) and agent outputs (e.g., CSV reports) to a task.
+	//          Callers validate the content type; the store computes size and checksum.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	//   - name: Artifact name (a plain file name without path separators)
+	//   - contentType: MIME type of the content (empty stores application/octet-stream)
+	//   - content: The artifact content, streamed to the store
+	//   - userID: Unique identifier of the uploading user or producing agent
+	// Outputs:
+	//   - *domain.Artifact: The stored artifact
+	//   - error: Returns error if the task is not found or storage fails.
+	//            Wraps domain.ErrInvalidArtifactName for invalid names.
+	AddArtifact(ctx context.Context, taskID string, name string, contentType string, content io.Reader, userID string) (*domain.Artifact, error)
+
+	// GetArtifact opens an artifact of a task for streaming.
+	// Purpose: Serves artifact downloads.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	//   - name: Name of the artifact
+	// Outputs:
+	//   - *domain.Artifact: Metadata of the artifact
+	//   - io.ReadCloser: The content; the caller must close it
+	//   - error: Returns error if the task is not found; wraps domain.ErrArtifactNotFound
+	//            if it has no such artifact
+	GetArtifact(ctx context.Context, taskID string, name string) (*domain.Artifact, io.ReadCloser, error)
+
+	// ListArtifacts returns the artifacts of a task.
+	// Purpose: Shows which files are attached to a task.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	// Outputs:
+	//   - []*domain.Artifact: Artifacts sorted by name
+	//   - error: Returns error if the task is not found or artifacts cannot be listed
+	ListArtifacts(ctx context.Context, taskID string) ([]*domain.Artifact, error)
+
+	// DeleteArtifact removes an artifact from a task.
+	// Purpose: Lets users withdraw outdated attachments.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	//   - name: Name of the artifact
+	//   - userID: Unique identifier of the user deleting the artifact
+	// Outputs:
+	//   - error: Returns error if the task is not found; wraps domain.ErrArtifactNotFound
+	//            if it has no such artifact
+	DeleteArtifact(ctx context.Context, taskID string, name string, userID string) error
+
+	// GetTaskUsage returns the LLM token usage and cost of a task.
+	// Purpose: Shows what a task cost, broken down by step, phase and model.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	// Outputs:
+	//   - *domain.UsageSummary: Usage totals of the task (ByStep lists calls made by steps)
+	//   - error: Returns error if the task is not found or usage cannot be loaded
+	GetTaskUsage(ctx context.Context, taskID string) (*domain.UsageSummary, error)
+
+	// GetUsage aggregates LLM token usage and cost across tasks.
+	// Purpose: Cost reporting per user and time range (e.g., monthly finance reports).
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - filter: Optional UserID, TaskID and [From, To) range of the LLM calls
+	// Outputs:
+	//   - *domain.UsageSummary: Totals broken down by model and phase (and by user if
+	//                           the filter names none)
+	//   - error: Returns error wrapping domain.ErrInvalidTimeRange if To is not after From,
+	//            or if usage cannot be loaded
+	GetUsage(ctx context.Context, filter domain.UsageFilter) (*domain.UsageSummary, error)
+
+	// SetUserBudget stores the daily and monthly LLM spend caps of a user.
+	// Purpose: Lets administrators bound what a user's tasks may cost. Tasks of the user
+	//          paused in BUDGET_EXCEEDED resume if the new budget allows.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - budget: The user's limits (UserID required; zero limits are unlimited)
+	//   - actorID: Unique identifier of the administrator making the change
+	// Outputs:
+	//   - *domain.UserBudget: The stored budget
+	//   - error: Returns error if the actor is not a budget administrator (wraps
+	//            domain.ErrNotBudgetAdmin), inputs are invalid (wraps domain.ErrInvalidBudget
+	//            for negative limits), budgets are not configured or persistence fails
+	SetUserBudget(ctx context.Context, budget domain.UserBudget, actorID string) (*domain.UserBudget, error)
+
+	// GetUserBudget returns a user's budget with the spend counted against it.
+	// Purpose: Shows how much of the current UTC day's and month's caps a user has used.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - userID: Unique identifier of the user
+	// Outputs:
+	//   - *domain.BudgetStatus: The effective budget (the default if none is stored) and spend
+	//   - error: Returns error if the budget or usage cannot be loaded
+	GetUserBudget(ctx context.Context, userID string) (*domain.BudgetStatus, error)
+
+	// UpdateTaskBudget replaces the budget of a task paused in BUDGET_EXCEEDED.
+	// Purpose: Lets administrators raise the limit of a task that hit it; the task resumes
+	//          if its spend and its user's budget allow.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the paused task
+	//   - budget: The new limits (zero limits are unlimited)
+	//   - userID: Unique identifier of the administrator making the change
+	// Outputs:
+	//   - *domain.Task: The task with its new budget
+	//   - error: Returns error if the task is not found or persistence fails. Wraps
+	//            domain.ErrNotBudgetAdmin if userID is not a budget administrator,
+	//            domain.ErrInvalidBudget for negative limits, domain.ErrTaskFinished for
+	//            finished tasks and domain.ErrTaskNotOverBudget for tasks not paused over budget.
+	UpdateTaskBudget(ctx context.Context, taskID string, budget domain.BudgetLimit, userID string) (*domain.Task, error)
+
+	// GetTaskChildren retrieves the child tasks spawned by a task's SUB_TASK steps.
+	// Purpose: Lets clients walk a task tree; each child is a full task with its own audit trail.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the parent task
+	// Outputs:
+	//   - []*domain.Task: Direct children of the task in creation order
+	//   - error: Returns error if the task is not found
+	GetTaskChildren(ctx context.Context, taskID string) ([]*domain.Task, error)
+
+	// CancelTask stops a task, interrupting any step that is currently executing.
+	// Purpose: Lets users and operators halt runaway tasks before they consume more resources.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task to cancel
+	//   - userID: Unique identifier of the user requesting cancellation
+	//   - reason: Human-readable reason recorded on the task and in the audit log
+	// Outputs:
+	//   - error: Returns error if the task is not found or persistence fails.
+	//            Wraps domain.ErrTaskFinished if the task has already reached a terminal status.
+	CancelTask(ctx context.Context, taskID string, userID string, reason string) error
+
+	// RecoverTasks resumes or fails every non-terminal task left behind by a previous process.
+	// Purpose: Called once at boot so tasks stuck in PLANNING, EXECUTING, VERIFYING or
+	//          WAITING_APPROVAL after a crash move again; tasks that can continue are queued
+	//          for the worker pool. Emits TASK_RECOVERED per task.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	// Outputs:
+	//   - []*domain.Task: The recovered tasks in their state after recovery
+	//   - error: Returns error if tasks could not be listed or some could not be recovered
+	RecoverTasks(ctx context.Context) ([]*domain.Task, error)
+
+	// ExpireDeadlines fails suspended tasks whose deadline has passed.
+	// Purpose: Called periodically (by the deadline watcher) so tasks waiting for an approval,
+	//          their sub-tasks or a budget increase fail at their deadline like running tasks.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	// Outputs:
+	//   - int: Number of tasks failed
+	//   - error: Returns error if tasks could not be listed or some could not be failed
+	ExpireDeadlines(ctx context.Context) (int, error)
+
 	// HandleApproval processes user approval or rejection for high-risk steps.
 	// Purpose: Implements the human-in-the-loop pattern for risky operations.
 	// Inputs:
@@ -39,7 +294,96 @@ type Orchestrator interface {
 	//   - stepID: Unique identifier of the step requiring approval
 	//   - approved: User decision (true = approve, false = reject)
 	//   - userID: Unique identifier of the user making the decision
+	//   - comment: Optional reviewer comment stored with the decision
+	// Outputs:
+	//   - error: Returns error if task/step not found, already processed, or unauthorized.
+	//            Wraps domain.ErrTaskNotWaitingApproval or domain.ErrStepMismatch for conflicts.
+	HandleApproval(ctx context.Context, taskID string, stepID string, approved bool, userID string, comment string) error
+
+	// ListApprovals returns approval requests across tasks that match the filter.
+	// Purpose: Lets reviewers find pending decisions without knowing task IDs.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - filter: Criteria such as task owner and approval status
+	// Outputs:
+	//   - []*domain.ApprovalRequest: Matching approval requests
+	//   - error: Returns error if approvals cannot be loaded
+	ListApprovals(ctx context.Context, filter domain.ApprovalFilter) ([]*domain.ApprovalRequest, error)
+
+	// GetTaskApprovals returns the approval history of a single task.
+	// Purpose: Shows which steps were gated and how each was decided.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - taskID: Unique identifier of the task
+	// Outputs:
+	//   - []*domain.ApprovalRequest: Approval requests of the task in creation order
+	//   - error: Returns error if the task is not found or approvals cannot be loaded
+	GetTaskApprovals(ctx context.Context, taskID string) ([]*domain.ApprovalRequest, error)
+}
+
+// Scheduler is the primary port for managing schedules that start tasks at fixed times.
+// Due schedules call Orchestrator.StartTask on behalf of their owner.
+type Scheduler interface {
+	// CreateSchedule registers a recurring (cron) or one-shot schedule.
+	// Purpose: Lets users automate recurring work such as daily digests.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - spec: Schedule definition (exactly one of CronExpr or RunAt)
+	// Outputs:
+	//   - *domain.Schedule: The ACTIVE schedule with its first NextRunAt
+	//   - error: Returns error wrapping domain.ErrInvalidSchedule or domain.ErrInvalidPriority
+	//            if the definition is malformed, or if persistence fails
+	CreateSchedule(ctx context.Context, spec domain.ScheduleSpec) (*domain.Schedule, error)
+
+	// GetSchedule retrieves a schedule by its unique identifier.
+	// Purpose: Shows the definition and run bookkeeping of a schedule.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - scheduleID: Unique identifier of the schedule
+	// Outputs:
+	//   - *domain.Schedule: The schedule
+	//   - error: Returns error if the schedule is not found
+	GetSchedule(ctx context.Context, scheduleID string) (*domain.Schedule, error)
+
+	// ListSchedules returns schedules that match the filter.
+	// Purpose: Lets users review their automations.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - filter: Criteria such as owner and status
+	// Outputs:
+	//   - []*domain.Schedule: Matching schedules in creation order
+	//   - error: Returns error if schedules cannot be loaded
+	ListSchedules(ctx context.Context, filter domain.ScheduleFilter) ([]*domain.Schedule, error)
+
+	// PauseSchedule stops a schedule from starting tasks until it is resumed.
+	// Purpose: Temporarily suspends an automation without losing its definition.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - scheduleID: Unique identifier of the schedule
+	//   - userID: Unique identifier of the user pausing the schedule
+	// Outputs:
+	//   - *domain.Schedule: The PAUSED schedule
+	//   - error: Returns error if not found; wraps domain.ErrScheduleCompleted for finished one-shots
+	PauseSchedule(ctx context.Context, scheduleID string, userID string) (*domain.Schedule, error)
+
+	// ResumeSchedule reactivates a paused schedule.
+	// Purpose: Restarts an automation; runs that fell inside the pause are not caught up.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - scheduleID: Unique identifier of the schedule
+	//   - userID: Unique identifier of the user resuming the schedule
+	// Outputs:
+	//   - *domain.Schedule: The ACTIVE schedule with a recomputed NextRunAt
+	//   - error: Returns error if not found; wraps domain.ErrScheduleCompleted for finished one-shots
+	ResumeSchedule(ctx context.Context, scheduleID string, userID string) (*domain.Schedule, error)
+
+	// DeleteSchedule removes a schedule permanently.
+	// Purpose: Ends an automation; tasks it already started keep running.
+	// Inputs:
+	//   - ctx: Context for cancellation and timeout control
+	//   - scheduleID: Unique identifier of the schedule
+	//   - userID: Unique identifier of the user deleting the schedule
 	// Outputs:
-	//   - error: Returns error if task/step not found, already processed, or unauthorized
-	HandleApproval(ctx context.Context, taskID string, stepID string, approved bool, userID string) error
+	//   - error: Returns error if the schedule is not found or cannot be deleted
+	DeleteSchedule(ctx context.Context, scheduleID string, userID string) error
 }
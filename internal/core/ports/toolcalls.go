@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// ToolInvoker runs tools on behalf of the step executing in a context.
+// The orchestrator attaches one to the context of every step attempt. It enforces the
+// AllowedTools of the task's agent and records each call with the task, step, agent and
+// attempt it belongs to.
+type ToolInvoker interface {
+	// InvokeTool runs a tool with the given input and records the call.
+	// Purpose: Keeps the exact inputs and outputs of tools for debugging misbehaving agents.
+	//          A tool outside the agent's AllowedTools is not run; the call is recorded as REJECTED.
+	// Inputs:
+	//   - ctx: Context of the step attempt
+	//   - tool: The tool to run
+	//   - input: Input payload passed to the tool
+	// Outputs:
+	//   - string: Output of the tool
+	//   - error: The tool's error, or an error wrapping domain.ErrToolNotAllowed and
+	//            domain.ErrPermanent if the agent may not use the tool
+	InvokeTool(ctx context.Context, tool domain.Tool, input string) (string, error)
+}
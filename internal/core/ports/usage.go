@@ -0,0 +1,27 @@
+package ports
+
+import "github.com/JAROBOTAI/jaro/internal/core/domain"
+
+// UsageMeter collects the token usage of LLM calls made on behalf of a task.
+// The orchestrator attaches one to the context of every Planner, Executor, Verifier,
+// IntentNormalizer and AgentRouter call and accounts what it collected afterwards.
+type UsageMeter interface {
+	// RecordUsage adds the usage of one LLM call.
+	// Purpose: Attributes token usage to the task, step and phase the meter belongs to.
+	// Inputs:
+	//   - usage: Model and prompt/completion tokens of the call
+	// Outputs: None
+	RecordUsage(usage domain.TokenUsage)
+}
+
+// LLMStreamListener receives the text of LLM calls made on behalf of a task while it is generated.
+// The orchestrator attaches one next to every usage meter and publishes the fragments as
+// LLM_TOKEN_DELTA task events.
+type LLMStreamListener interface {
+	// RecordDelta receives one fragment of generated text.
+	// Purpose: Lets clients follow what the agent is "thinking" live.
+	// Inputs:
+	//   - delta: The next fragment of the response text
+	// Outputs: None
+	RecordDelta(delta string)
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// defaultAgentDescription describes the agent backed by the orchestrator's own components.
+const defaultAgentDescription = "General-purpose agent handling tasks no specialized agent was chosen for"
+
+// builtinAgent is the ports.Agent backed by the orchestrator's own components.
+type builtinAgent struct {
+	profile  domain.AgentProfile
+	planner  ports.Planner
+	executor ports.Executor
+}
+
+// Profile implements ports.Agent.
+func (a *builtinAgent) Profile() domain.AgentProfile { return a.profile }
+
+// Planner implements ports.Agent.
+func (a *builtinAgent) Planner() ports.Planner { return a.planner }
+
+// Executor implements ports.Agent.
+func (a *builtinAgent) Executor() ports.Executor { return a.executor }
+
+// defaultAgent returns the agent backed by the orchestrator's own Planner and Executor.
+// It may use every registered tool.
+func (s *OrchestratorService) defaultAgent() ports.Agent {
+	return &builtinAgent{
+		profile: domain.AgentProfile{
+			Name:         domain.DefaultAgentName,
+			Description:  defaultAgentDescription,
+			AllowedTools: []string{domain.AllToolsAllowed},
+		},
+		planner:  s.planner,
+		executor: s.executor,
+	}
+}
+
+// resolveAgent looks up an agent by name.
+// Purpose: Maps Task.TargetAgent to its components; an empty name or DefaultAgentName
+//          selects the default agent.
+// Inputs:
+//   - name: Agent name (matched case-insensitively)
+// Outputs:
+//   - ports.Agent: The agent handling tasks with that TargetAgent
+//   - error: Returns error wrapping domain.ErrUnknownAgent if no such agent is registered
+func (s *OrchestratorService) resolveAgent(name string) (ports.Agent, error) {
+	name = domain.NormalizeAgentName(name)
+	if name == "" || name == domain.DefaultAgentName {
+		return s.defaultAgent(), nil
+	}
+	if s.agents == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnknownAgent, name)
+	}
+
+	return s.agents.GetAgent(name)
+}
+
+// agentTools returns the tool catalogue an agent may plan with.
+func (s *OrchestratorService) agentTools(agent ports.Agent) []domain.ToolMetadata {
+	profile := agent.Profile()
+	return profile.FilterTools(s.availableTools())
+}
+
+// routeTask settles which agent handles a task before it is planned.
+// Purpose: Keeps an agent named at creation (EXPLICIT) and otherwise asks the AgentRouter
+//          to classify the input (CLASSIFIED). Without a router, registered agents or a
+//          usable answer, the task goes to the default agent (DEFAULT). The decision is
+//          stored in Task.TargetAgent and Metadata["routing_method"] and audited as
+//          TASK_ROUTED; tasks that were already routed (e.g., on recovery) are left alone.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task to route (mutated in place)
+// Outputs:
+//   - error: Returns error if the task could not be persisted,
+//            or the interruption cause if CancelTask or the deadline stopped routing
+func (s *OrchestratorService) routeTask(ctx context.Context, task *domain.Task) error {
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]string)
+	}
+	if task.Metadata["routing_method"] != "" {
+		return nil
+	}
+
+	route := &domain.AgentRoute{Agent: task.TargetAgent, Reason: "agent named in the request"}
+	method := domain.RoutingExplicit
+	if task.TargetAgent == "" {
+		route, method = s.classifyTask(ctx, task)
+		if isInterrupted(ctx) {
+			return context.Cause(ctx)
+		}
+	}
+
+	task.TargetAgent = route.Agent
+	task.Metadata["routing_method"] = method
+	task.UpdatedAt = s.clock.Now()
+	if err := s.repo.SaveTask(ctx, task); err != nil {
+		return fmt.Errorf("failed to save routed task: %w", err)
+	}
+
+	s.recordEvent(ctx, task, "TASK_ROUTED", systemActor, map[string]interface{}{
+		"task_id": task.ID,
+		"agent":   route.Agent,
+		"method":  method,
+		"reason":  route.Reason,
+	})
+
+	return nil
+}
+
+// classifyTask asks the AgentRouter for an agent and falls back to the default agent.
+// Routing errors are logged, not returned: an unroutable task is still handled by CORE.
+func (s *OrchestratorService) classifyTask(ctx context.Context, task *domain.Task) (*domain.AgentRoute, string) {
+	fallback := func(reason string) (*domain.AgentRoute, string) {
+		return &domain.AgentRoute{Agent: domain.DefaultAgentName, Reason: reason}, domain.RoutingDefault
+	}
+
+	if s.router == nil || s.agents == nil {
+		return fallback("no agent router configured")
+	}
+	candidates := s.agents.ListAgents()
+	if len(candidates) == 0 {
+		return fallback("no specialized agents registered")
+	}
+
+	routeCtx, meter := metered(s.streaming(ctx, task, "", domain.UsagePhaseRouting))
+	route, err := s.router.Route(routeCtx, task, candidates)
+	s.chargeUsage(ctx, task, "", domain.UsagePhaseRouting, meter)
+	if err != nil {
+		s.logger.Warn("agent routing failed", map[string]interface{}{
+			"error":   err.Error(),
+			"task_id": task.ID,
+		})
+		return fallback(fmt.Sprintf("routing failed: %v", err))
+	}
+	if route == nil || route.Agent == "" {
+		reason := "no agent fits the input"
+		if route != nil && route.Reason != "" {
+			reason = route.Reason
+		}
+		return fallback(reason)
+	}
+
+	agent, err := s.resolveAgent(route.Agent)
+	if err != nil {
+		return fallback(fmt.Sprintf("router chose an unavailable agent: %v", err))
+	}
+
+	return &domain.AgentRoute{Agent: agent.Profile().Name, Reason: route.Reason}, domain.RoutingClassified
+}
+
+// ListAgents returns the profiles of every agent tasks can be routed to.
+// Purpose: Agent discovery for clients choosing TaskOptions.TargetAgent.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (unused)
+// Outputs:
+//   - []domain.AgentProfile: The default agent followed by the registered agents by name
+func (s *OrchestratorService) ListAgents(ctx context.Context) []domain.AgentProfile {
+	profiles := []domain.AgentProfile{s.defaultAgent().Profile()}
+	if s.agents != nil {
+		profiles = append(profiles, s.agents.ListAgents()...)
+	}
+	return profiles
+}
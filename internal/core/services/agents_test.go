@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JAROBOTAI/jaro/internal/adapters/memory"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// withAgents registers a RESEARCH agent that may only call the "search" tool and routes
+// tasks by keyword.
+func withAgents(t *testing.T, planner fixedPlanner, executor ports.Executor) harnessOption {
+	t.Helper()
+	registry := memory.NewAgentRegistry()
+	profile := domain.AgentProfile{
+		Name:         "research",
+		Description:  "Finds and cites sources",
+		AllowedTools: []string{"search"},
+		Keywords:     []string{"research", "paper"},
+	}
+	if err := registry.Register(profile, planner, executor); err != nil {
+		t.Fatalf("Register = %v", err)
+	}
+	return func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		deps.Agents = registry
+		deps.Router = memory.NewKeywordRouter()
+	}
+}
+
+func TestOrchestratorRoutesTasksToAgents(t *testing.T) {
+	toolStep := func(tool string) []domain.Step {
+		return []domain.Step{{ID: "lookup", Type: domain.StepTypeToolCall, Status: domain.StepStatusPending, ToolName: tool, ToolInput: "query"}}
+	}
+
+	tests := []struct {
+		name        string
+		input       string
+		targetAgent string
+		tool        string
+		wantAgent   string
+		wantMethod  string
+		wantStatus  domain.TaskStatus
+		wantCalls   int    // Calls of the RESEARCH agent's executor
+		wantCode    string // ErrorCode of step lookup
+	}{
+		{
+			name:       "keyword match runs an allowed tool",
+			input:      "research the topic",
+			tool:       "search",
+			wantAgent:  "RESEARCH",
+			wantMethod: domain.RoutingClassified,
+			wantStatus: domain.TaskStatusDone,
+			wantCalls:  1,
+		},
+		{
+			name:       "disallowed tool is rejected without running",
+			input:      "summarize this paper",
+			tool:       "shell",
+			wantAgent:  "RESEARCH",
+			wantMethod: domain.RoutingClassified,
+			wantStatus: domain.TaskStatusFailed,
+			wantCalls:  0,
+			wantCode:   domain.ErrorCodeToolNotAllowed,
+		},
+		{
+			name:        "explicit agent skips routing",
+			input:       "bake bread",
+			targetAgent: " Research ",
+			tool:        "search",
+			wantAgent:   "RESEARCH",
+			wantMethod:  domain.RoutingExplicit,
+			wantStatus:  domain.TaskStatusDone,
+			wantCalls:   1,
+		},
+		{
+			name:       "no keyword match goes to the default agent",
+			input:      "bake bread",
+			tool:       "shell",
+			wantAgent:  domain.DefaultAgentName,
+			wantMethod: domain.RoutingDefault,
+			wantStatus: domain.TaskStatusDone,
+			wantCalls:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentExecutor := newScriptedExecutor()
+			defaultExecutor := newScriptedExecutor()
+			h := newHarness(t, toolStep(tt.tool), defaultExecutor, withAgents(t, fixedPlanner{steps: toolStep(tt.tool)}, agentExecutor))
+
+			task, err := h.orchestrator.StartTask(context.Background(), tt.input, "alice", domain.TaskOptions{TargetAgent: tt.targetAgent})
+			if err != nil {
+				t.Fatalf("StartTask = %v", err)
+			}
+			done := h.waitForStatus(t, task.ID, tt.wantStatus)
+
+			if done.TargetAgent != tt.wantAgent || done.Metadata["routing_method"] != tt.wantMethod {
+				t.Errorf("routed to (%s, %s), want (%s, %s)", done.TargetAgent, done.Metadata["routing_method"], tt.wantAgent, tt.wantMethod)
+			}
+			if n := h.audit.count("TASK_ROUTED"); n != 1 {
+				t.Errorf("TASK_ROUTED events = %d, want 1", n)
+			}
+			if n := agentExecutor.callCount("lookup"); n != tt.wantCalls {
+				t.Errorf("agent executor ran lookup %d times, want %d", n, tt.wantCalls)
+			}
+			if tt.wantAgent == domain.DefaultAgentName && defaultExecutor.callCount("lookup") != 1 {
+				t.Errorf("default executor ran lookup %d times, want 1", defaultExecutor.callCount("lookup"))
+			}
+			if result := h.stepResult(t, task.ID, "lookup"); result.ErrorCode != tt.wantCode {
+				t.Errorf("step lookup error_code = %q, want %q", result.ErrorCode, tt.wantCode)
+			}
+		})
+	}
+}
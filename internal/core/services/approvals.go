@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// findStepApproval returns the most recent approval request for a step, if any.
+// Purpose: Lets the execution loop decide whether a gated step may run.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task
+//   - stepID: Unique identifier of the gated step
+// Outputs:
+//   - *domain.ApprovalRequest: Latest approval for the step, or nil if none exists
+//   - error: Returns error if approvals could not be loaded
+func (s *OrchestratorService) findStepApproval(ctx context.Context, taskID string, stepID string) (*domain.ApprovalRequest, error) {
+	approvals, err := s.approvals.ListTaskApprovals(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load approvals: %w", err)
+	}
+
+	var latest *domain.ApprovalRequest
+	for _, approval := range approvals {
+		if approval.StepID == stepID {
+			latest = approval
+		}
+	}
+
+	return latest, nil
+}
+
+// requestApproval suspends a task on a gated step until a human decides.
+// Purpose: Persists an OPEN ApprovalRequest (reusing an existing open one), points
+//          CurrentStepID at the step and moves the task to WAITING_APPROVAL.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task to suspend (mutated in place)
+//   - step: The step awaiting approval
+// Outputs:
+//   - error: Returns error if approval or task state could not be persisted
+func (s *OrchestratorService) requestApproval(ctx context.Context, task *domain.Task, step *domain.Step) error {
+	approval, err := s.findStepApproval(ctx, task.ID, step.ID)
+	if err != nil {
+		return err
+	}
+
+	if approval == nil || approval.Status != domain.ApprovalStatusOpen {
+		approval = &domain.ApprovalRequest{
+			ID:            s.idGen.Generate(),
+			TaskID:        task.ID,
+			StepID:        step.ID,
+			UserID:        task.UserID,
+			ActionSummary: approvalActionSummary(step),
+			RiskReason:    approvalRiskReason(step),
+			Status:        domain.ApprovalStatusOpen,
+			CreatedAt:     s.clock.Now(),
+		}
+		if err := s.approvals.SaveApproval(ctx, approval); err != nil {
+			return fmt.Errorf("failed to save approval request: %w", err)
+		}
+	}
+
+	// Recovered tasks whose request was lost are already WAITING_APPROVAL
+	if task.Status != domain.TaskStatusWaitingApproval {
+		task.CurrentStepID = step.ID
+		if err := s.setTaskStatus(ctx, task, domain.TaskStatusWaitingApproval); err != nil {
+			return err
+		}
+	}
+
+	s.recordEvent(ctx, task, "APPROVAL_REQUESTED", systemActor, map[string]interface{}{
+		"approval_id":    approval.ID,
+		"step_id":        step.ID,
+		"action_summary": approval.ActionSummary,
+		"risk_reason":    approval.RiskReason,
+	})
+
+	return nil
+}
+
+// completeApprovalGate marks an approved APPROVAL_GATE step as completed.
+// Purpose: Approval gates have no executable work; the human decision is their result.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The parent task
+//   - planID: Unique identifier of the plan owning the step
+//   - step: The approved gate step (Status is updated in place)
+//   - approval: The approved request (used for the step output)
+// Outputs:
+//   - error: Returns error if step state could not be persisted
+func (s *OrchestratorService) completeApprovalGate(ctx context.Context, task *domain.Task, planID string, step *domain.Step, approval *domain.ApprovalRequest) error {
+	result := &domain.StepResult{
+		StepID:  step.ID,
+		Success: true,
+		Output:  fmt.Sprintf("approved by %s", approval.ApprovedBy),
+	}
+	if err := s.plans.SaveStepResult(ctx, planID, result); err != nil {
+		return fmt.Errorf("failed to save result of step %s: %w", step.ID, err)
+	}
+
+	if err := s.setStepStatus(ctx, task, planID, step, domain.StepStatusCompleted); err != nil {
+		return err
+	}
+
+	s.recordEvent(ctx, task, "STEP_COMPLETED", systemActor, map[string]interface{}{
+		"step_id":     step.ID,
+		"approval_id": approval.ID,
+		"approved_by": approval.ApprovedBy,
+	})
+
+	return nil
+}
+
+// applyApprovalDecision carries out a recorded approval decision before the task resumes.
+// Purpose: A rejection fails the step and cancels the task (FAIL_TASK) or skips the step
+//          (SKIP_STEP); an approved step is left for executePlan, which runs it.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The suspended task (mutated in place)
+//   - plan: The task's current plan
+//   - step: The gated step the decision applies to
+//   - approval: The decided approval request
+// Outputs:
+//   - bool: True if the plan should be resumed, false if the task was canceled
+//   - error: Returns error if task or plan state could not be persisted
+func (s *OrchestratorService) applyApprovalDecision(ctx context.Context, task *domain.Task, plan *domain.Plan, step *domain.Step, approval *domain.ApprovalRequest) (bool, error) {
+	if approval.Status != domain.ApprovalStatusRejected {
+		return true, nil
+	}
+
+	if s.cfg.RejectionPolicy != domain.RejectionPolicySkipStep {
+		if err := s.setStepStatus(ctx, task, plan.ID, step, domain.StepStatusFailed); err != nil {
+			return false, err
+		}
+		return false, s.finishTask(ctx, task, domain.TaskStatusCanceled,
+			fmt.Sprintf("approval for step %s rejected by %s", step.ID, approval.ApprovedBy))
+	}
+
+	if step.Status != domain.StepStatusSkipped {
+		if err := s.setStepStatus(ctx, task, plan.ID, step, domain.StepStatusSkipped); err != nil {
+			return false, err
+		}
+		s.recordEvent(ctx, task, "STEP_SKIPPED", approval.ApprovedBy, map[string]interface{}{
+			"step_id": step.ID,
+			"reason":  "approval rejected",
+		})
+	}
+
+	return true, nil
+}
+
+// resumeApproval continues a task in WAITING_APPROVAL once its approval was decided.
+// Purpose: Called by RunTask when a task queued by HandleApproval or crash recovery is
+//          dequeued. The decision is applied and the task claimed by moving it back to
+//          EXECUTING under approvalMu, so a task queued twice runs only once.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (owned by the worker)
+//   - task: The suspended task (refreshed and mutated in place)
+// Outputs:
+//   - error: Returns error if task, plan or approval state could not be loaded or persisted
+func (s *OrchestratorService) resumeApproval(ctx context.Context, task *domain.Task) error {
+	s.approvalMu.Lock()
+	latest, err := s.repo.GetTask(ctx, task.ID)
+	if err != nil {
+		s.approvalMu.Unlock()
+		return fmt.Errorf("failed to reload task: %w", err)
+	}
+	*task = *latest
+	if task.Status != domain.TaskStatusWaitingApproval || s.isRunning(task.ID) {
+		s.approvalMu.Unlock()
+		return nil
+	}
+
+	approval, err := s.findStepApproval(ctx, task.ID, task.CurrentStepID)
+	if err != nil || approval == nil || approval.Status == domain.ApprovalStatusOpen {
+		s.approvalMu.Unlock()
+		return err
+	}
+
+	plan, err := s.plans.GetPlan(ctx, task.PlanID)
+	if err != nil {
+		s.approvalMu.Unlock()
+		return fmt.Errorf("failed to load plan: %w", err)
+	}
+	step := findStep(plan, task.CurrentStepID)
+	if step == nil {
+		s.approvalMu.Unlock()
+		return fmt.Errorf("step not found in plan: %s", task.CurrentStepID)
+	}
+
+	resume, err := s.applyApprovalDecision(ctx, task, plan, step, approval)
+	if err != nil || !resume {
+		s.approvalMu.Unlock()
+		return err
+	}
+	if err := s.setTaskStatus(ctx, task, domain.TaskStatusExecuting); err != nil {
+		s.approvalMu.Unlock()
+		return err
+	}
+	s.approvalMu.Unlock()
+
+	// Resume execution from the decided step
+	return s.runExecution(ctx, task, func(execCtx context.Context) error {
+		return s.executePlan(execCtx, task, plan)
+	})
+}
+
+// approvalActionSummary describes what a gated step is about to do.
+func approvalActionSummary(step *domain.Step) string {
+	if step.ToolName != "" {
+		return fmt.Sprintf("%s (tool %s, input: %s)", step.Title, step.ToolName, step.ToolInput)
+	}
+	if step.Description != "" {
+		return fmt.Sprintf("%s: %s", step.Title, step.Description)
+	}
+	return step.Title
+}
+
+// approvalRiskReason explains why a step was gated.
+func approvalRiskReason(step *domain.Step) string {
+	switch {
+	case step.Type == domain.StepTypeApprovalGate:
+		return "plan contains an explicit approval gate"
+	case step.RiskLevel == domain.RiskLevelHigh:
+		return "step is classified as HIGH risk"
+	default:
+		return "step requires approval"
+	}
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// defaultArtifactContentType is stored for artifacts whose content type is unknown.
+const defaultArtifactContentType = "application/octet-stream"
+
+// AddArtifact stores a file as an artifact of a task.
+// Purpose: Attaches user uploads (e.g., PDFs) and agent outputs (e.g., CSV reports) to a task.
+//          An artifact with the same name is replaced. Artifacts can be added in any task
+//          status, so results can still be attached after a task finished.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task
+//   - name: Artifact name (a plain file name, see domain.ValidateArtifactName)
+//   - contentType: MIME type of the content (empty stores application/octet-stream)
+//   - content: The artifact content, streamed to the store
+//   - userID: Unique identifier of the uploading user or producing agent
+// Outputs:
+//   - *domain.Artifact: The stored artifact including size and checksum
+//   - error: Returns error if the task is not found, the name is invalid (wraps
+//            domain.ErrInvalidArtifactName), artifacts are not configured or storage fails
+func (s *OrchestratorService) AddArtifact(ctx context.Context, taskID string, name string, contentType string, content io.Reader, userID string) (*domain.Artifact, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("taskID cannot be empty")
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("userID cannot be empty")
+	}
+	if err := domain.ValidateArtifactName(name); err != nil {
+		return nil, err
+	}
+	if s.artifacts == nil {
+		return nil, fmt.Errorf("artifact storage is not configured")
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+
+	if contentType == "" {
+		contentType = defaultArtifactContentType
+	}
+	artifact, err := s.artifacts.PutArtifact(ctx, &domain.Artifact{
+		TaskID:      taskID,
+		Name:        name,
+		ContentType: contentType,
+		CreatedBy:   userID,
+		CreatedAt:   s.clock.Now(),
+	}, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+
+	s.recordEvent(ctx, task, "ARTIFACT_STORED", userID, map[string]interface{}{
+		"task_id":      taskID,
+		"name":         artifact.Name,
+		"content_type": artifact.ContentType,
+		"size":         artifact.Size,
+		"checksum":     artifact.Checksum,
+	})
+
+	return artifact, nil
+}
+
+// GetArtifact opens an artifact of a task for reading.
+// Purpose: Streams artifact downloads.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task
+//   - name: Name of the artifact
+// Outputs:
+//   - *domain.Artifact: Metadata of the artifact
+//   - io.ReadCloser: The content; the caller must close it
+//   - error: Returns error if the task is not found, artifacts are not configured or the
+//            artifact does not exist (wraps domain.ErrArtifactNotFound)
+func (s *OrchestratorService) GetArtifact(ctx context.Context, taskID string, name string) (*domain.Artifact, io.ReadCloser, error) {
+	if taskID == "" {
+		return nil, nil, fmt.Errorf("taskID cannot be empty")
+	}
+	if err := domain.ValidateArtifactName(name); err != nil {
+		return nil, nil, err
+	}
+	if s.artifacts == nil {
+		return nil, nil, fmt.Errorf("artifact storage is not configured")
+	}
+	if _, err := s.repo.GetTask(ctx, taskID); err != nil {
+		return nil, nil, fmt.Errorf("failed to load task: %w", err)
+	}
+
+	artifact, content, err := s.artifacts.GetArtifact(ctx, taskID, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get artifact: %w", err)
+	}
+
+	return artifact, content, nil
+}
+
+// ListArtifacts returns the artifacts of a task.
+// Purpose: Shows which files are attached to a task with their types, sizes and checksums.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task
+// Outputs:
+//   - []*domain.Artifact: Artifacts sorted by name (empty if none or artifacts are not configured)
+//   - error: Returns error if the task is not found or artifacts cannot be listed
+func (s *OrchestratorService) ListArtifacts(ctx context.Context, taskID string) ([]*domain.Artifact, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("taskID cannot be empty")
+	}
+	if _, err := s.repo.GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+	if s.artifacts == nil {
+		return []*domain.Artifact{}, nil
+	}
+
+	artifacts, err := s.artifacts.ListArtifacts(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	return artifacts, nil
+}
+
+// DeleteArtifact removes an artifact from a task.
+// Purpose: Lets users withdraw attachments that are outdated or were uploaded by mistake.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task
+//   - name: Name of the artifact
+//   - userID: Unique identifier of the user deleting the artifact
+// Outputs:
+//   - error: Returns error if the task is not found, artifacts are not configured or the
+//            artifact does not exist (wraps domain.ErrArtifactNotFound)
+func (s *OrchestratorService) DeleteArtifact(ctx context.Context, taskID string, name string, userID string) error {
+	if taskID == "" {
+		return fmt.Errorf("taskID cannot be empty")
+	}
+	if userID == "" {
+		return fmt.Errorf("userID cannot be empty")
+	}
+	if err := domain.ValidateArtifactName(name); err != nil {
+		return err
+	}
+	if s.artifacts == nil {
+		return fmt.Errorf("artifact storage is not configured")
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to load task: %w", err)
+	}
+
+	if err := s.artifacts.DeleteArtifact(ctx, taskID, name); err != nil {
+		return fmt.Errorf("failed to delete artifact: %w", err)
+	}
+
+	s.recordEvent(ctx, task, "ARTIFACT_DELETED", userID, map[string]interface{}{
+		"task_id": taskID,
+		"name":    name,
+	})
+
+	return nil
+}
+
+// applyArtifacts sets Task.Artifacts to the name and checksum of every stored artifact.
+// The store is the source of truth; failures to read it leave the task's artifacts unchanged.
+func (s *OrchestratorService) applyArtifacts(ctx context.Context, task *domain.Task) {
+	if s.artifacts == nil {
+		return
+	}
+
+	artifacts, err := s.artifacts.ListArtifacts(ctx, task.ID)
+	if err != nil {
+		s.logger.Warn("failed to load task artifacts", map[string]interface{}{
+			"error":   err.Error(),
+			"task_id": task.ID,
+		})
+		return
+	}
+
+	task.Artifacts = make(map[string]string, len(artifacts))
+	for _, artifact := range artifacts {
+		task.Artifacts[artifact.Name] = artifact.Checksum
+	}
+}
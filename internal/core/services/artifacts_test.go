@@ -0,0 +1,96 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JAROBOTAI/jaro/internal/adapters/filesystem"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// withArtifacts stores artifacts in a temporary directory.
+func withArtifacts(t *testing.T) harnessOption {
+	t.Helper()
+	store, err := filesystem.NewArtifactStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewArtifactStore = %v", err)
+	}
+	return func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		deps.Artifacts = store
+	}
+}
+
+func TestOrchestratorAddsListsAndDeletesArtifacts(t *testing.T) {
+	h := newHarness(t, thinkStep("a"), newScriptedExecutor(), withArtifacts(t))
+	ctx := context.Background()
+
+	task, err := h.orchestrator.StartTask(ctx, "summarize the attachments", "alice", domain.TaskOptions{})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	h.waitForStatus(t, task.ID, domain.TaskStatusDone)
+
+	// Artifacts can still be attached to a finished task
+	report, err := h.orchestrator.AddArtifact(ctx, task.ID, "report.csv", "text/csv", strings.NewReader("a,b\n"), "alice")
+	if err != nil {
+		t.Fatalf("AddArtifact = %v", err)
+	}
+	blob, err := h.orchestrator.AddArtifact(ctx, task.ID, "blob.bin", "", strings.NewReader("\x00\x01"), "alice")
+	if err != nil {
+		t.Fatalf("AddArtifact = %v", err)
+	}
+	if blob.ContentType != "application/octet-stream" || blob.CreatedBy != "alice" || !blob.CreatedAt.Equal(h.clock.Now()) {
+		t.Errorf("blob = %+v, want the default type, the uploader and the clock's time", blob)
+	}
+
+	for _, name := range []string{"../escape", "/etc/passwd", "a\x00b", ".hidden"} {
+		if _, err := h.orchestrator.AddArtifact(ctx, task.ID, name, "", strings.NewReader("x"), "alice"); !errors.Is(err, domain.ErrInvalidArtifactName) {
+			t.Errorf("AddArtifact(%q) = %v, want ErrInvalidArtifactName", name, err)
+		}
+	}
+	if _, err := h.orchestrator.AddArtifact(ctx, "missing", "report.csv", "", strings.NewReader("x"), "alice"); err == nil {
+		t.Error("AddArtifact to an unknown task succeeded")
+	}
+
+	artifacts, err := h.orchestrator.ListArtifacts(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("ListArtifacts = %v", err)
+	}
+	if len(artifacts) != 2 || artifacts[0].Name != "blob.bin" || artifacts[1].Name != "report.csv" {
+		t.Fatalf("ListArtifacts = %+v, want blob.bin and report.csv", artifacts)
+	}
+	got, err := h.orchestrator.GetTaskStatus(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetTaskStatus = %v", err)
+	}
+	if len(got.Artifacts) != 2 || got.Artifacts["report.csv"] != report.Checksum {
+		t.Errorf("Task.Artifacts = %v, want both artifacts by checksum", got.Artifacts)
+	}
+
+	if err := h.orchestrator.DeleteArtifact(ctx, task.ID, "report.csv", "alice"); err != nil {
+		t.Fatalf("DeleteArtifact = %v", err)
+	}
+	if err := h.orchestrator.DeleteArtifact(ctx, task.ID, "report.csv", "alice"); !errors.Is(err, domain.ErrArtifactNotFound) {
+		t.Errorf("second DeleteArtifact = %v, want ErrArtifactNotFound", err)
+	}
+	if _, _, err := h.orchestrator.GetArtifact(ctx, task.ID, "report.csv"); !errors.Is(err, domain.ErrArtifactNotFound) {
+		t.Errorf("GetArtifact of a deleted artifact = %v, want ErrArtifactNotFound", err)
+	}
+	got, err = h.orchestrator.GetTaskStatus(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetTaskStatus = %v", err)
+	}
+	if _, ok := got.Artifacts["report.csv"]; ok || len(got.Artifacts) != 1 {
+		t.Errorf("Task.Artifacts = %v, want only blob.bin", got.Artifacts)
+	}
+
+	if n := h.audit.count("ARTIFACT_STORED"); n != 2 {
+		t.Errorf("ARTIFACT_STORED events = %d, want 2", n)
+	}
+	if n := h.audit.count("ARTIFACT_DELETED"); n != 1 {
+		t.Errorf("ARTIFACT_DELETED events = %d, want 1", n)
+	}
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// takeBranch routes execution after a DECISION step has completed.
+// Purpose: Marks every step that is only reachable through untaken branches SKIPPED and
+//          emits BRANCH_TAKEN plus one STEP_SKIPPED event per skipped step. Steps of the
+//          taken branch become ready through their dependency on the decision.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The parent task
+//   - plan: The plan owning the decision (step statuses are updated in place)
+//   - decision: The completed DECISION step
+//   - branch: Index of the branch selected by domain.Step.SelectBranch
+// Outputs:
+//   - error: Returns error if step state could not be persisted
+func (s *OrchestratorService) takeBranch(ctx context.Context, task *domain.Task, plan *domain.Plan, decision *domain.Step, branch int) error {
+	skipped := plan.UntakenBranchSteps(decision.ID, branch)
+
+	s.recordEvent(ctx, task, "BRANCH_TAKEN", systemActor, map[string]interface{}{
+		"step_id":       decision.ID,
+		"condition":     decision.Branches[branch].Condition,
+		"next":          decision.Branches[branch].Next,
+		"skipped_steps": skipped,
+	})
+
+	for _, id := range skipped {
+		step := findStep(plan, id)
+		if step == nil {
+			continue
+		}
+		if err := s.setStepStatus(ctx, task, plan.ID, step, domain.StepStatusSkipped); err != nil {
+			return err
+		}
+		s.recordEvent(ctx, task, "STEP_SKIPPED", systemActor, map[string]interface{}{
+			"step_id":     id,
+			"decision_id": decision.ID,
+			"reason":      "branch not taken",
+		})
+	}
+
+	return nil
+}
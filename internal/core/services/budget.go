@@ -0,0 +1,412 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// budgetExceeded checks the spend of a task and its user against their budgets.
+// Purpose: Called before each LLM-consuming phase: planning, every executed or VERIFY step and
+//          the final verification. The task's Budget is compared with the usage ledger of the
+//          task and all of its sub-tasks (see taskTreeUsage), the user's budget (or
+//          cfg.DefaultUserBudget) with the user's spend in the current UTC day and month.
+//          Nothing is enforced without a usage repository. Ledger failures are
+//          logged and the check passes, so an accounting outage cannot stall every task.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task about to consume LLM tokens
+// Outputs:
+//   - string: Why the task must pause, or "" if it may continue
+func (s *OrchestratorService) budgetExceeded(ctx context.Context, task *domain.Task) string {
+	if s.usage == nil {
+		return ""
+	}
+
+	if !task.Budget.IsZero() {
+		spent, err := s.taskTreeUsage(ctx, task)
+		if err != nil {
+			s.logger.Warn("failed to check task budget", map[string]interface{}{
+				"error":   err.Error(),
+				"task_id": task.ID,
+			})
+		} else if reason := task.Budget.Exceeded(spent); reason != "" {
+			return "task budget exceeded: " + reason
+		}
+	}
+
+	budget, _, err := s.userBudget(ctx, task.UserID)
+	if err == nil && budget.Daily.IsZero() && budget.Monthly.IsZero() {
+		return ""
+	}
+	var status *domain.BudgetStatus
+	if err == nil {
+		status, err = s.budgetStatus(ctx, task.UserID)
+	}
+	if err != nil {
+		s.logger.Warn("failed to check user budget", map[string]interface{}{
+			"error":   err.Error(),
+			"task_id": task.ID,
+			"user_id": task.UserID,
+		})
+		return ""
+	}
+
+	if reason := status.Daily.Exceeded(status.DailySpent); reason != "" {
+		return fmt.Sprintf("daily budget of user %s exceeded: %s", task.UserID, reason)
+	}
+	if reason := status.Monthly.Exceeded(status.MonthlySpent); reason != "" {
+		return fmt.Sprintf("monthly budget of user %s exceeded: %s", task.UserID, reason)
+	}
+	return ""
+}
+
+// taskTreeUsage returns the usage of a task together with that of all its sub-tasks.
+// Children spend on behalf of their parent, so the parent's Budget covers the whole tree.
+func (s *OrchestratorService) taskTreeUsage(ctx context.Context, task *domain.Task) (domain.UsageTotals, error) {
+	records := make([]*domain.UsageRecord, 0)
+	level := []string{task.ID}
+	for depth := 0; len(level) > 0 && depth <= s.cfg.MaxSubTaskDepth; depth++ {
+		next := make([]string, 0)
+		for _, taskID := range level {
+			usage, err := s.usage.ListUsage(ctx, domain.UsageFilter{TaskID: taskID})
+			if err != nil {
+				return domain.UsageTotals{}, fmt.Errorf("failed to load usage of task %s: %w", taskID, err)
+			}
+			records = append(records, usage...)
+
+			children, err := s.repo.ListTasks(ctx, domain.TaskFilter{ParentTaskID: taskID})
+			if err != nil {
+				return domain.UsageTotals{}, fmt.Errorf("failed to list sub-tasks of task %s: %w", taskID, err)
+			}
+			for _, child := range children {
+				next = append(next, child.ID)
+			}
+		}
+		level = next
+	}
+
+	return domain.SummarizeUsage(records, domain.UsageFilter{}).UsageTotals, nil
+}
+
+// userBudget returns the budget stored for a user, or cfg.DefaultUserBudget (isDefault true).
+func (s *OrchestratorService) userBudget(ctx context.Context, userID string) (domain.UserBudget, bool, error) {
+	if s.budgets != nil {
+		stored, err := s.budgets.GetBudget(ctx, userID)
+		if err != nil {
+			return domain.UserBudget{}, false, fmt.Errorf("failed to load budget: %w", err)
+		}
+		if stored != nil {
+			return *stored, false, nil
+		}
+	}
+
+	budget := s.cfg.DefaultUserBudget
+	budget.UserID = userID
+	return budget, true, nil
+}
+
+// budgetStatus loads a user's budget with the spend of the current UTC day and month.
+func (s *OrchestratorService) budgetStatus(ctx context.Context, userID string) (*domain.BudgetStatus, error) {
+	budget, isDefault, err := s.userBudget(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dayStart, monthStart := domain.BudgetPeriods(s.clock.Now())
+	status := &domain.BudgetStatus{
+		UserBudget: budget,
+		IsDefault:  isDefault,
+		DayStart:   dayStart,
+		MonthStart: monthStart,
+	}
+	if s.usage == nil {
+		return status, nil
+	}
+
+	monthly := domain.UsageFilter{UserID: userID, From: monthStart}
+	records, err := s.usage.ListUsage(ctx, monthly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usage: %w", err)
+	}
+	status.MonthlySpent = domain.SummarizeUsage(records, monthly).UsageTotals
+	status.DailySpent = domain.SummarizeUsage(records, domain.UsageFilter{UserID: userID, From: dayStart}).UsageTotals
+
+	return status, nil
+}
+
+// pauseForBudget suspends a task that has reached its budget.
+// Purpose: Moves the task to BUDGET_EXCEEDED so it releases its worker, records the reason
+//          in Task.Metadata and emits BUDGET_EXCEEDED. The status change and a final budget
+//          check happen under budgetMu, the lock budget updates take, so a limit raised
+//          concurrently cannot be missed: the task is then queued again right away.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task to pause (mutated in place)
+//   - reason: Which budget was exceeded, as returned by budgetExceeded
+// Outputs:
+//   - error: Returns error if task state could not be persisted or the task could not be queued
+func (s *OrchestratorService) pauseForBudget(ctx context.Context, task *domain.Task, reason string) error {
+	s.budgetMu.Lock()
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]string)
+	}
+	task.Metadata[domain.MetadataBudgetExceeded] = reason
+	s.applyUsage(ctx, task)
+	if err := s.setTaskStatus(ctx, task, domain.TaskStatusBudgetExceeded); err != nil {
+		s.budgetMu.Unlock()
+		return err
+	}
+
+	s.recordEvent(ctx, task, "BUDGET_EXCEEDED", systemActor, map[string]interface{}{
+		"task_id":       task.ID,
+		"reason":        reason,
+		"usage_tokens":  task.UsageTokens,
+		"cost_estimate": task.CostEstimate,
+	})
+	s.logger.Warn("task paused over budget", map[string]interface{}{
+		"task_id": task.ID,
+		"user_id": task.UserID,
+		"reason":  reason,
+	})
+
+	withinBudget := s.budgetExceeded(ctx, task) == ""
+	s.budgetMu.Unlock()
+	if withinBudget {
+		return s.enqueueTask(ctx, task)
+	}
+
+	return nil
+}
+
+// resumeBudget continues a task in BUDGET_EXCEEDED after its budget was raised.
+// Purpose: Called by RunTask when a task queued by a budget update is dequeued. The task is
+//          claimed under budgetMu by moving it back to PLANNING (no plan yet) or EXECUTING,
+//          so a task queued twice runs only once. A task still over budget stays paused.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (owned by the worker)
+//   - task: The paused task (refreshed and mutated in place)
+// Outputs:
+//   - error: Returns error if task or plan state could not be loaded or persisted
+func (s *OrchestratorService) resumeBudget(ctx context.Context, task *domain.Task) error {
+	s.budgetMu.Lock()
+	latest, err := s.repo.GetTask(ctx, task.ID)
+	if err != nil {
+		s.budgetMu.Unlock()
+		return fmt.Errorf("failed to reload task: %w", err)
+	}
+	*task = *latest
+	if task.Status != domain.TaskStatusBudgetExceeded || s.isRunning(task.ID) || s.budgetExceeded(ctx, task) != "" {
+		s.budgetMu.Unlock()
+		return nil
+	}
+
+	var plan *domain.Plan
+	next := domain.TaskStatusPlanning
+	if task.PlanID != "" {
+		if plan, err = s.plans.GetPlan(ctx, task.PlanID); err != nil {
+			s.budgetMu.Unlock()
+			return fmt.Errorf("failed to load plan: %w", err)
+		}
+		next = domain.TaskStatusExecuting
+	}
+
+	previous := task.Metadata[domain.MetadataBudgetExceeded]
+	delete(task.Metadata, domain.MetadataBudgetExceeded)
+	if err := s.setTaskStatus(ctx, task, next); err != nil {
+		s.budgetMu.Unlock()
+		return err
+	}
+	s.budgetMu.Unlock()
+
+	s.recordEvent(ctx, task, "BUDGET_RESUMED", systemActor, map[string]interface{}{
+		"task_id":         task.ID,
+		"previous_reason": previous,
+		"status":          string(next),
+	})
+
+	return s.runExecution(ctx, task, func(execCtx context.Context) error {
+		if plan == nil {
+			return s.runTask(execCtx, task)
+		}
+		return s.executePlan(execCtx, task, plan)
+	})
+}
+
+// queueWithinBudget queues every paused task of a user whose budget now suffices.
+// The caller must hold budgetMu. Failures are logged: the budget update itself succeeded.
+func (s *OrchestratorService) queueWithinBudget(ctx context.Context, userID string) int {
+	tasks, err := s.repo.ListTasks(ctx, domain.TaskFilter{
+		UserID:   userID,
+		Statuses: []domain.TaskStatus{domain.TaskStatusBudgetExceeded},
+	})
+	if err != nil {
+		s.logger.Warn("failed to list tasks paused over budget", map[string]interface{}{
+			"error":   err.Error(),
+			"user_id": userID,
+		})
+		return 0
+	}
+
+	queued := 0
+	for _, task := range tasks {
+		if _, waiting := s.queue.Position(task.ID); waiting || s.budgetExceeded(ctx, task) != "" {
+			continue
+		}
+		if err := s.enqueueTask(ctx, task); err != nil {
+			s.logger.Warn("failed to queue task resumed within budget", map[string]interface{}{
+				"error":   err.Error(),
+				"task_id": task.ID,
+			})
+			continue
+		}
+		queued++
+	}
+
+	return queued
+}
+
+// checkBudgetAdmin rejects budget changes by actors missing from cfg.BudgetAdmins.
+func (s *OrchestratorService) checkBudgetAdmin(actorID string) error {
+	if !slices.Contains(s.cfg.BudgetAdmins, actorID) {
+		return fmt.Errorf("%w: %s may not change budgets", domain.ErrNotBudgetAdmin, actorID)
+	}
+	return nil
+}
+
+// SetUserBudget stores the daily and monthly caps of a user.
+// Purpose: Lets an administrator cap (or raise) what a user's tasks may spend on LLM calls.
+//          Paused tasks of the user that fit the new budget are queued to resume.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - budget: The user's limits (UserID required; zero limits are unlimited)
+//   - actorID: Unique identifier of the administrator making the change (must be listed
+//              in cfg.BudgetAdmins)
+// Outputs:
+//   - *domain.UserBudget: The stored budget
+//   - error: Returns error if the actor is not a budget administrator (wraps
+//            domain.ErrNotBudgetAdmin), no budget repository is configured, inputs are
+//            invalid (wraps domain.ErrInvalidBudget for negative limits) or persistence fails
+func (s *OrchestratorService) SetUserBudget(ctx context.Context, budget domain.UserBudget, actorID string) (*domain.UserBudget, error) {
+	if budget.UserID == "" {
+		return nil, fmt.Errorf("userID cannot be empty")
+	}
+	if actorID == "" {
+		return nil, fmt.Errorf("actorID cannot be empty")
+	}
+	if err := s.checkBudgetAdmin(actorID); err != nil {
+		return nil, err
+	}
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+	if s.budgets == nil {
+		return nil, fmt.Errorf("user budgets are not configured")
+	}
+
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+
+	budget.UpdatedAt = s.clock.Now()
+	budget.UpdatedBy = actorID
+	if err := s.budgets.SaveBudget(ctx, &budget); err != nil {
+		return nil, fmt.Errorf("failed to save budget: %w", err)
+	}
+
+	resumed := s.queueWithinBudget(ctx, budget.UserID)
+	s.logger.Info("user budget updated", map[string]interface{}{
+		"user_id":       budget.UserID,
+		"updated_by":    actorID,
+		"daily":         budget.Daily,
+		"monthly":       budget.Monthly,
+		"resumed_tasks": resumed,
+	})
+
+	return &budget, nil
+}
+
+// GetUserBudget returns a user's budget with the spend counted against it.
+// Purpose: Shows how much of the daily and monthly caps a user has used.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - userID: Unique identifier of the user
+// Outputs:
+//   - *domain.BudgetStatus: The effective budget (the default if none is stored) and spend
+//   - error: Returns error if the budget or usage cannot be loaded
+func (s *OrchestratorService) GetUserBudget(ctx context.Context, userID string) (*domain.BudgetStatus, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID cannot be empty")
+	}
+
+	return s.budgetStatus(ctx, userID)
+}
+
+// UpdateTaskBudget replaces the budget of a task paused in BUDGET_EXCEEDED.
+// Purpose: Lets an administrator raise the limit of a task that hit it; the task is queued
+//          to resume if its spend and its user's budget allow. Running tasks cannot be
+//          changed, as their execution owns the task state.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the paused task
+//   - budget: The new limits (zero limits are unlimited)
+//   - userID: Unique identifier of the administrator making the change (must be listed
+//             in cfg.BudgetAdmins)
+// Outputs:
+//   - *domain.Task: The task with its new budget
+//   - error: Returns error if userID is not a budget administrator (wraps
+//            domain.ErrNotBudgetAdmin), inputs are invalid (wraps domain.ErrInvalidBudget),
+//            the task is not found, finished (wraps domain.ErrTaskFinished) or not paused over
+//            budget (wraps domain.ErrTaskNotOverBudget), persistence fails or the task cannot
+//            be queued
+func (s *OrchestratorService) UpdateTaskBudget(ctx context.Context, taskID string, budget domain.BudgetLimit, userID string) (*domain.Task, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("taskID cannot be empty")
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("userID cannot be empty")
+	}
+	if err := s.checkBudgetAdmin(userID); err != nil {
+		return nil, err
+	}
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+	if task.Status.IsTerminal() {
+		return nil, fmt.Errorf("%w: task %s (current status: %s)", domain.ErrTaskFinished, taskID, task.Status)
+	}
+	if task.Status != domain.TaskStatusBudgetExceeded {
+		return nil, fmt.Errorf("%w: task %s (current status: %s)", domain.ErrTaskNotOverBudget, taskID, task.Status)
+	}
+
+	previous := task.Budget
+	task.Budget = budget
+	task.UpdatedAt = s.clock.Now()
+	if err := s.repo.SaveTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to save task budget: %w", err)
+	}
+
+	s.recordEvent(ctx, task, "TASK_BUDGET_UPDATED", userID, map[string]interface{}{
+		"task_id":         task.ID,
+		"previous_budget": previous,
+		"budget":          budget,
+	})
+
+	if _, waiting := s.queue.Position(task.ID); !waiting && s.budgetExceeded(ctx, task) == "" {
+		if err := s.enqueueTask(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+	s.applyUsage(ctx, task)
+
+	return task, nil
+}
@@ -0,0 +1,370 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/adapters/memory"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// withSubTaskPlans plans tasks with the given inputs, e.g., sub-tasks, with their own steps.
+func withSubTaskPlans(plans map[string][]domain.Step) harnessOption {
+	return func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		planner := deps.Planner.(fixedPlanner)
+		planner.byInput = plans
+		deps.Planner = planner
+	}
+}
+
+// withBudgetAdmins lets the given actors change budgets.
+func withBudgetAdmins(admins ...string) harnessOption {
+	return func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		cfg.BudgetAdmins = admins
+	}
+}
+
+func TestOrchestratorBudgetChangesRequireAdmin(t *testing.T) {
+	steps := []domain.Step{{ID: "a", Type: domain.StepTypeThink, Status: domain.StepStatusPending}}
+	executor := newScriptedExecutor()
+	executor.usage["a"] = domain.TokenUsage{Model: "model", PromptTokens: 100}
+	h := newHarness(t, steps, executor, withUsage(nil), withBudgetAdmins("admin"), func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		deps.Budgets = memory.NewBudgetRepository()
+	})
+	ctx := context.Background()
+
+	budget := domain.UserBudget{UserID: "alice", Daily: domain.BudgetLimit{MaxTokens: 50}}
+	for _, actor := range []string{"alice", "Admin", " admin"} {
+		if _, err := h.orchestrator.SetUserBudget(ctx, budget, actor); !errors.Is(err, domain.ErrNotBudgetAdmin) {
+			t.Errorf("SetUserBudget by %q = %v, want ErrNotBudgetAdmin", actor, err)
+		}
+	}
+	if status, err := h.orchestrator.GetUserBudget(ctx, "alice"); err != nil || !status.IsDefault {
+		t.Fatalf("GetUserBudget after rejected changes = (%+v, %v), want the default budget", status, err)
+	}
+
+	task, err := h.orchestrator.StartTask(ctx, "spend", "alice", domain.TaskOptions{Budget: domain.BudgetLimit{MaxTokens: 50}})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	// Planning is free here, so the task pauses before the final verification
+	h.waitForStatus(t, task.ID, domain.TaskStatusBudgetExceeded)
+	if _, err := h.orchestrator.UpdateTaskBudget(ctx, task.ID, domain.BudgetLimit{}, "alice"); !errors.Is(err, domain.ErrNotBudgetAdmin) {
+		t.Fatalf("UpdateTaskBudget by the task owner = %v, want ErrNotBudgetAdmin", err)
+	}
+	if got := h.waitForStatus(t, task.ID, domain.TaskStatusBudgetExceeded); got.Budget.MaxTokens != 50 {
+		t.Fatalf("budget = %+v after a rejected change, want it unchanged", got.Budget)
+	}
+
+	if _, err := h.orchestrator.SetUserBudget(ctx, budget, "admin"); err != nil {
+		t.Fatalf("SetUserBudget by an admin = %v", err)
+	}
+	if _, err := h.orchestrator.UpdateTaskBudget(ctx, task.ID, domain.BudgetLimit{}, "admin"); err != nil {
+		t.Fatalf("UpdateTaskBudget by an admin = %v", err)
+	}
+}
+
+func TestOrchestratorSubTaskSpendCountsAgainstParentBudget(t *testing.T) {
+	executor := newScriptedExecutor()
+	executor.usage["prep"] = domain.TokenUsage{Model: "model", PromptTokens: 400}
+	executor.usage["work-1"] = domain.TokenUsage{Model: "model", PromptTokens: 400}
+	executor.usage["work-2"] = domain.TokenUsage{Model: "model", PromptTokens: 400}
+	h := newHarness(t, []domain.Step{
+		{ID: "prep", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+		{ID: "spawn", Type: domain.StepTypeSubTask, Status: domain.StepStatusPending, DependsOn: []string{"prep"}, SubTasks: []domain.SubTaskSpec{
+			{Input: "child 1"},
+			{Input: "child 2"},
+		}},
+		{ID: "after", Type: domain.StepTypeThink, Status: domain.StepStatusPending, DependsOn: []string{"spawn"}},
+	}, executor, withUsage(nil), withBudgetAdmins("admin"), withSubTaskPlans(map[string][]domain.Step{
+		"child 1": {{ID: "work-1", Type: domain.StepTypeThink, Status: domain.StepStatusPending}},
+		"child 2": {{ID: "work-2", Type: domain.StepTypeThink, Status: domain.StepStatusPending}},
+	}))
+
+	parent, err := h.orchestrator.StartTask(context.Background(), "fan out", "alice", domain.TaskOptions{
+		Budget: domain.BudgetLimit{MaxTokens: 1000},
+	})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+
+	// Each child may spend the 600 tokens the parent has left; with the parent they spend 1200
+	paused := h.waitForStatus(t, parent.ID, domain.TaskStatusBudgetExceeded)
+	if reason := paused.Metadata[domain.MetadataBudgetExceeded]; !strings.Contains(reason, "1200 tokens") {
+		t.Errorf("budget_exceeded_reason = %q, want the spend of the parent and its children", reason)
+	}
+	if n := executor.callCount("after"); n != 0 {
+		t.Errorf("step after ran %d times over budget", n)
+	}
+
+	children := h.step(t, parent.ID, "spawn").ChildTaskIDs
+	if len(children) != 2 {
+		t.Fatalf("ChildTaskIDs = %v, want 2 children", children)
+	}
+	for _, childID := range children {
+		child := h.waitForStatus(t, childID, domain.TaskStatusDone)
+		if child.Budget.MaxTokens != 600 {
+			t.Errorf("child %s budget = %+v, want the 600 tokens left to the parent", childID, child.Budget)
+		}
+	}
+	if n := h.audit.count("BUDGET_EXCEEDED"); n != 1 {
+		t.Errorf("BUDGET_EXCEEDED events = %d, want 1 for the parent only", n)
+	}
+
+	// Raising the parent's limit above the spend of the whole tree resumes it
+	if _, err := h.orchestrator.UpdateTaskBudget(context.Background(), parent.ID, domain.BudgetLimit{MaxTokens: 2000}, "admin"); err != nil {
+		t.Fatalf("UpdateTaskBudget = %v", err)
+	}
+	h.waitForStatus(t, parent.ID, domain.TaskStatusDone)
+	if n := executor.callCount("after"); n != 1 {
+		t.Errorf("step after ran %d times after the budget was raised, want 1", n)
+	}
+}
+
+// withUserBudgets stores per-user budgets in memory.
+func withUserBudgets() harnessOption {
+	return func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		deps.Budgets = memory.NewBudgetRepository()
+	}
+}
+
+// spendingSteps is a plan whose step a spends 100 prompt tokens of "model" before step b runs.
+func spendingSteps(executor *scriptedExecutor) []domain.Step {
+	executor.usage["a"] = domain.TokenUsage{Model: "model", PromptTokens: 100}
+	return []domain.Step{
+		{ID: "a", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+		{ID: "b", Type: domain.StepTypeThink, Status: domain.StepStatusPending, DependsOn: []string{"a"}},
+	}
+}
+
+func TestOrchestratorPausesTaskOverBudget(t *testing.T) {
+	prices := domain.PriceTable{"model": {PromptPerMillion: 10}} // 100 tokens cost $0.001
+
+	tests := []struct {
+		name       string
+		budget     domain.BudgetLimit // Budget of the task
+		user       *domain.UserBudget // Budget stored for alice before the task starts
+		defaults   domain.UserBudget  // cfg.DefaultUserBudget
+		setup      func(t *testing.T, h *harness)
+		wantStatus domain.TaskStatus
+		wantReason string
+	}{
+		{
+			name:       "task token cap",
+			budget:     domain.BudgetLimit{MaxTokens: 100},
+			wantStatus: domain.TaskStatusBudgetExceeded,
+			wantReason: "task budget exceeded: 100 tokens reached the limit of 100",
+		},
+		{
+			name:       "task cost cap",
+			budget:     domain.BudgetLimit{MaxCostUSD: 0.0005},
+			wantStatus: domain.TaskStatusBudgetExceeded,
+			wantReason: "task budget exceeded: cost $0.0010 reached the limit of $0.0005",
+		},
+		{
+			name:       "default daily cap",
+			defaults:   domain.UserBudget{Daily: domain.BudgetLimit{MaxTokens: 100}},
+			wantStatus: domain.TaskStatusBudgetExceeded,
+			wantReason: "daily budget of user alice exceeded: 100 tokens",
+		},
+		{
+			name:       "stored daily cap replaces the default",
+			user:       &domain.UserBudget{UserID: "alice", Daily: domain.BudgetLimit{MaxCostUSD: 0.001}},
+			defaults:   domain.UserBudget{Daily: domain.BudgetLimit{MaxTokens: 1000}},
+			wantStatus: domain.TaskStatusBudgetExceeded,
+			wantReason: "daily budget of user alice exceeded: cost $0.0010",
+		},
+		{
+			name: "monthly cap counts earlier days of the month only",
+			user: &domain.UserBudget{UserID: "alice", Daily: domain.BudgetLimit{MaxTokens: 150}, Monthly: domain.BudgetLimit{MaxTokens: 150}},
+			setup: func(t *testing.T, h *harness) {
+				yesterday := h.clock.Now()
+				h.clock.Advance(24 * time.Hour)
+				spent := []*domain.UsageRecord{
+					{ID: "yesterday", TaskID: "old", UserID: "alice", Model: "model", PromptTokens: 100, CreatedAt: yesterday},
+					{ID: "last-month", TaskID: "old", UserID: "alice", Model: "model", PromptTokens: 1000, CreatedAt: yesterday.AddDate(0, -1, 0)},
+					{ID: "other-user", TaskID: "other", UserID: "bob", Model: "model", PromptTokens: 1000, CreatedAt: h.clock.Now()},
+				}
+				for _, record := range spent {
+					if err := h.deps.Usage.SaveUsage(context.Background(), record); err != nil {
+						t.Fatalf("SaveUsage = %v", err)
+					}
+				}
+			},
+			wantStatus: domain.TaskStatusBudgetExceeded,
+			wantReason: "monthly budget of user alice exceeded: 200 tokens reached the limit of 150",
+		},
+		{
+			name:       "within every budget",
+			budget:     domain.BudgetLimit{MaxTokens: 1000, MaxCostUSD: 1},
+			defaults:   domain.UserBudget{Daily: domain.BudgetLimit{MaxTokens: 1000}, Monthly: domain.BudgetLimit{MaxCostUSD: 1}},
+			wantStatus: domain.TaskStatusDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := newScriptedExecutor()
+			h := newHarness(t, spendingSteps(executor), executor, withUsage(prices), withUserBudgets(), withBudgetAdmins("admin"),
+				func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+					cfg.DefaultUserBudget = tt.defaults
+				})
+			ctx := context.Background()
+			if tt.setup != nil {
+				tt.setup(t, h)
+			}
+			if tt.user != nil {
+				if _, err := h.orchestrator.SetUserBudget(ctx, *tt.user, "admin"); err != nil {
+					t.Fatalf("SetUserBudget = %v", err)
+				}
+			}
+
+			task, err := h.orchestrator.StartTask(ctx, "spend", "alice", domain.TaskOptions{Budget: tt.budget})
+			if err != nil {
+				t.Fatalf("StartTask = %v", err)
+			}
+			got := h.waitForStatus(t, task.ID, tt.wantStatus)
+
+			if reason := got.Metadata[domain.MetadataBudgetExceeded]; !strings.HasPrefix(reason, tt.wantReason) || (tt.wantReason == "") != (reason == "") {
+				t.Errorf("budget_exceeded_reason = %q, want prefix %q", reason, tt.wantReason)
+			}
+			if got.UsageTokens != 100 {
+				t.Errorf("UsageTokens = %d, want the 100 tokens of step a", got.UsageTokens)
+			}
+			wantEvents, wantB := 0, 1
+			if tt.wantStatus == domain.TaskStatusBudgetExceeded {
+				wantEvents, wantB = 1, 0
+			}
+			if n := h.audit.count("BUDGET_EXCEEDED"); n != wantEvents {
+				t.Errorf("BUDGET_EXCEEDED events = %d, want %d", n, wantEvents)
+			}
+			if n := executor.callCount("b"); n != wantB {
+				t.Errorf("step b ran %d times, want %d", n, wantB)
+			}
+		})
+	}
+}
+
+func TestOrchestratorResumesTaskAfterBudgetUpdate(t *testing.T) {
+	executor := newScriptedExecutor()
+	executor.block["hold"] = true
+	h := newHarness(t, spendingSteps(executor), executor, withUsage(nil), withBudgetAdmins("admin"),
+		withSubTaskPlans(map[string][]domain.Step{"hold": thinkStep("hold")}))
+	ctx := context.Background()
+
+	task, err := h.orchestrator.StartTask(ctx, "spend", "alice", domain.TaskOptions{Budget: domain.BudgetLimit{MaxTokens: 100}})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	h.waitForStatus(t, task.ID, domain.TaskStatusBudgetExceeded)
+
+	if _, err := h.orchestrator.UpdateTaskBudget(ctx, task.ID, domain.BudgetLimit{MaxTokens: -1}, "admin"); !errors.Is(err, domain.ErrInvalidBudget) {
+		t.Fatalf("UpdateTaskBudget with a negative limit = %v, want ErrInvalidBudget", err)
+	}
+
+	// A limit the task has already spent keeps it paused and off the queue
+	if _, err := h.orchestrator.UpdateTaskBudget(ctx, task.ID, domain.BudgetLimit{MaxTokens: 100, MaxCostUSD: 5}, "admin"); err != nil {
+		t.Fatalf("UpdateTaskBudget = %v", err)
+	}
+	if _, waiting := h.deps.Queue.Position(task.ID); waiting {
+		t.Fatal("task still over budget was queued")
+	}
+	if got := h.waitForStatus(t, task.ID, domain.TaskStatusBudgetExceeded); got.Budget.MaxCostUSD != 5 {
+		t.Errorf("budget = %+v, want the updated limits", got.Budget)
+	}
+
+	updated, err := h.orchestrator.UpdateTaskBudget(ctx, task.ID, domain.BudgetLimit{MaxTokens: 1000}, "admin")
+	if err != nil {
+		t.Fatalf("UpdateTaskBudget = %v", err)
+	}
+	if updated.Budget.MaxTokens != 1000 {
+		t.Errorf("returned budget = %+v, want 1000 tokens", updated.Budget)
+	}
+	done := h.waitForStatus(t, task.ID, domain.TaskStatusDone)
+	if reason, ok := done.Metadata[domain.MetadataBudgetExceeded]; ok {
+		t.Errorf("budget_exceeded_reason = %q survived the resume", reason)
+	}
+	// The resumed task continues its plan instead of starting over
+	if a, b := executor.callCount("a"), executor.callCount("b"); a != 1 || b != 1 {
+		t.Errorf("steps ran (a %d, b %d) times, want once each", a, b)
+	}
+	if n := h.audit.count("BUDGET_RESUMED"); n != 1 {
+		t.Errorf("BUDGET_RESUMED events = %d, want 1", n)
+	}
+	if n := h.audit.count("TASK_BUDGET_UPDATED"); n != 2 {
+		t.Errorf("TASK_BUDGET_UPDATED events = %d, want 2", n)
+	}
+
+	if _, err := h.orchestrator.UpdateTaskBudget(ctx, task.ID, domain.BudgetLimit{}, "admin"); !errors.Is(err, domain.ErrTaskFinished) {
+		t.Errorf("UpdateTaskBudget of a finished task = %v, want ErrTaskFinished", err)
+	}
+	running, err := h.orchestrator.StartTask(ctx, "hold", "alice", domain.TaskOptions{})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	h.waitForStart(t, "hold")
+	if _, err := h.orchestrator.UpdateTaskBudget(ctx, running.ID, domain.BudgetLimit{}, "admin"); !errors.Is(err, domain.ErrTaskNotOverBudget) {
+		t.Errorf("UpdateTaskBudget of a running task = %v, want ErrTaskNotOverBudget", err)
+	}
+}
+
+func TestOrchestratorPausesAndResumesTasksWithUserBudget(t *testing.T) {
+	executor := newScriptedExecutor()
+	h := newHarness(t, spendingSteps(executor), executor, withUsage(nil), withUserBudgets(), withBudgetAdmins("admin"))
+	ctx := context.Background()
+
+	if _, err := h.orchestrator.SetUserBudget(ctx, domain.UserBudget{UserID: "alice", Daily: domain.BudgetLimit{MaxTokens: 100}}, "admin"); err != nil {
+		t.Fatalf("SetUserBudget = %v", err)
+	}
+	ids := make([]string, 0, 2)
+	for _, input := range []string{"spend 1", "spend 2"} {
+		task, err := h.orchestrator.StartTask(ctx, input, "alice", domain.TaskOptions{})
+		if err != nil {
+			t.Fatalf("StartTask(%s) = %v", input, err)
+		}
+		ids = append(ids, task.ID)
+	}
+	// Whichever task spends first pauses both: the other before planning or before step b
+	for _, id := range ids {
+		h.waitForStatus(t, id, domain.TaskStatusBudgetExceeded)
+	}
+	if n := executor.callCount("b"); n != 0 {
+		t.Fatalf("step b ran %d times over the daily cap", n)
+	}
+
+	status, err := h.orchestrator.GetUserBudget(ctx, "alice")
+	if err != nil {
+		t.Fatalf("GetUserBudget = %v", err)
+	}
+	if status.IsDefault || status.DailySpent.TotalTokens < 100 || status.DailySpent != status.MonthlySpent {
+		t.Errorf("status = %+v, want the stored budget with at least 100 tokens spent today", status)
+	}
+
+	// Lowering the cap leaves the tasks paused
+	if _, err := h.orchestrator.SetUserBudget(ctx, domain.UserBudget{UserID: "alice", Daily: domain.BudgetLimit{MaxTokens: 50}}, "admin"); err != nil {
+		t.Fatalf("SetUserBudget = %v", err)
+	}
+	for _, id := range ids {
+		if _, waiting := h.deps.Queue.Position(id); waiting {
+			t.Errorf("task %s was queued under a lower cap", id)
+		}
+	}
+
+	// Lifting the cap resumes both tasks
+	if _, err := h.orchestrator.SetUserBudget(ctx, domain.UserBudget{UserID: "alice"}, "admin"); err != nil {
+		t.Fatalf("SetUserBudget = %v", err)
+	}
+	for _, id := range ids {
+		h.waitForStatus(t, id, domain.TaskStatusDone)
+	}
+	if a, b := executor.callCount("a"), executor.callCount("b"); a != 2 || b != 2 {
+		t.Errorf("steps ran (a %d, b %d) times, want twice each", a, b)
+	}
+	if n := h.audit.count("BUDGET_RESUMED"); n != 2 {
+		t.Errorf("BUDGET_RESUMED events = %d, want 2", n)
+	}
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// errTaskCanceled is the cancellation cause used when CancelTask interrupts an execution.
+// Execution code that observes it stops without writing state; CancelTask owns the final state.
+var errTaskCanceled = errors.New("task canceled")
+
+// execution tracks a plan execution that is currently running for a task.
+type execution struct {
+	cancel     context.CancelCauseFunc
+	done       chan struct{} // closed once the execution has stopped writing state
+	settled    chan struct{} // closed once CancelTask has written the final state
+	settleOnce sync.Once
+}
+
+// settle signals that the canceler has finished writing the task's final state.
+func (e *execution) settle() {
+	e.settleOnce.Do(func() { close(e.settled) })
+}
+
+// runExecution runs fn with a cancellable context registered for the task.
+// Purpose: Makes every running execution interruptible by CancelTask and by the task
+//          Deadline. If the execution is canceled, it waits for CancelTask to settle and
+//          reloads the task so the caller sees the final CANCELED state. If the deadline
+//          passes (or has already passed), the task is FAILED via failDeadline before the
+//          execution is unregistered, so a concurrent CancelTask sees the final state.
+//          An execution stopped by a worker pool shutdown leaves the task as it is for
+//          RecoverTasks.
+// Inputs:
+//   - ctx: Parent context for the execution
+//   - task: The task being executed (refreshed in place after cancellation or deadline)
+//   - fn: The execution to run (e.g., runTask or a resumption of executePlan)
+// Outputs:
+//   - error: Returns error from fn, except cancellation and shutdown which are not treated as
+//            failures; after a missed deadline only persistence errors of failDeadline are returned
+func (s *OrchestratorService) runExecution(ctx context.Context, task *domain.Task, fn func(ctx context.Context) error) error {
+	execCtx, cancel := context.WithCancelCause(ctx)
+	exec := &execution{cancel: cancel, done: make(chan struct{}), settled: make(chan struct{})}
+
+	s.mu.Lock()
+	s.running[task.ID] = exec
+	s.mu.Unlock()
+
+	// Unregister even if fn panics, so CancelTask never waits for an execution that is gone
+	unregistered := false
+	unregister := func() {
+		s.mu.Lock()
+		if s.running[task.ID] == exec {
+			delete(s.running, task.ID)
+		}
+		s.mu.Unlock()
+		cancel(nil)
+		close(exec.done)
+		unregistered = true
+	}
+	defer func() {
+		if !unregistered {
+			unregister()
+		}
+	}()
+
+	if !task.Deadline.IsZero() {
+		s.watchDeadline(execCtx, cancel, task.Deadline)
+	}
+
+	var err error
+	if !isDeadlineExceeded(execCtx) {
+		err = fn(execCtx)
+	}
+	canceled := isCanceled(execCtx)
+	shutdown := isShutdown(execCtx)
+	if isDeadlineExceeded(execCtx) {
+		err = s.failDeadline(ctx, task)
+	}
+
+	unregister()
+
+	if canceled {
+		<-exec.settled
+		if latest, loadErr := s.repo.GetTask(ctx, task.ID); loadErr == nil {
+			*task = *latest
+		}
+		return nil
+	}
+	if shutdown {
+		s.logger.Info("execution stopped by shutdown, task left for recovery", map[string]interface{}{
+			"task_id": task.ID,
+			"status":  string(task.Status),
+		})
+		return nil
+	}
+
+	return err
+}
+
+// interruptExecution cancels the running execution of a task and waits for it to stop.
+// Purpose: Guarantees that no execution writes task state after CancelTask takes over.
+//          The caller must call settle on the returned execution once it is done.
+// Inputs:
+//   - ctx: Context bounding how long to wait for the execution to stop
+//   - taskID: Unique identifier of the task
+// Outputs:
+//   - *execution: The interrupted execution, or nil if none was running
+//   - error: Returns error if ctx expires before the execution stops
+func (s *OrchestratorService) interruptExecution(ctx context.Context, taskID string) (*execution, error) {
+	s.mu.Lock()
+	exec, running := s.running[taskID]
+	s.mu.Unlock()
+
+	if !running {
+		return nil, nil
+	}
+
+	exec.cancel(errTaskCanceled)
+
+	select {
+	case <-exec.done:
+		return exec, nil
+	case <-ctx.Done():
+		return exec, fmt.Errorf("timed out waiting for task %s to stop: %w", taskID, ctx.Err())
+	}
+}
+
+// closeApprovals rejects the open approvals of a task being canceled or failed.
+// The approvals are closed under approvalMu, so a concurrent HandleApproval either records
+// its decision first or fails with domain.ErrApprovalNotOpen.
+func (s *OrchestratorService) closeApprovals(ctx context.Context, taskID string, userID string, comment string) error {
+	s.approvalMu.Lock()
+	defer s.approvalMu.Unlock()
+
+	return s.closeOpenApprovals(ctx, taskID, userID, comment)
+}
+
+// closeOpenApprovals rejects the open approvals of a task. The caller must hold approvalMu.
+func (s *OrchestratorService) closeOpenApprovals(ctx context.Context, taskID string, userID string, comment string) error {
+	approvals, err := s.approvals.ListTaskApprovals(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to load approvals: %w", err)
+	}
+	for _, approval := range approvals {
+		if approval.Status != domain.ApprovalStatusOpen {
+			continue
+		}
+		approval.Status = domain.ApprovalStatusRejected
+		approval.ApprovedBy = userID
+		approval.Comment = comment
+		approval.DecidedAt = s.clock.Now()
+		if err := s.approvals.SaveApproval(ctx, approval); err != nil {
+			return fmt.Errorf("failed to close approval %s: %w", approval.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// isCanceled reports whether ctx was canceled by CancelTask (as opposed to a caller timeout).
+func isCanceled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errTaskCanceled)
+}
+
+// CancelTask stops a task, interrupting any step that is currently executing.
+// Purpose: Cancels the context handed to the running Executor.ExecuteStep, waits for the
+//          execution to stop, marks all remaining steps SKIPPED, closes open approvals and
+//          finishes the task as CANCELED with a TASK_CANCELED audit event.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task to cancel
+//   - userID: Unique identifier of the user requesting cancellation
+//   - reason: Human-readable reason recorded on the task and in the audit log
+// Outputs:
+//   - error: Returns error if the task is not found or persistence fails.
+//            Wraps domain.ErrTaskFinished if the task has already reached a terminal status.
+func (s *OrchestratorService) CancelTask(ctx context.Context, taskID string, userID string, reason string) error {
+	if taskID == "" {
+		return fmt.Errorf("taskID cannot be empty")
+	}
+	if userID == "" {
+		return fmt.Errorf("userID cannot be empty")
+	}
+	if reason == "" {
+		reason = "canceled by user"
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to load task: %w", err)
+	}
+	if task.Status.IsTerminal() {
+		return fmt.Errorf("%w: task %s (current status: %s)", domain.ErrTaskFinished, taskID, task.Status)
+	}
+
+	// A task still waiting for a worker only has to leave the queue
+	if task.Status == domain.TaskStatusNew {
+		s.queue.Remove(taskID)
+	}
+
+	// Stop the in-flight step, then reload the state it left behind
+	exec, err := s.interruptExecution(ctx, taskID)
+	if exec != nil {
+		defer exec.settle()
+	}
+	if err != nil {
+		return err
+	}
+
+	task, err = s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to reload task: %w", err)
+	}
+	if task.Status.IsTerminal() {
+		return fmt.Errorf("%w: task %s (current status: %s)", domain.ErrTaskFinished, taskID, task.Status)
+	}
+
+	// Skip everything that has not run to completion
+	skipped := make([]string, 0)
+	if task.PlanID != "" {
+		plan, err := s.plans.GetPlan(ctx, task.PlanID)
+		if err != nil {
+			return fmt.Errorf("failed to load plan: %w", err)
+		}
+		for i := range plan.Steps {
+			step := &plan.Steps[i]
+			if step.Status != domain.StepStatusPending && step.Status != domain.StepStatusInProgress {
+				continue
+			}
+			if err := s.setStepStatus(ctx, task, plan.ID, step, domain.StepStatusSkipped); err != nil {
+				return err
+			}
+			skipped = append(skipped, step.ID)
+		}
+	}
+
+	// Close approvals nobody needs to decide anymore
+	if err := s.closeApprovals(ctx, taskID, userID, fmt.Sprintf("task canceled: %s", reason)); err != nil {
+		return err
+	}
+
+	s.recordEvent(ctx, task, "TASK_CANCELED", userID, map[string]interface{}{
+		"task_id":        taskID,
+		"reason":         reason,
+		"previous":       string(task.Status),
+		"skipped_steps":  skipped,
+		"interrupted_at": task.CurrentStepID,
+	})
+
+	return s.finishTask(ctx, task, domain.TaskStatusCanceled, reason)
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// OrchestratorConfig holds the tunable policies of the orchestrator.
+// Purpose: Keeps orchestration thresholds and policies out of service code so they can be
+//          driven by config.Config at wiring time without the core importing the config package.
+type OrchestratorConfig struct {
+	// Approvals - Human-in-the-loop behaviour
+	RejectionPolicy domain.RejectionPolicy // Outcome of a rejected approval (default: FAIL_TASK)
+
+	// Execution - Plan scheduling
+	MaxParallelSteps int // Maximum number of independent steps executed concurrently per task (default: 4)
+	MaxSubTaskDepth  int // Maximum nesting of tasks spawned by SUB_TASK steps; 0 disables sub-tasks (default: 3)
+
+	// Time limits - Applied when tasks and steps do not set their own
+	TaskTimeout time.Duration // Deadline given to tasks created without one; 0 means none (default: 0)
+	StepTimeout time.Duration // Per-attempt timeout of steps without TimeoutMs; 0 means none (default: 10m)
+
+	// Idempotency - Deduplication of retried task submissions
+	IdempotencyKeyTTL time.Duration // How long an idempotency key returns its original task (default: 24h)
+
+	// Accounting - Pricing of LLM usage
+	Prices            domain.PriceTable // USD per million prompt/completion tokens by model, from config.Config.LLMPrices (default: none, calls are recorded unpriced)
+	DefaultUserBudget domain.UserBudget // Daily/monthly caps of users without a stored budget (default: unlimited)
+	BudgetAdmins      []string          // Actors allowed to change user and task budgets (default: none)
+
+	// Results - Storage of large step outputs
+	ResultInlineMaxBytes int // Step outputs above this size are moved to the ResultStore; 0 keeps all inline (default: 64KB)
+	ResultPreviewBytes   int // Maximum size of the preview kept inline for a moved output; 0 keeps none (default: 1KB)
+
+	// Verification - Goal checking before a task is reported DONE
+	MaxReplans int // Maximum revised plans requested after failed verification (default: 2)
+
+	// Retries - Default policy for failed steps (steps may override it)
+	RetryPolicy domain.RetryPolicy // Attempts, backoff and error classification (default: 3 attempts, 1s→30s x2, 20% jitter)
+}
+
+// DefaultOrchestratorConfig returns an OrchestratorConfig with safe default values.
+// Purpose: Provides defaults matching config.NewDefaultConfig for tests and simple wiring.
+// Inputs: None
+// Outputs:
+//   - OrchestratorConfig: Configuration with all defaults set
+func DefaultOrchestratorConfig() OrchestratorConfig {
+	return OrchestratorConfig{
+		RejectionPolicy:   domain.RejectionPolicyFailTask,
+		MaxParallelSteps:  4,
+		MaxSubTaskDepth:   3,
+		StepTimeout:       10 * time.Minute,
+		IdempotencyKeyTTL: 24 * time.Hour,
+		MaxReplans:        2,
+
+		ResultInlineMaxBytes: 64 * 1024,
+		ResultPreviewBytes:   1024,
+		RetryPolicy: domain.RetryPolicy{
+			MaxAttempts:        3,
+			InitialBackoffMs:   1000,
+			MaxBackoffMs:       30000,
+			Multiplier:         2,
+			Jitter:             0.2,
+			RetryUnknownErrors: true,
+		},
+	}
+}
+
+// SchedulerConfig holds the tunable policies of the scheduler.
+// Purpose: Mirrors the scheduling settings of config.Config without importing the config package.
+type SchedulerConfig struct {
+	PollInterval   time.Duration // How often due schedules are checked (default: 15s)
+	MisfireGrace   time.Duration // How late a run may start under the SKIP policy (default: 1m)
+	MaxCatchUpRuns int           // Maximum missed runs started per schedule and check under CATCH_UP (at least 1; default: 10)
+}
+
+// DefaultSchedulerConfig returns a SchedulerConfig with safe default values.
+// Purpose: Provides defaults matching config.NewDefaultConfig for tests and simple wiring.
+// Inputs: None
+// Outputs:
+//   - SchedulerConfig: Configuration with all defaults set
+func DefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		PollInterval:   15 * time.Second,
+		MisfireGrace:   1 * time.Minute,
+		MaxCatchUpRuns: 10,
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// DeadlineWatcher fails suspended tasks once their deadline passes.
+// Running executions watch their own deadline, but tasks parked in WAITING_APPROVAL,
+// WAITING_SUBTASKS or BUDGET_EXCEEDED have no execution; the watcher runs a loop driven by
+// ports.Clock that calls Orchestrator.ExpireDeadlines for them, independently of the scheduler.
+type DeadlineWatcher struct {
+	orchestrator ports.Orchestrator
+	clock        ports.Clock
+	logger       ports.Logger
+	interval     time.Duration
+	wg           sync.WaitGroup
+}
+
+// NewDeadlineWatcher creates a watcher that expires the deadlines of suspended tasks.
+// Purpose: Factory function wiring the orchestrator to its own expiry loop.
+// Inputs:
+//   - orchestrator: Orchestrator whose ExpireDeadlines is called on every check
+//   - clock: Implementation of the Clock port that drives the checks
+//   - logger: Implementation of the Logger port for expiry errors
+//   - interval: Time between checks; bounds how late a suspended task fails (must be positive)
+// Outputs:
+//   - *DeadlineWatcher: Watcher ready to be started
+//   - error: Returns error if interval is not positive
+func NewDeadlineWatcher(orchestrator ports.Orchestrator, clock ports.Clock, logger ports.Logger, interval time.Duration) (*DeadlineWatcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("deadline check interval must be positive: %v", interval)
+	}
+	return &DeadlineWatcher{
+		orchestrator: orchestrator,
+		clock:        clock,
+		logger:       logger,
+		interval:     interval,
+	}, nil
+}
+
+// Start launches the expiry loop in the background.
+// Purpose: Expires passed deadlines immediately (e.g., of tasks suspended before a restart)
+//          and then every interval until ctx is done.
+// Inputs:
+//   - ctx: Lifetime of the loop (cancel it to stop the watcher)
+// Outputs: None
+func (w *DeadlineWatcher) Start(ctx context.Context) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		for {
+			if _, err := w.orchestrator.ExpireDeadlines(ctx); err != nil {
+				w.logger.Error("failed to expire task deadlines", err, nil)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.clock.After(w.interval):
+			}
+		}
+	}()
+
+	w.logger.Info("deadline watcher started", map[string]interface{}{
+		"interval": w.interval.String(),
+	})
+}
+
+// Wait blocks until the expiry loop has stopped.
+// Purpose: Lets the caller finish a graceful shutdown after canceling the loop context.
+// Inputs: None
+// Outputs: None
+func (w *DeadlineWatcher) Wait() {
+	w.wg.Wait()
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// isDeadlineExceeded reports whether ctx was interrupted because the task deadline passed.
+func isDeadlineExceeded(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), domain.ErrDeadlineExceeded)
+}
+
+// isInterrupted reports whether ctx was interrupted by CancelTask, the task deadline or a
+// worker pool shutdown. Execution code that observes it stops without writing state; the
+// interrupter owns the final state (after a shutdown, RecoverTasks resumes the task).
+func isInterrupted(ctx context.Context) bool {
+	return isCanceled(ctx) || isDeadlineExceeded(ctx) || isShutdown(ctx)
+}
+
+// afterClock returns a child of ctx that is canceled with cause once d has elapsed on the Clock.
+// Purpose: Implements deadlines and timeouts on ports.Clock (like retry backoff) so they can be
+//          driven deterministically in tests. d <= 0 cancels the child immediately.
+// Inputs:
+//   - ctx: Parent context
+//   - d: Time until the child is canceled
+//   - cause: Cancellation cause reported by context.Cause on expiry
+// Outputs:
+//   - context.Context: The child context
+//   - context.CancelFunc: Releases the child; call it once the guarded work has finished
+func (s *OrchestratorService) afterClock(ctx context.Context, d time.Duration, cause error) (context.Context, context.CancelFunc) {
+	child, cancel := context.WithCancelCause(ctx)
+	if d <= 0 {
+		cancel(cause)
+		return child, func() {}
+	}
+
+	go func() {
+		select {
+		case <-s.clock.After(d):
+			cancel(cause)
+		case <-child.Done():
+		}
+	}()
+
+	return child, func() { cancel(context.Canceled) }
+}
+
+// watchDeadline interrupts an execution once the task deadline passes.
+// Purpose: Started by runExecution for tasks with a Deadline; cancels the execution context
+//          with domain.ErrDeadlineExceeded unless the execution stops first.
+// Inputs:
+//   - ctx: The execution context
+//   - cancel: Cancels the execution context with a cause
+//   - deadline: The task deadline
+// Outputs: None
+func (s *OrchestratorService) watchDeadline(ctx context.Context, cancel context.CancelCauseFunc, deadline time.Time) {
+	remaining := deadline.Sub(s.clock.Now())
+	if remaining <= 0 {
+		cancel(domain.ErrDeadlineExceeded)
+		return
+	}
+
+	go func() {
+		select {
+		case <-s.clock.After(remaining):
+			cancel(domain.ErrDeadlineExceeded)
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// ExpireDeadlines fails suspended tasks whose deadline has passed.
+// Purpose: Running executions watch their own deadline (see watchDeadline), but tasks parked
+//          in WAITING_APPROVAL, WAITING_SUBTASKS or BUDGET_EXCEEDED have no execution. The
+//          DeadlineWatcher calls this on every check so they fail at their deadline like
+//          running tasks, with their open approvals closed.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+// Outputs:
+//   - int: Number of tasks failed
+//   - error: Returns error if tasks could not be listed; per-task failures are joined
+//            into the error but do not stop the remaining tasks
+func (s *OrchestratorService) ExpireDeadlines(ctx context.Context) (int, error) {
+	tasks, err := s.repo.ListTasks(ctx, domain.TaskFilter{
+		Statuses: []domain.TaskStatus{domain.TaskStatusWaitingApproval, domain.TaskStatusWaitingSubTasks, domain.TaskStatusBudgetExceeded},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list suspended tasks: %w", err)
+	}
+
+	now := s.clock.Now()
+	expired := 0
+	var errs []error
+	for _, task := range tasks {
+		if task.Deadline.IsZero() || now.Before(task.Deadline) {
+			continue
+		}
+		failed, err := s.expireSuspended(ctx, task)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("task %s: %w", task.ID, err))
+		}
+		if failed {
+			expired++
+		}
+	}
+
+	return expired, errors.Join(errs...)
+}
+
+// expireSuspended fails a suspended task whose deadline has passed.
+// Purpose: The task is claimed under the lock its resume takes (approvalMu, subTaskMu or
+//          budgetMu) by registering an execution for it, so a resume that races the expiry
+//          finds it running and leaves it alone, and CancelTask waits for the expiry to end.
+//          Open approvals are closed within the claim, so later decisions are rejected.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The suspended task (refreshed and mutated in place)
+// Outputs:
+//   - bool: True if the task was failed, false if it was resumed or finished meanwhile
+//   - error: Returns error if task, plan or approval state could not be loaded or persisted
+func (s *OrchestratorService) expireSuspended(ctx context.Context, task *domain.Task) (bool, error) {
+	var lock *sync.Mutex
+	switch task.Status {
+	case domain.TaskStatusWaitingApproval:
+		lock = &s.approvalMu
+	case domain.TaskStatusWaitingSubTasks:
+		lock = &s.subTaskMu
+	case domain.TaskStatusBudgetExceeded:
+		lock = &s.budgetMu
+	default:
+		return false, nil
+	}
+
+	lock.Lock()
+	latest, err := s.repo.GetTask(ctx, task.ID)
+	if err != nil {
+		lock.Unlock()
+		return false, fmt.Errorf("failed to reload task: %w", err)
+	}
+	if latest.Status != task.Status {
+		lock.Unlock()
+		return false, nil
+	}
+	exec := &execution{cancel: func(error) {}, done: make(chan struct{}), settled: make(chan struct{})}
+	s.mu.Lock()
+	_, running := s.running[task.ID]
+	if !running {
+		s.running[task.ID] = exec
+	}
+	s.mu.Unlock()
+	if running {
+		lock.Unlock()
+		return false, nil
+	}
+
+	// Close open approvals before releasing approvalMu, so no decision is recorded after the claim
+	reason := fmt.Sprintf("task deadline %s exceeded", latest.Deadline.Format(time.RFC3339))
+	var closeErr error
+	if latest.Status == domain.TaskStatusWaitingApproval {
+		closeErr = s.closeOpenApprovals(ctx, task.ID, systemActor, reason)
+	}
+	lock.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		if s.running[task.ID] == exec {
+			delete(s.running, task.ID)
+		}
+		s.mu.Unlock()
+		close(exec.done)
+	}()
+
+	if closeErr != nil {
+		return false, closeErr
+	}
+	*task = *latest
+	if err := s.failDeadline(ctx, task); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// failDeadline finishes a task whose deadline passed during execution.
+// Purpose: Marks steps that were running FAILED with ErrorCodeDeadlineExceeded, skips steps that
+//          never started, emits TASK_DEADLINE_EXCEEDED and finishes the task as FAILED with
+//          Metadata["failure_code"] set. Tasks that already finished are left alone.
+// Inputs:
+//   - ctx: Context for persistence (not the interrupted execution context)
+//   - task: The task whose deadline passed (refreshed in place)
+// Outputs:
+//   - error: Returns error if task or plan state could not be loaded or persisted
+func (s *OrchestratorService) failDeadline(ctx context.Context, task *domain.Task) error {
+	latest, err := s.repo.GetTask(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to reload task: %w", err)
+	}
+	*task = *latest
+	if task.Status.IsTerminal() {
+		return nil
+	}
+
+	reason := fmt.Sprintf("task deadline %s exceeded", task.Deadline.Format(time.RFC3339))
+	failed := make([]string, 0)
+	skipped := make([]string, 0)
+	if task.PlanID != "" {
+		plan, err := s.plans.GetPlan(ctx, task.PlanID)
+		if err != nil {
+			return fmt.Errorf("failed to load plan: %w", err)
+		}
+		for i := range plan.Steps {
+			step := &plan.Steps[i]
+			switch step.Status {
+			case domain.StepStatusInProgress:
+				result := &domain.StepResult{
+					StepID:       step.ID,
+					Success:      false,
+					ErrorMessage: reason,
+					ErrorCode:    domain.ErrorCodeDeadlineExceeded,
+				}
+				if err := s.plans.SaveStepResult(ctx, plan.ID, result); err != nil {
+					return fmt.Errorf("failed to save result of step %s: %w", step.ID, err)
+				}
+				if err := s.setStepStatus(ctx, task, plan.ID, step, domain.StepStatusFailed); err != nil {
+					return err
+				}
+				failed = append(failed, step.ID)
+			case domain.StepStatusPending:
+				if err := s.setStepStatus(ctx, task, plan.ID, step, domain.StepStatusSkipped); err != nil {
+					return err
+				}
+				skipped = append(skipped, step.ID)
+			}
+		}
+	}
+
+	s.recordEvent(ctx, task, "TASK_DEADLINE_EXCEEDED", systemActor, map[string]interface{}{
+		"task_id":       task.ID,
+		"deadline":      task.Deadline,
+		"previous":      string(task.Status),
+		"failed_steps":  failed,
+		"skipped_steps": skipped,
+	})
+
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]string)
+	}
+	task.Metadata["failure_code"] = domain.ErrorCodeDeadlineExceeded
+
+	return s.finishTask(ctx, task, domain.TaskStatusFailed, reason)
+}
@@ -0,0 +1,187 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/adapters/memory"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// awaitWait skips After calls until one requests d, i.e., until the waiter of d is registered.
+func (c *fakeClock) awaitWait(t *testing.T, d time.Duration) {
+	t.Helper()
+	for c.nextWait(t) != d {
+	}
+}
+
+// startDeadlineWatcher runs a DeadlineWatcher on the harness clock until the test ends.
+func (h *harness) startDeadlineWatcher(t *testing.T, interval time.Duration) {
+	t.Helper()
+	watcher, err := services.NewDeadlineWatcher(h.orchestrator, h.clock, nopLogger{}, interval)
+	if err != nil {
+		t.Fatalf("NewDeadlineWatcher = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher.Start(ctx)
+	t.Cleanup(func() {
+		cancel()
+		watcher.Wait()
+	})
+}
+
+// assertDeadlineFailure checks that the task failed on its deadline.
+func assertDeadlineFailure(t *testing.T, task *domain.Task) {
+	t.Helper()
+	if code := task.Metadata["failure_code"]; code != domain.ErrorCodeDeadlineExceeded {
+		t.Errorf("failure_code = %q, want %s", code, domain.ErrorCodeDeadlineExceeded)
+	}
+}
+
+func TestOrchestratorFailsRunningTaskAtDeadline(t *testing.T) {
+	executor := newScriptedExecutor()
+	executor.block["a"] = true
+	h := newHarness(t, []domain.Step{
+		{ID: "a", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+		{ID: "b", Type: domain.StepTypeThink, Status: domain.StepStatusPending, DependsOn: []string{"a"}},
+	}, executor)
+
+	task, err := h.orchestrator.StartTask(context.Background(), "run out of time", "alice", domain.TaskOptions{Timeout: 10 * time.Minute})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	h.waitForStart(t, "a")
+	h.clock.awaitWait(t, 10*time.Minute)
+
+	h.clock.Advance(10 * time.Minute)
+	failed := h.waitForStatus(t, task.ID, domain.TaskStatusFailed)
+	assertDeadlineFailure(t, failed)
+
+	if step := h.step(t, task.ID, "a"); step.Status != domain.StepStatusFailed {
+		t.Errorf("running step a is %s, want FAILED", step.Status)
+	}
+	if result := h.stepResult(t, task.ID, "a"); result.ErrorCode != domain.ErrorCodeDeadlineExceeded {
+		t.Errorf("step a error_code = %q, want %s", result.ErrorCode, domain.ErrorCodeDeadlineExceeded)
+	}
+	if step := h.step(t, task.ID, "b"); step.Status != domain.StepStatusSkipped {
+		t.Errorf("pending step b is %s, want SKIPPED", step.Status)
+	}
+	if n := executor.callCount("b"); n != 0 {
+		t.Errorf("step b ran %d times after the deadline", n)
+	}
+	if n := h.audit.count("TASK_DEADLINE_EXCEEDED"); n != 1 {
+		t.Errorf("TASK_DEADLINE_EXCEEDED events = %d, want 1", n)
+	}
+}
+
+func TestOrchestratorRejectsPassedDeadline(t *testing.T) {
+	h := newHarness(t, []domain.Step{{ID: "a", Type: domain.StepTypeThink, Status: domain.StepStatusPending}}, newScriptedExecutor())
+
+	tests := []struct {
+		name string
+		opts domain.TaskOptions
+	}{
+		{name: "deadline in the past", opts: domain.TaskOptions{Deadline: h.clock.Now().Add(-time.Second)}},
+		{name: "deadline now", opts: domain.TaskOptions{Deadline: h.clock.Now()}},
+		{name: "negative timeout", opts: domain.TaskOptions{Timeout: -time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := h.orchestrator.StartTask(context.Background(), "too late", "alice", tt.opts); !errors.Is(err, domain.ErrInvalidDeadline) {
+				t.Errorf("StartTask = %v, want ErrInvalidDeadline", err)
+			}
+		})
+	}
+	if n := h.executor.callCount("a"); n != 0 {
+		t.Errorf("step a ran %d times for rejected tasks", n)
+	}
+}
+
+func TestDeadlineWatcherExpiresSuspendedTasks(t *testing.T) {
+	const (
+		timeout  = 10 * time.Minute
+		interval = time.Minute
+	)
+
+	tests := []struct {
+		name        string
+		gated       bool
+		budget      domain.BudgetLimit
+		suspended   domain.TaskStatus
+		passedEarly bool // The deadline passes before the watcher starts
+	}{
+		{name: "waiting for approval", gated: true, suspended: domain.TaskStatusWaitingApproval},
+		{name: "over budget", budget: domain.BudgetLimit{MaxTokens: 50}, suspended: domain.TaskStatusBudgetExceeded},
+		{name: "deadline passed before the watcher started", gated: true, suspended: domain.TaskStatusWaitingApproval, passedEarly: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := newScriptedExecutor()
+			executor.usage["a"] = domain.TokenUsage{Model: "model", PromptTokens: 100}
+			h := newHarness(t, []domain.Step{
+				{ID: "a", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+				{ID: "b", Type: domain.StepTypeThink, Status: domain.StepStatusPending, DependsOn: []string{"a"}, RequiresApproval: tt.gated},
+			}, executor, withUsage(nil), func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+				deps.Budgets = memory.NewBudgetRepository()
+			})
+			ctx := context.Background()
+
+			task, err := h.orchestrator.StartTask(ctx, "wait too long", "alice", domain.TaskOptions{Timeout: timeout, Budget: tt.budget})
+			if err != nil {
+				t.Fatalf("StartTask = %v", err)
+			}
+			h.waitForStatus(t, task.ID, tt.suspended)
+			// Suspension ended the execution and with it the deadline watch of the execution
+			h.clock.awaitWait(t, timeout)
+
+			if tt.passedEarly {
+				h.clock.Advance(timeout)
+				h.startDeadlineWatcher(t, interval)
+			} else {
+				h.startDeadlineWatcher(t, interval)
+				h.clock.awaitWait(t, interval)
+				h.clock.Advance(interval)
+				// The next wait is requested once the check before the deadline has finished
+				h.clock.awaitWait(t, interval)
+				h.waitForStatus(t, task.ID, tt.suspended)
+				h.clock.Advance(timeout - interval)
+			}
+
+			failed := h.waitForStatus(t, task.ID, domain.TaskStatusFailed)
+			assertDeadlineFailure(t, failed)
+			if step := h.step(t, task.ID, "b"); step.Status != domain.StepStatusSkipped {
+				t.Errorf("step b is %s, want SKIPPED", step.Status)
+			}
+			if n := executor.callCount("b"); n != 0 {
+				t.Errorf("step b ran %d times after the deadline", n)
+			}
+			if !tt.gated {
+				return
+			}
+
+			approvals, err := h.orchestrator.GetTaskApprovals(ctx, task.ID)
+			if err != nil {
+				t.Fatalf("GetTaskApprovals = %v", err)
+			}
+			if len(approvals) != 1 || approvals[0].Status != domain.ApprovalStatusRejected {
+				t.Errorf("approvals = %+v, want the open approval closed", approvals)
+			}
+			if err := h.orchestrator.HandleApproval(ctx, task.ID, "b", true, "bob", "too late"); err == nil {
+				t.Error("HandleApproval after the deadline succeeded")
+			}
+		})
+	}
+}
+
+func TestNewDeadlineWatcherRejectsNonPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if _, err := services.NewDeadlineWatcher(nil, newFakeClock(), nopLogger{}, interval); err == nil {
+			t.Errorf("NewDeadlineWatcher(%v) succeeded", interval)
+		}
+	}
+}
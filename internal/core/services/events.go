@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/execctx"
+)
+
+// llmStreamListener is the ports.LLMStreamListener attached to the context of LLM-backed
+// component calls. It publishes each fragment of generated text as an LLM_TOKEN_DELTA event.
+type llmStreamListener struct {
+	s          *OrchestratorService
+	taskID     string
+	taskStatus domain.TaskStatus // Captured up front: the listener may be called from provider goroutines
+	stepID     string
+	phase      string
+}
+
+// RecordDelta publishes one fragment of generated text; empty fragments are ignored.
+func (l *llmStreamListener) RecordDelta(delta string) {
+	if delta == "" {
+		return
+	}
+
+	l.s.events.Publish(&domain.TaskEvent{
+		TaskID:     l.taskID,
+		Type:       domain.TaskEventLLMTokenDelta,
+		TaskStatus: l.taskStatus,
+		Timestamp:  l.s.clock.Now(),
+		Data: map[string]interface{}{
+			"step_id": l.stepID,
+			"phase":   l.phase,
+			"delta":   delta,
+		},
+	})
+}
+
+// streaming returns a copy of ctx that publishes the text of LLM calls made for a task.
+// It is attached next to the usage meter with the same step and phase attribution.
+// Without a task event bus ctx is returned unchanged.
+func (s *OrchestratorService) streaming(ctx context.Context, task *domain.Task, stepID string, phase string) context.Context {
+	if s.events == nil {
+		return ctx
+	}
+
+	return execctx.WithLLMStreamListener(ctx, &llmStreamListener{
+		s:          s,
+		taskID:     task.ID,
+		taskStatus: task.Status,
+		stepID:     stepID,
+		phase:      phase,
+	})
+}
+
+// publishEvent publishes a recorded audit event to the task's live event stream.
+func (s *OrchestratorService) publishEvent(task *domain.Task, event *domain.AuditEvent) {
+	if s.events == nil {
+		return
+	}
+
+	s.events.Publish(&domain.TaskEvent{
+		TaskID:     task.ID,
+		Type:       event.EventType,
+		TaskStatus: task.Status,
+		Timestamp:  event.Timestamp,
+		Data:       event.Payload,
+	})
+}
+
+// closeTaskEvents ends the live event stream of a task that reached a terminal status.
+func (s *OrchestratorService) closeTaskEvents(task *domain.Task) {
+	if s.events == nil {
+		return
+	}
+	s.events.CloseTask(task.ID)
+}
+
+// SubscribeTaskEvents starts following the live progress of a task.
+// Purpose: Replays the buffered events a client has missed and delivers new ones as they
+//          happen, so clients can show progress without polling. The stream of a task that
+//          has already finished is closed after its buffered events.
+// Inputs:
+//   - ctx: Lifetime of the subscription (e.g., the client's request)
+//   - taskID: Unique identifier of the task
+//   - afterSeq: Seq of the last event the client has seen (0: none)
+// Outputs:
+//   - *domain.TaskEventSubscription: Buffered events after afterSeq and the live channel
+//   - error: Returns error if the task is not found or event streaming is not configured
+func (s *OrchestratorService) SubscribeTaskEvents(ctx context.Context, taskID string, afterSeq int64) (*domain.TaskEventSubscription, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("taskID cannot be empty")
+	}
+	if afterSeq < 0 {
+		return nil, fmt.Errorf("afterSeq cannot be negative: %d", afterSeq)
+	}
+	if s.events == nil {
+		return nil, fmt.Errorf("task event streaming is not configured")
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+
+	subscription := s.events.Subscribe(ctx, taskID, afterSeq)
+
+	// The stream of a task that finished before the bus saw it (e.g., before a restart) was never closed
+	if task.Status.IsTerminal() && !subscription.Closed {
+		s.events.CloseTask(taskID)
+		subscription.Closed = true
+	}
+
+	return subscription, nil
+}
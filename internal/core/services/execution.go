@@ -0,0 +1,717 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/execctx"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// systemActor is the audit actor used for events produced by the orchestrator itself.
+const systemActor = "system"
+
+// behaviorVersion tags audit events with the version of the orchestration logic.
+const behaviorVersion = "v1"
+
+// runTask drives a freshly created task through planning, execution and verification.
+// Purpose: Implements the task lifecycle PLANNING → EXECUTING → VERIFYING → DONE/FAILED.
+//          The input is normalized and the task routed to its agent first; that agent's
+//          Planner sees only the tools the agent may use. Planner and executor failures are recorded on the task (status FAILED) rather
+//          than returned, so callers always get the task back in a consistent state.
+//          A task that has already reached its budget pauses in BUDGET_EXCEEDED before planning.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task to run (mutated in place as it progresses)
+// Outputs:
+//   - error: Returns error if task state could not be persisted,
+//            or the interruption cause if CancelTask or the deadline stopped it
+func (s *OrchestratorService) runTask(ctx context.Context, task *domain.Task) error {
+	// Planning phase (resumed tasks are already PLANNING)
+	if task.Status != domain.TaskStatusPlanning {
+		if err := s.setTaskStatus(ctx, task, domain.TaskStatusPlanning); err != nil {
+			return err
+		}
+	}
+	if reason := s.budgetExceeded(ctx, task); reason != "" {
+		return s.pauseForBudget(ctx, task, reason)
+	}
+
+	if err := s.normalizeTask(ctx, task); err != nil {
+		return err
+	}
+	if err := s.routeTask(ctx, task); err != nil {
+		return err
+	}
+	agent, err := s.resolveAgent(task.TargetAgent)
+	if err != nil {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, fmt.Sprintf("agent unavailable: %v", err))
+	}
+
+	planCtx, meter := metered(s.streaming(execctx.WithAgentProfile(ctx, agent.Profile()), task, "", domain.UsagePhasePlanning))
+	plan, err := agent.Planner().CreatePlan(planCtx, task, s.agentTools(agent))
+	s.chargeUsage(ctx, task, "", domain.UsagePhasePlanning, meter)
+	if isInterrupted(ctx) {
+		return context.Cause(ctx)
+	}
+	if err != nil {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, fmt.Sprintf("planning failed: %v", err))
+	}
+	if plan == nil || len(plan.Steps) == 0 {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, "planner returned an empty plan")
+	}
+	if err := plan.Validate(); err != nil {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, fmt.Sprintf("planner returned an invalid plan: %v", err))
+	}
+
+	if err := s.plans.SavePlan(ctx, plan); err != nil {
+		return fmt.Errorf("failed to save plan: %w", err)
+	}
+
+	task.PlanID = plan.ID
+	s.recordEvent(ctx, task, "PLAN_CREATED", systemActor, map[string]interface{}{
+		"plan_id":      plan.ID,
+		"goal":         plan.Goal,
+		"step_count":   len(plan.Steps),
+		"risk_summary": plan.RiskSummary,
+	})
+
+	return s.executePlan(ctx, task, plan)
+}
+
+// stepOutcome carries the result of a step executed on a worker goroutine.
+type stepOutcome struct {
+	step         *domain.Step
+	result       *domain.StepResult
+	verification *domain.Verification // Set for VERIFY steps whose verifier returned a verdict
+	retries      int                  // Retries made by executeWithRetry, applied by the coordinator
+	err          error
+}
+
+// executePlan runs the pending steps of a plan as a dependency graph and finishes the task.
+// Purpose: Shared by fresh runs and resumptions (e.g., after an approval decision).
+//          Steps whose dependencies are satisfied run concurrently, up to MaxParallelSteps.
+//          Steps that are not PENDING are left alone, so execution continues exactly where
+//          it stopped. Gated steps without an approval wait until no other work is runnable
+//          and then suspend the task in WAITING_APPROVAL. SUB_TASK steps spawn child tasks
+//          and stay IN_PROGRESS until every child has finished; the task suspends in
+//          WAITING_SUBTASKS if nothing else can run meanwhile. VERIFY steps run the Verifier;
+//          a failed verdict (from a VERIFY step or the final verification) triggers replanning.
+//          Executed and VERIFY steps, and the final verification, only start within budget;
+//          otherwise the running steps finish and the task pauses in BUDGET_EXCEEDED.
+//          All task and plan writes happen on the calling goroutine; workers only execute.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task being executed (mutated in place)
+//   - plan: The task's plan with current step statuses
+// Outputs:
+//   - error: Returns error if task, plan or approval state could not be persisted,
+//            or the interruption cause if CancelTask or the deadline interrupted the execution
+func (s *OrchestratorService) executePlan(ctx context.Context, task *domain.Task, plan *domain.Plan) error {
+	// Execution phase
+	if task.Status != domain.TaskStatusExecuting {
+		if err := s.setTaskStatus(ctx, task, domain.TaskStatusExecuting); err != nil {
+			return err
+		}
+	}
+
+	limit := s.cfg.MaxParallelSteps
+	if limit < 1 {
+		limit = 1
+	}
+
+	outcomes := make(chan stepOutcome)
+	inFlight := 0
+	failure := ""
+	overBudget := ""
+	var firstErr error
+	var gated *domain.Step
+	var unmet *domain.Verification
+
+	// record persists a finished step and notes the first failure
+	record := func(outcome stepOutcome) {
+		if outcome.retries > 0 {
+			outcome.step.RetryCount += outcome.retries
+			if err := s.plans.UpdateStep(ctx, plan.ID, outcome.step); err != nil && outcome.err == nil {
+				outcome.err = fmt.Errorf("failed to save retry count of step %s: %w", outcome.step.ID, err)
+			}
+		}
+		if outcome.err != nil {
+			if firstErr == nil {
+				firstErr = outcome.err
+			}
+			return
+		}
+		if err := s.finishStep(ctx, task, plan, outcome.step, outcome.result); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return
+		}
+		if outcome.verification != nil {
+			s.recordVerification(ctx, task, plan, outcome.step.ID, outcome.verification)
+		}
+		if !outcome.result.Success && failure == "" {
+			failure = fmt.Sprintf("step %s failed: %s", outcome.step.ID, outcome.result.ErrorMessage)
+			if outcome.verification != nil {
+				unmet = outcome.verification
+			}
+		}
+	}
+
+	for {
+		// Launch every ready step while capacity allows
+		progressed := false
+		gated = nil
+		if failure == "" && firstErr == nil && overBudget == "" && !isInterrupted(ctx) {
+			for _, step := range plan.ReadySteps() {
+				if inFlight >= limit {
+					break
+				}
+
+				// Human-in-the-loop gate
+				if step.NeedsApproval() {
+					approval, err := s.findStepApproval(ctx, task.ID, step.ID)
+					if err != nil {
+						firstErr = err
+						break
+					}
+					if approval == nil || approval.Status != domain.ApprovalStatusApproved {
+						if gated == nil {
+							gated = step
+						}
+						continue
+					}
+					if step.Type == domain.StepTypeApprovalGate {
+						if err := s.completeApprovalGate(ctx, task, plan.ID, step, approval); err != nil {
+							firstErr = err
+							break
+						}
+						progressed = true
+						continue
+					}
+				}
+
+				// Steps that may call the LLM only start within budget
+				if step.Type != domain.StepTypeSubTask {
+					if overBudget = s.budgetExceeded(ctx, task); overBudget != "" {
+						break
+					}
+				}
+
+				if err := s.startStep(ctx, task, plan.ID, step); err != nil {
+					firstErr = err
+					break
+				}
+				progressed = true
+
+				// Sub-task steps spawn children and are collected once they have finished
+				if step.Type == domain.StepTypeSubTask {
+					result, err := s.spawnSubTasks(ctx, task, plan.ID, step)
+					if err != nil {
+						firstErr = err
+						break
+					}
+					if result != nil {
+						record(stepOutcome{step: step, result: result})
+						break
+					}
+					continue
+				}
+				inFlight++
+
+				snapshot := task.Clone()
+				if step.Type == domain.StepTypeVerify {
+					results, err := s.collectResults(ctx, plan)
+					if err != nil {
+						firstErr = err
+						break
+					}
+					planSnapshot := *plan
+					planSnapshot.Steps = append([]domain.Step(nil), plan.Steps...)
+					go func(step *domain.Step) {
+						stepCtx, meter := metered(s.streaming(ctx, snapshot, step.ID, domain.UsagePhaseVerification))
+						result, verification, err := s.verifyStep(stepCtx, snapshot, &planSnapshot, results, step)
+						s.chargeUsage(ctx, snapshot, step.ID, domain.UsagePhaseVerification, meter)
+						outcomes <- stepOutcome{step: step, result: result, verification: verification, err: err}
+					}(step)
+					continue
+				}
+
+				// The worker executes a copy; its retries are applied to the plan by record
+				work := *step
+				go func(step *domain.Step) {
+					stepCtx, meter := metered(s.streaming(ctx, snapshot, step.ID, domain.UsagePhaseExecution))
+					result, retries, err := s.executeWithRetry(stepCtx, snapshot, &work)
+					s.chargeUsage(ctx, snapshot, step.ID, domain.UsagePhaseExecution, meter)
+					outcomes <- stepOutcome{step: step, result: result, retries: retries, err: err}
+				}(step)
+			}
+		}
+
+		if inFlight == 0 {
+			if progressed && failure == "" && firstErr == nil {
+				continue
+			}
+
+			// Nothing else can run: collect sub-task steps whose children have finished
+			if failure == "" && firstErr == nil && !isInterrupted(ctx) {
+				settled, err := s.collectSubTasks(ctx, plan)
+				if err != nil {
+					firstErr = err
+					break
+				}
+				for _, outcome := range settled {
+					record(outcome)
+				}
+				if len(settled) > 0 {
+					continue
+				}
+			}
+			break
+		}
+
+		// Wait for the next step to finish and record it
+		inFlight--
+		record(<-outcomes)
+	}
+
+	if isInterrupted(ctx) {
+		return context.Cause(ctx)
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	if unmet != nil {
+		return s.replan(ctx, task, plan, unmet)
+	}
+	if failure != "" {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, failure)
+	}
+	if overBudget != "" {
+		return s.pauseForBudget(ctx, task, overBudget)
+	}
+	if gated != nil {
+		return s.requestApproval(ctx, task, gated)
+	}
+	if len(plan.WaitingSubTaskSteps()) > 0 {
+		return s.awaitSubTasks(ctx, task, plan)
+	}
+	if plan.HasPendingSteps() {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, "plan has pending steps that can never run")
+	}
+
+	// Verification phase
+	if reason := s.budgetExceeded(ctx, task); reason != "" {
+		return s.pauseForBudget(ctx, task, reason)
+	}
+	return s.verifyPlan(ctx, task, plan)
+}
+
+// startStep marks a step IN_PROGRESS before it is handed to a worker.
+// Purpose: Points CurrentStepID at the most recently started step and emits STEP_STARTED.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The parent task (CurrentStepID is updated)
+//   - planID: Unique identifier of the plan owning the step
+//   - step: The step about to run (Status is updated in place and persisted)
+// Outputs:
+//   - error: Returns error if task or step state could not be persisted
+func (s *OrchestratorService) startStep(ctx context.Context, task *domain.Task, planID string, step *domain.Step) error {
+	task.CurrentStepID = step.ID
+	task.UpdatedAt = s.clock.Now()
+	if err := s.repo.SaveTask(ctx, task); err != nil {
+		return fmt.Errorf("failed to save task before step %s: %w", step.ID, err)
+	}
+
+	if err := s.setStepStatus(ctx, task, planID, step, domain.StepStatusInProgress); err != nil {
+		return err
+	}
+	s.recordEvent(ctx, task, "STEP_STARTED", systemActor, map[string]interface{}{
+		"step_id":    step.ID,
+		"title":      step.Title,
+		"step_type":  string(step.Type),
+		"tool_name":  step.ToolName,
+		"depends_on": step.DependsOn,
+	})
+
+	return nil
+}
+
+// finishStep records the outcome of an executed step.
+// Purpose: Persists the StepResult, marks the step COMPLETED or FAILED and emits the
+//          matching audit event. Outputs above ResultInlineMaxBytes are offloaded to the
+//          ResultStore first. A completed DECISION step with branches then routes
+//          execution, skipping the steps of untaken branches.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The parent task
+//   - plan: The plan owning the step (statuses of skipped branch steps are updated)
+//   - step: The executed step (Status is updated in place and persisted)
+//   - result: The execution result returned by executeWithRetry
+// Outputs:
+//   - error: Returns error if step state could not be persisted
+func (s *OrchestratorService) finishStep(ctx context.Context, task *domain.Task, plan *domain.Plan, step *domain.Step, result *domain.StepResult) error {
+	planID := plan.ID
+	result.StepID = step.ID
+
+	// A decision whose output matches no branch cannot route execution
+	branch := -1
+	if result.Success && len(step.Branches) > 0 {
+		selected, err := step.SelectBranch(result.Output)
+		if err != nil {
+			result.Success = false
+			result.ErrorMessage = err.Error()
+		}
+		branch = selected
+	}
+
+	outputSize := len(result.Output)
+	s.offloadResult(ctx, task, planID, step, result)
+
+	if err := s.plans.SaveStepResult(ctx, planID, result); err != nil {
+		return fmt.Errorf("failed to save result of step %s: %w", step.ID, err)
+	}
+
+	if !result.Success {
+		if err := s.setStepStatus(ctx, task, planID, step, domain.StepStatusFailed); err != nil {
+			return err
+		}
+		s.recordEvent(ctx, task, "STEP_FAILED", systemActor, map[string]interface{}{
+			"step_id":     step.ID,
+			"error":       result.ErrorMessage,
+			"duration_ms": result.DurationMs,
+		})
+		return nil
+	}
+
+	if err := s.setStepStatus(ctx, task, planID, step, domain.StepStatusCompleted); err != nil {
+		return err
+	}
+	s.recordEvent(ctx, task, "STEP_COMPLETED", systemActor, map[string]interface{}{
+		"step_id":     step.ID,
+		"duration_ms": result.DurationMs,
+		"output_size": outputSize,
+	})
+
+	if branch >= 0 {
+		return s.takeBranch(ctx, task, plan, step, branch)
+	}
+
+	return nil
+}
+
+// executeWithRetry invokes the executor for a step, retrying failures per the retry policy.
+// Purpose: Absorbs flaky tools and rate-limited LLM calls. Each retry increments
+//          Step.RetryCount, emits STEP_RETRIED, and waits an exponential backoff on the Clock.
+//          Runs on a worker goroutine and persists nothing: the caller applies the returned
+//          retry count to the plan.
+//          Every attempt is bounded by the step timeout; a timed-out final attempt yields a
+//          failed result with ErrorCode STEP_TIMEOUT. The step runs on the Executor of the
+//          task's agent; calling a tool outside the agent's AllowedTools fails the step with
+//          ErrorCode TOOL_NOT_ALLOWED without executing it (recorded as a REJECTED tool call).
+//          Tools the executor runs through the ports.ToolInvoker in ctx are recorded per attempt.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The parent task
+//   - step: A copy of the step to execute (RetryCount is updated in place)
+// Outputs:
+//   - *domain.StepResult: Result of the last attempt; executor errors become a failed result
+//   - int: Number of retries made (also when interrupted)
+//   - error: Returns the interruption cause if CancelTask or the deadline stopped the step
+func (s *OrchestratorService) executeWithRetry(ctx context.Context, task *domain.Task, step *domain.Step) (*domain.StepResult, int, error) {
+	policy := s.cfg.RetryPolicy
+	if step.RetryPolicy != nil {
+		policy = *step.RetryPolicy
+	}
+
+	timeout := step.Timeout(s.cfg.StepTimeout)
+
+	// The task's agent executes the step, and only with tools it may use
+	agent, err := s.resolveAgent(task.TargetAgent)
+	if err != nil {
+		return &domain.StepResult{StepID: step.ID, Success: false, ErrorMessage: fmt.Sprintf("agent unavailable: %v", err)}, 0, nil
+	}
+	profile := agent.Profile()
+	if step.ToolName != "" && !profile.AllowsTool(step.ToolName) {
+		message := fmt.Sprintf("%v: agent %s may not call %s", domain.ErrToolNotAllowed, profile.Name, step.ToolName)
+		s.recordRejectedToolCall(ctx, task, step, profile, message)
+		return &domain.StepResult{
+			StepID:       step.ID,
+			Success:      false,
+			ErrorMessage: message,
+			ErrorCode:    domain.ErrorCodeToolNotAllowed,
+		}, 0, nil
+	}
+	ctx = execctx.WithAgentProfile(ctx, agent.Profile())
+
+	retries := 0
+	for attempt := 1; ; attempt++ {
+		attemptCtx := s.invokingTools(ctx, task, step, profile, attempt)
+		result, err := s.executeAttempt(attemptCtx, agent.Executor(), task, step, timeout)
+		if isInterrupted(ctx) {
+			return nil, retries, context.Cause(ctx)
+		}
+		if err == nil && result == nil {
+			err = fmt.Errorf("executor returned no result")
+		}
+		if err == nil && !result.Success {
+			err = errors.New(result.ErrorMessage)
+		}
+		if err == nil {
+			return result, retries, nil
+		}
+		if result == nil || result.Success || errors.Is(err, domain.ErrStepTimeout) {
+			result = &domain.StepResult{StepID: step.ID, Success: false, ErrorMessage: err.Error()}
+		}
+		if errors.Is(err, domain.ErrStepTimeout) {
+			result.ErrorCode = domain.ErrorCodeStepTimeout
+		}
+
+		if !policy.ShouldRetry(attempt, err) {
+			return result, retries, nil
+		}
+
+		delay := policy.Backoff(attempt, s.jitter())
+		retries++
+		step.RetryCount++
+		s.recordEvent(ctx, task, "STEP_RETRIED", systemActor, map[string]interface{}{
+			"step_id":      step.ID,
+			"attempt":      attempt,
+			"max_attempts": policy.MaxAttempts,
+			"retry_count":  step.RetryCount,
+			"delay_ms":     delay.Milliseconds(),
+			"error":        err.Error(),
+		})
+
+		select {
+		case <-s.clock.After(delay):
+		case <-ctx.Done():
+			if isInterrupted(ctx) {
+				return nil, retries, context.Cause(ctx)
+			}
+			result.ErrorMessage = fmt.Sprintf("%s (retry aborted: %v)", result.ErrorMessage, ctx.Err())
+			return result, retries, nil
+		}
+	}
+}
+
+// jitter returns the random value spreading a retry delay; without a RandomSource the
+// midpoint is used, so delays are not randomized.
+func (s *OrchestratorService) jitter() float64 {
+	if s.random == nil {
+		return 0.5
+	}
+	return s.random.Float64()
+}
+
+// executeAttempt runs a single executor attempt, bounded by the step timeout.
+// Purpose: Derives a per-attempt context that expires after timeout on the Clock. An attempt
+//          cut short by the timeout yields an error wrapping domain.ErrStepTimeout, whatever
+//          the executor returned.
+// Inputs:
+//   - ctx: Execution context of the task
+//   - executor: Executor of the task's agent
+//   - task: The parent task
+//   - step: The step to execute
+//   - timeout: Per-attempt limit (0 = unlimited)
+// Outputs:
+//   - *domain.StepResult: The executor result
+//   - error: The executor error, an error wrapping domain.ErrStepTimeout, or one wrapping
+//            domain.ErrPermanent if the executor panicked
+func (s *OrchestratorService) executeAttempt(ctx context.Context, executor ports.Executor, task *domain.Task, step *domain.Step, timeout time.Duration) (result *domain.StepResult, err error) {
+	// Steps run on their own goroutines, so an executor panic would otherwise end the process
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("executor panicked: %v: %w", r, domain.ErrPermanent)
+		}
+	}()
+
+	if timeout <= 0 {
+		return executor.ExecuteStep(ctx, task, step)
+	}
+
+	attemptCtx, release := s.afterClock(ctx, timeout, domain.ErrStepTimeout)
+	defer release()
+
+	result, err = executor.ExecuteStep(attemptCtx, task, step)
+	if errors.Is(context.Cause(attemptCtx), domain.ErrStepTimeout) {
+		return nil, fmt.Errorf("%w after %s", domain.ErrStepTimeout, timeout)
+	}
+
+	return result, err
+}
+
+// setStepStatus moves a step to a new status and persists it in the plan repository.
+// Purpose: Keeps the stored plan in sync with in-memory step progress, enforcing the
+//          step state machine and emitting STEP_STATUS_CHANGED.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The parent task (used for the audit event)
+//   - planID: Unique identifier of the plan owning the step
+//   - step: The step to update (mutated in place)
+//   - status: The new step status
+// Outputs:
+//   - error: Returns error if the step could not be persisted.
+//            Wraps domain.ErrInvalidTransition if the state machine forbids the change.
+func (s *OrchestratorService) setStepStatus(ctx context.Context, task *domain.Task, planID string, step *domain.Step, status domain.StepStatus) error {
+	from := step.Status
+	if err := step.Transition(status); err != nil {
+		return fmt.Errorf("failed to update step %s: %w", step.ID, err)
+	}
+
+	if err := s.plans.UpdateStep(ctx, planID, step); err != nil {
+		return fmt.Errorf("failed to save step %s with status %s: %w", step.ID, status, err)
+	}
+
+	if from != status {
+		s.recordEvent(ctx, task, "STEP_STATUS_CHANGED", systemActor, map[string]interface{}{
+			"plan_id": planID,
+			"step_id": step.ID,
+			"from":    string(from),
+			"to":      string(status),
+		})
+	}
+
+	return nil
+}
+
+// setTaskStatus moves a task to a new non-terminal status and persists it.
+// Purpose: Central place for lifecycle transitions so UpdatedAt is always maintained
+//          and the task state machine is enforced.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task to update (mutated in place)
+//   - status: The new status
+// Outputs:
+//   - error: Returns error if the task could not be persisted.
+//            Wraps domain.ErrInvalidTransition if the state machine forbids the change.
+func (s *OrchestratorService) setTaskStatus(ctx context.Context, task *domain.Task, status domain.TaskStatus) error {
+	from := task.Status
+	if err := task.Transition(status); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+	task.UpdatedAt = s.clock.Now()
+
+	if err := s.repo.SaveTask(ctx, task); err != nil {
+		return fmt.Errorf("failed to save task with status %s: %w", status, err)
+	}
+
+	s.recordTaskTransition(ctx, task, from)
+
+	return nil
+}
+
+// finishTask moves a task into a terminal status and emits TASK_FINISHED.
+// Purpose: Sets FinishedAt, records the failure reason (if any) in task metadata,
+//          persists the task and logs the final outcome. A task that did not finish DONE
+//          cancels its unfinished sub-tasks; a sub-task wakes its waiting parent.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task to finish (mutated in place)
+//   - status: Terminal status (DONE, FAILED or CANCELED)
+//   - reason: Human-readable failure reason (empty for successful completion)
+// Outputs:
+//   - error: Returns error if the task could not be persisted.
+//            Wraps domain.ErrInvalidTransition if the task is already finished.
+func (s *OrchestratorService) finishTask(ctx context.Context, task *domain.Task, status domain.TaskStatus, reason string) error {
+	from := task.Status
+	if err := task.Transition(status); err != nil {
+		return fmt.Errorf("failed to finish task: %w", err)
+	}
+
+	now := s.clock.Now()
+	task.UpdatedAt = now
+	task.FinishedAt = now
+	if reason != "" {
+		if task.Metadata == nil {
+			task.Metadata = make(map[string]string)
+		}
+		task.Metadata["failure_reason"] = reason
+	}
+	s.applyUsage(ctx, task)
+	s.applyArtifacts(ctx, task)
+
+	if err := s.repo.SaveTask(ctx, task); err != nil {
+		return fmt.Errorf("failed to save finished task: %w", err)
+	}
+
+	s.recordTaskTransition(ctx, task, from)
+
+	s.recordEvent(ctx, task, "TASK_FINISHED", systemActor, map[string]interface{}{
+		"task_id": task.ID,
+		"status":  string(status),
+		"reason":  reason,
+	})
+	s.closeTaskEvents(task)
+
+	// Propagate the outcome through the task tree
+	if status != domain.TaskStatusDone {
+		s.cancelSubTasks(ctx, task, reason)
+	}
+	if task.ParentTaskID != "" {
+		s.wakeParent(ctx, task)
+	}
+
+	return nil
+}
+
+// availableTools returns the tool catalogue offered to the planner.
+// Purpose: Tolerates a missing tool registry so planners that need no tools still work.
+// Inputs: None
+// Outputs:
+//   - []domain.ToolMetadata: Registered tools, or nil if no registry is configured
+func (s *OrchestratorService) availableTools() []domain.ToolMetadata {
+	if s.tools == nil {
+		return nil
+	}
+	return s.tools.ListTools()
+}
+
+// recordTaskTransition emits TASK_STATUS_CHANGED if the task's status differs from from.
+func (s *OrchestratorService) recordTaskTransition(ctx context.Context, task *domain.Task, from domain.TaskStatus) {
+	if from == task.Status {
+		return
+	}
+	s.recordEvent(ctx, task, "TASK_STATUS_CHANGED", systemActor, map[string]interface{}{
+		"task_id": task.ID,
+		"from":    string(from),
+		"to":      string(task.Status),
+	})
+}
+
+// recordEvent persists an audit event for the given task and publishes it to the task's live stream.
+// Purpose: Builds the AuditEvent envelope (ID, timestamp, correlation) in one place.
+//          Audit failures are logged as warnings and never block task execution.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task the event belongs to
+//   - eventType: Event name (e.g., "TASK_CREATED", "STEP_STARTED")
+//   - actor: User ID or systemActor responsible for the event
+//   - payload: Event-specific data
+// Outputs: None
+func (s *OrchestratorService) recordEvent(ctx context.Context, task *domain.Task, eventType string, actor string, payload map[string]interface{}) {
+	event := &domain.AuditEvent{
+		ID:              s.idGen.Generate(),
+		TaskID:          task.ID,
+		CorrelationID:   task.ID,
+		Timestamp:       s.clock.Now(),
+		EventType:       eventType,
+		Actor:           actor,
+		BehaviorVersion: behaviorVersion,
+		Payload:         payload,
+	}
+
+	if err := s.audit.SaveEvent(ctx, event); err != nil {
+		s.logger.Warn("failed to save audit event", map[string]interface{}{
+			"error":      err.Error(),
+			"task_id":    task.ID,
+			"event_type": eventType,
+		})
+	}
+
+	s.publishEvent(task, event)
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// claimIdempotencyKey reserves a request's idempotency key for the task about to be created.
+// Purpose: Detects repeats of a request so retried submissions do not create duplicate tasks.
+//          The key is stored with a fingerprint of the request for IdempotencyKeyTTL.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier the new task will get if the key is free
+//   - input: Raw user request of the submission
+//   - userID: Owner of the key
+//   - opts: Creation settings of the submission (including IdempotencyKey)
+// Outputs:
+//   - *domain.Task: The task created by an earlier request with the same key, or nil if the
+//                   key was claimed for taskID
+//   - error: Returns error wrapping domain.ErrIdempotencyKeyReused if the earlier request
+//            differs, domain.ErrIdempotencyKeyInUse if its task is not stored yet,
+//            or an error if the key could not be claimed
+func (s *OrchestratorService) claimIdempotencyKey(ctx context.Context, taskID string, input string, userID string, opts domain.TaskOptions) (*domain.Task, error) {
+	now := s.clock.Now()
+	record := &domain.IdempotencyRecord{
+		UserID:      userID,
+		Key:         opts.IdempotencyKey,
+		Fingerprint: domain.TaskRequestFingerprint(input, opts),
+		TaskID:      taskID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.cfg.IdempotencyKeyTTL),
+	}
+
+	existing, err := s.idempotency.ClaimKey(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+	if existing == nil {
+		return nil, nil
+	}
+	if existing.Fingerprint != record.Fingerprint {
+		return nil, fmt.Errorf("%w: %s was first used for task %s", domain.ErrIdempotencyKeyReused, existing.Key, existing.TaskID)
+	}
+
+	task, err := s.repo.GetTask(ctx, existing.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: task %s: %w", domain.ErrIdempotencyKeyInUse, existing.TaskID, err)
+	}
+
+	s.recordEvent(ctx, task, "TASK_CREATE_REPLAYED", userID, map[string]interface{}{
+		"task_id":         task.ID,
+		"idempotency_key": existing.Key,
+	})
+
+	return task, nil
+}
+
+// releaseIdempotencyKey frees a claimed key after its task could not be created.
+// Failures are logged: the key then stays blocked until its record expires.
+func (s *OrchestratorService) releaseIdempotencyKey(ctx context.Context, userID string, key string) {
+	if err := s.idempotency.ReleaseKey(ctx, userID, key); err != nil {
+		s.logger.Error("failed to release idempotency key", err, map[string]interface{}{
+			"user_id": userID,
+			"key":     key,
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/adapters/memory"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// withIdempotency keeps idempotency keys in memory on the harness clock.
+func withIdempotency() harnessOption {
+	return func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		deps.Idempotency = memory.NewIdempotencyRepository(deps.Clock)
+	}
+}
+
+// taskCount returns the number of tasks stored for a user.
+func (h *harness) taskCount(t *testing.T, userID string) int {
+	t.Helper()
+	tasks, err := h.deps.Repo.ListTasks(context.Background(), domain.TaskFilter{UserID: userID})
+	if err != nil {
+		t.Fatalf("ListTasks = %v", err)
+	}
+	return len(tasks)
+}
+
+func TestOrchestratorStartTaskIsIdempotent(t *testing.T) {
+	keyed := func(opts domain.TaskOptions) domain.TaskOptions {
+		opts.IdempotencyKey = "request-1"
+		return opts
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		userID    string
+		opts      domain.TaskOptions
+		advance   time.Duration // Clock advance before the repeat
+		wantSame  bool
+		wantErr   error
+		wantTasks map[string]int // Tasks per user after the repeat
+	}{
+		{
+			name:      "repeat returns the original task",
+			input:     "write a report",
+			userID:    "alice",
+			opts:      keyed(domain.TaskOptions{}),
+			wantSame:  true,
+			wantTasks: map[string]int{"alice": 1},
+		},
+		{
+			name:      "different input conflicts",
+			input:     "write a poem",
+			userID:    "alice",
+			opts:      keyed(domain.TaskOptions{}),
+			wantErr:   domain.ErrIdempotencyKeyReused,
+			wantTasks: map[string]int{"alice": 1},
+		},
+		{
+			name:      "different options conflict",
+			input:     "write a report",
+			userID:    "alice",
+			opts:      keyed(domain.TaskOptions{Priority: domain.TaskPriorityUrgent}),
+			wantErr:   domain.ErrIdempotencyKeyReused,
+			wantTasks: map[string]int{"alice": 1},
+		},
+		{
+			name:      "keys are scoped per user",
+			input:     "write a report",
+			userID:    "bob",
+			opts:      keyed(domain.TaskOptions{}),
+			wantTasks: map[string]int{"alice": 1, "bob": 1},
+		},
+		{
+			name:      "expired key creates a new task",
+			input:     "write a report",
+			userID:    "alice",
+			opts:      keyed(domain.TaskOptions{}),
+			advance:   services.DefaultOrchestratorConfig().IdempotencyKeyTTL,
+			wantTasks: map[string]int{"alice": 2},
+		},
+		{
+			name:      "without a key every request creates a task",
+			input:     "write a report",
+			userID:    "alice",
+			wantTasks: map[string]int{"alice": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHarness(t, thinkStep("a"), newScriptedExecutor(), withIdempotency())
+			ctx := context.Background()
+
+			original, err := h.orchestrator.StartTask(ctx, "write a report", "alice", keyed(domain.TaskOptions{}))
+			if err != nil {
+				t.Fatalf("first StartTask = %v", err)
+			}
+			h.clock.Advance(tt.advance)
+
+			repeat, err := h.orchestrator.StartTask(ctx, tt.input, tt.userID, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("repeated StartTask = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (repeat.ID == original.ID) != tt.wantSame {
+				t.Errorf("repeat returned task %s for original %s, want same = %v", repeat.ID, original.ID, tt.wantSame)
+			}
+			for userID, want := range tt.wantTasks {
+				if n := h.taskCount(t, userID); n != want {
+					t.Errorf("tasks of %s = %d, want %d", userID, n, want)
+				}
+			}
+			wantReplays := 0
+			if tt.wantSame {
+				wantReplays = 1
+			}
+			if n := h.audit.count("TASK_CREATE_REPLAYED"); n != wantReplays {
+				t.Errorf("TASK_CREATE_REPLAYED events = %d, want %d", n, wantReplays)
+			}
+		})
+	}
+}
+
+func TestOrchestratorReleasesKeyOfRejectedRequest(t *testing.T) {
+	h := newHarness(t, thinkStep("a"), newScriptedExecutor(), withIdempotency())
+	ctx := context.Background()
+
+	rejected := domain.TaskOptions{IdempotencyKey: "request-1", Priority: "SOMEDAY"}
+	if _, err := h.orchestrator.StartTask(ctx, "write a report", "alice", rejected); !errors.Is(err, domain.ErrInvalidPriority) {
+		t.Fatalf("StartTask = %v, want ErrInvalidPriority", err)
+	}
+
+	// The corrected retry is a different request, but no task holds the key
+	task, err := h.orchestrator.StartTask(ctx, "write a report", "alice", domain.TaskOptions{IdempotencyKey: "request-1"})
+	if err != nil {
+		t.Fatalf("corrected StartTask = %v", err)
+	}
+	if n := h.taskCount(t, "alice"); n != 1 {
+		t.Errorf("tasks = %d, want only %s", n, task.ID)
+	}
+}
+
+func TestOrchestratorConcurrentStartTaskCreatesOneTask(t *testing.T) {
+	const requests = 16
+	h := newHarness(t, thinkStep("a"), newScriptedExecutor(), withIdempotency())
+	opts := domain.TaskOptions{IdempotencyKey: "request-1"}
+
+	var wg sync.WaitGroup
+	ids := make([]string, requests)
+	errs := make([]error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			task, err := h.orchestrator.StartTask(context.Background(), "write a report", "alice", opts)
+			errs[i] = err
+			if err == nil {
+				ids[i] = task.ID
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if n := h.taskCount(t, "alice"); n != 1 {
+		t.Fatalf("tasks = %d, want exactly 1", n)
+	}
+	created := ""
+	for i := 0; i < requests; i++ {
+		switch {
+		case errs[i] == nil && created == "":
+			created = ids[i]
+		case errs[i] == nil && ids[i] != created:
+			t.Errorf("request %d got task %s, want %s", i, ids[i], created)
+		case errs[i] != nil && !errors.Is(errs[i], domain.ErrIdempotencyKeyInUse):
+			// Repeats that race the creation may only be told to retry
+			t.Errorf("request %d = %v, want the task or ErrIdempotencyKeyInUse", i, errs[i])
+		}
+	}
+	if created == "" {
+		t.Fatal("no request returned the task")
+	}
+	h.waitForStatus(t, created, domain.TaskStatusDone)
+	if n := h.executor.callCount("a"); n != 1 {
+		t.Errorf("step a ran %d times, want 1", n)
+	}
+}
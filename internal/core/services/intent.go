@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// normalizeTask runs the intent normalization stage before a task is routed and planned.
+// Purpose: Replaces the raw copy of the input in Task.NormalizedIntent with the cleaned intent
+//          and stores language, confidence and entities in Task.Metadata, audited as
+//          INTENT_NORMALIZED. Normalization is best effort: without a normalizer, or if it
+//          fails, the task is planned from its raw input. Tasks that were already normalized
+//          (e.g., on recovery) are left alone.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task to normalize (mutated in place)
+// Outputs:
+//   - error: Returns error if the task could not be persisted,
+//            or the interruption cause if CancelTask or the deadline stopped normalization
+func (s *OrchestratorService) normalizeTask(ctx context.Context, task *domain.Task) error {
+	if s.normalizer == nil || task.Metadata[domain.MetadataIntentConfidence] != "" {
+		return nil
+	}
+
+	normalizeCtx, meter := metered(s.streaming(ctx, task, "", domain.UsagePhaseNormalization))
+	intent, err := s.normalizer.Normalize(normalizeCtx, task)
+	s.chargeUsage(ctx, task, "", domain.UsagePhaseNormalization, meter)
+	if isInterrupted(ctx) {
+		return context.Cause(ctx)
+	}
+	if err == nil && intent == nil {
+		err = fmt.Errorf("normalizer returned no intent")
+	}
+	if err == nil {
+		err = intent.Validate()
+	}
+	if err != nil {
+		s.logger.Warn("intent normalization failed, planning from raw input", map[string]interface{}{
+			"error":   err.Error(),
+			"task_id": task.ID,
+		})
+		return nil
+	}
+
+	intent.ApplyTo(task)
+	task.UpdatedAt = s.clock.Now()
+	if err := s.repo.SaveTask(ctx, task); err != nil {
+		return fmt.Errorf("failed to save normalized task: %w", err)
+	}
+
+	s.recordEvent(ctx, task, "INTENT_NORMALIZED", systemActor, map[string]interface{}{
+		"task_id":           task.ID,
+		"normalized_intent": intent.Text,
+		"language":          intent.Language,
+		"confidence":        intent.Confidence,
+		"entities":          intent.Entities,
+	})
+
+	return nil
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/JAROBOTAI/jaro/internal/core/domain"
 	"github.com/JAROBOTAI/jaro/internal/core/ports"
@@ -12,58 +14,168 @@ import (
 // It coordinates task lifecycle management, plan execution, and approval workflows.
 // This service follows the Hexagonal Architecture pattern by depending only on ports (interfaces).
 type OrchestratorService struct {
-	planner   ports.Planner
-	executor  ports.Executor
-	repo      ports.TaskRepository
-	audit     ports.AuditRepository
-	clock     ports.Clock
-	idGen     ports.IDGenerator
-	logger    ports.Logger
+	planner     ports.Planner
+	executor    ports.Executor
+	verifier    ports.Verifier
+	tools       ports.ToolRegistry
+	normalizer  ports.IntentNormalizer
+	agents      ports.AgentRegistry
+	router      ports.AgentRouter
+	repo        ports.TaskRepository
+	plans       ports.PlanRepository
+	approvals   ports.ApprovalRepository
+	idempotency ports.IdempotencyRepository
+	usage       ports.UsageRepository
+	budgets     ports.BudgetRepository
+	artifacts   ports.ArtifactStore
+	results     ports.ResultStore
+	toolCalls   ports.ToolCallRepository
+	events      ports.TaskEventBus
+	queue       ports.TaskQueue
+	audit       ports.AuditRepository
+	clock       ports.Clock
+	idGen       ports.IDGenerator
+	random      ports.RandomSource
+	logger      ports.Logger
+	cfg         OrchestratorConfig
+
+	mu        sync.Mutex
+	running   map[string]*execution
+	recovered map[string]struct{} // Tasks queued by RecoverTasks that no worker has resumed yet
+
+	subTaskMu  sync.Mutex // Serializes suspension on sub-tasks with the wake-up by finishing children
+	budgetMu   sync.Mutex // Serializes pausing over budget with budget updates that resume tasks
+	approvalMu sync.Mutex // Serializes approval decisions with the resumes that apply them
+}
+
+// OrchestratorDeps holds the ports the orchestrator depends on.
+// Planner, Executor, Verifier, Repo, Plans, Approvals, Queue, Audit, Clock, IDGen and Logger
+// are required; every other field is optional and nil disables the feature it backs.
+type OrchestratorDeps struct {
+	Planner    ports.Planner          // Generates execution plans
+	Executor   ports.Executor         // Runs plan steps
+	Verifier   ports.Verifier         // Checks VERIFY steps and the verification phase
+	Tools      ports.ToolRegistry     // Tools offered to the planner (nil offers none)
+	Normalizer ports.IntentNormalizer // Runs before planning (nil plans on the raw input)
+	Agents     ports.AgentRegistry    // Specialized agents (nil for CORE only)
+	Router     ports.AgentRouter      // Classifies tasks without a TargetAgent (nil uses CORE)
+
+	Repo        ports.TaskRepository        // Task persistence
+	Plans       ports.PlanRepository        // Plan and step persistence
+	Approvals   ports.ApprovalRepository    // Approval requests
+	Idempotency ports.IdempotencyRepository // Idempotency keys (nil ignores TaskOptions.IdempotencyKey)
+	Usage       ports.UsageRepository       // LLM usage ledger (nil disables usage accounting and budget enforcement)
+	Budgets     ports.BudgetRepository      // Per-user budgets (nil applies cfg.DefaultUserBudget to every user)
+	Artifacts   ports.ArtifactStore         // Files attached to tasks (nil disables artifacts)
+	Results     ports.ResultStore           // Large step outputs (nil keeps all outputs inline)
+	ToolCalls   ports.ToolCallRepository    // Tool invocations (nil disables tool call recording)
+	Events      ports.TaskEventBus          // Live task progress (nil disables event streaming)
+	Queue       ports.TaskQueue             // Hands new tasks to the worker pool
+	Audit       ports.AuditRepository       // Audit logging
+
+	Clock  ports.Clock        // Time operations
+	IDGen  ports.IDGenerator  // ID generation
+	Random ports.RandomSource // Retry backoff jitter (nil applies no jitter)
+	Logger ports.Logger       // Structured logging
+}
+
+// validate reports the first required dependency that is missing.
+func (d OrchestratorDeps) validate() error {
+	required := []struct {
+		name    string
+		missing bool
+	}{
+		{"Planner", d.Planner == nil},
+		{"Executor", d.Executor == nil},
+		{"Verifier", d.Verifier == nil},
+		{"Repo", d.Repo == nil},
+		{"Plans", d.Plans == nil},
+		{"Approvals", d.Approvals == nil},
+		{"Queue", d.Queue == nil},
+		{"Audit", d.Audit == nil},
+		{"Clock", d.Clock == nil},
+		{"IDGen", d.IDGen == nil},
+		{"Logger", d.Logger == nil},
+	}
+	for _, dep := range required {
+		if dep.missing {
+			return fmt.Errorf("orchestrator dependency %s is required", dep.name)
+		}
+	}
+	return nil
 }
 
 // NewOrchestrator creates a new OrchestratorService instance with the required dependencies.
 // Purpose: Factory function for creating the orchestrator service with dependency injection.
 // Inputs:
-//   - planner: Implementation of the Planner port for generating execution plans
-//   - executor: Implementation of the Executor port for running plan steps
-//   - repo: Implementation of the TaskRepository port for task persistence
-//   - audit: Implementation of the AuditRepository port for audit logging
-//   - clock: Implementation of the Clock port for time operations
-//   - idGen: Implementation of the IDGenerator port for ID generation
-//   - logger: Implementation of the Logger port for structured logging
+//   - deps: Port implementations the orchestrator works with (see OrchestratorDeps for
+//           which are required and what a nil optional dependency disables)
+//   - cfg: Orchestration policies (see DefaultOrchestratorConfig)
 // Outputs:
 //   - ports.Orchestrator: Fully initialized orchestrator service ready for use
-func NewOrchestrator(
-	planner ports.Planner,
-	executor ports.Executor,
-	repo ports.TaskRepository,
-	audit ports.AuditRepository,
-	clock ports.Clock,
-	idGen ports.IDGenerator,
-	logger ports.Logger,
-) ports.Orchestrator {
-	return &OrchestratorService{
-		planner:  planner,
-		executor: executor,
-		repo:     repo,
-		audit:    audit,
-		clock:    clock,
-		idGen:    idGen,
-		logger:   logger,
+//   - error: Returns error naming the first missing required dependency
+func NewOrchestrator(deps OrchestratorDeps, cfg OrchestratorConfig) (ports.Orchestrator, error) {
+	if err := deps.validate(); err != nil {
+		return nil, err
 	}
+
+	return &OrchestratorService{
+		planner:     deps.Planner,
+		executor:    deps.Executor,
+		verifier:    deps.Verifier,
+		tools:       deps.Tools,
+		normalizer:  deps.Normalizer,
+		agents:      deps.Agents,
+		router:      deps.Router,
+		repo:        deps.Repo,
+		plans:       deps.Plans,
+		approvals:   deps.Approvals,
+		idempotency: deps.Idempotency,
+		usage:       deps.Usage,
+		budgets:     deps.Budgets,
+		artifacts:   deps.Artifacts,
+		results:     deps.Results,
+		toolCalls:   deps.ToolCalls,
+		events:      deps.Events,
+		queue:       deps.Queue,
+		audit:       deps.Audit,
+		clock:       deps.Clock,
+		idGen:       deps.IDGen,
+		random:      deps.Random,
+		logger:      deps.Logger,
+		cfg:         cfg,
+		running:     make(map[string]*execution),
+		recovered:   make(map[string]struct{}),
+	}, nil
 }
 
-// StartTask initializes a new task based on user input and creates an execution plan.
+// StartTask initializes a new task based on user input and queues it for execution.
 // Purpose: This is the primary entry point for submitting work to the JARO system.
-//          Creates a new task, persists it, and logs the creation event.
+//          Creates a new task, persists it, logs the creation event and hands it to the
+//          TaskQueue. A worker later drives it through planning and execution via RunTask.
+//          A repeat of a request carrying the same IdempotencyKey (per user) returns the
+//          task of the first request instead of creating another one.
 // Inputs:
 //   - ctx: Context for cancellation and timeout control
 //   - input: Raw user request in natural language
 //   - userID: Unique identifier of the user submitting the task
+//   - opts: Optional creation settings; an empty Priority defaults to NORMAL, Metadata is
+//           copied onto the task, Deadline/Timeout bound how long the task may run
+//           (TaskTimeout applies when neither is set), Budget caps the task's LLM spend,
+//           TargetAgent names the agent (empty: routed when planning starts),
+//           IdempotencyKey deduplicates retries, and ParentTaskID/ParentStepID are set
+//           when a SUB_TASK step spawns the task
 // Outputs:
-//   - *domain.Task: The created task with status NEW
-//   - error: Returns error if input validation fails, persistence fails, or system is unavailable
-func (s *OrchestratorService) StartTask(ctx context.Context, input string, userID string) (*domain.Task, error) {
+//   - *domain.Task: The accepted task in status NEW, or the original task for a repeat
+//   - error: Returns error if input validation fails (wraps domain.ErrInvalidPriority for
+//            unknown priorities, domain.ErrInvalidDeadline for past deadlines or negative
+//            timeouts, domain.ErrInvalidBudget for negative budget limits,
+//            domain.ErrUnknownAgent for unregistered agents), the idempotency key
+//            was used for a different request (wraps domain.ErrIdempotencyKeyReused) or by
+//            one still in progress (wraps domain.ErrIdempotencyKeyInUse), persistence fails,
+//            or the queue rejects the task
+//            (wraps domain.ErrQueueFull; the task is then FAILED)
+func (s *OrchestratorService) StartTask(ctx context.Context, input string, userID string, opts domain.TaskOptions) (*domain.Task, error) {
 	// Validate input
 	if input == "" {
 		return nil, fmt.Errorf("task input cannot be empty")
@@ -72,24 +184,80 @@ func (s *OrchestratorService) StartTask(ctx context.Context, input string, userI
 		return nil, fmt.Errorf("userID cannot be empty")
 	}
 
-	// Create new task with initial state
-	now := s.clock.Now()
 	taskID := s.idGen.Generate()
-	
+
+	// Repeats of an idempotent request get the task created by the first one
+	idempotent := opts.IdempotencyKey != "" && s.idempotency != nil
+	if idempotent {
+		original, err := s.claimIdempotencyKey(ctx, taskID, input, userID, opts)
+		if err != nil || original != nil {
+			return original, err
+		}
+	}
+
+	task, err := s.createTask(ctx, taskID, input, userID, opts)
+	if err != nil && idempotent {
+		// No task was accepted under the key, so a retry must be able to create one
+		s.releaseIdempotencyKey(ctx, userID, opts.IdempotencyKey)
+	}
+
+	return task, err
+}
+
+// createTask validates the creation options, then persists, audits and queues a new task.
+// It returns the same errors as StartTask, except those concerning idempotency keys.
+func (s *OrchestratorService) createTask(ctx context.Context, taskID string, input string, userID string, opts domain.TaskOptions) (*domain.Task, error) {
+	priority := opts.Priority
+	if priority == "" {
+		priority = domain.TaskPriorityNormal
+	}
+	if !priority.IsValid() {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidPriority, priority)
+	}
+
+	// Resolve the deadline: the earlier of an absolute deadline and a relative timeout
+	now := s.clock.Now()
+	deadline, err := s.resolveDeadline(now, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := opts.Budget.Validate(); err != nil {
+		return nil, err
+	}
+
+	// An agent named by the client must exist; otherwise the task is routed once a worker plans it
+	targetAgent := domain.NormalizeAgentName(opts.TargetAgent)
+	if targetAgent != "" {
+		if _, err := s.resolveAgent(targetAgent); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create new task with initial state
 	task := &domain.Task{
 		ID:               taskID,
 		CreatedAt:        now,
 		UpdatedAt:        now,
 		Status:           domain.TaskStatusNew,
+		Priority:         priority,
+		Deadline:         deadline,
 		Input:            input,
-		NormalizedIntent: input, // Initial value; will be refined by planner
+		NormalizedIntent: input, // Initial value; replaced by the intent normalization stage
 		UserID:           userID,
 		Channel:          "api", // Default channel
-		TargetAgent:      "CORE", // Default agent for V1
+		TargetAgent:      targetAgent,
+		ParentTaskID:     opts.ParentTaskID,
+		ParentStepID:     opts.ParentStepID,
 		Artifacts:        make(map[string]string),
 		Metadata:         make(map[string]string),
 		UsageTokens:      0,
 		CostEstimate:     0.0,
+		Budget:           opts.Budget,
+	}
+
+	for key, value := range opts.Metadata {
+		task.Metadata[key] = value
 	}
 
 	// Persist the task
@@ -98,42 +266,150 @@ func (s *OrchestratorService) StartTask(ctx context.Context, input string, userI
 	}
 
 	// Create audit event for task creation
-	auditEvent := &domain.AuditEvent{
-		ID:              s.idGen.Generate(),
-		TaskID:          taskID,
-		CorrelationID:   taskID,
-		Timestamp:       now,
-		EventType:       "TASK_CREATED",
-		Actor:           userID,
-		BehaviorVersion: "v1",
-		Payload: map[string]interface{}{
-			"input":        input,
-			"task_id":      taskID,
-			"user_id":      userID,
-			"target_agent": task.TargetAgent,
-		},
-	}
-
-	// Log the audit event (non-blocking - don't fail task creation if audit fails)
-	if err := s.audit.SaveEvent(ctx, auditEvent); err != nil {
-		// Log warning using logger port instead of fmt.Printf
-		s.logger.Warn("failed to save audit event", map[string]interface{}{
-			"error":   err.Error(),
-			"task_id": taskID,
-		})
+	payload := map[string]interface{}{
+		"input":        input,
+		"task_id":      taskID,
+		"user_id":      userID,
+		"target_agent": task.TargetAgent,
+		"priority":     string(task.Priority),
+	}
+	if !task.Deadline.IsZero() {
+		payload["deadline"] = task.Deadline
+	}
+	if !task.Budget.IsZero() {
+		payload["budget"] = task.Budget
+	}
+	if task.ParentTaskID != "" {
+		payload["parent_task_id"] = task.ParentTaskID
+		payload["parent_step_id"] = task.ParentStepID
+	}
+	s.recordEvent(ctx, task, "TASK_CREATED", userID, payload)
+
+	// Hand the task to the worker pool
+	if err := s.enqueueTask(ctx, task); err != nil {
+		return task, err
 	}
 
 	return task, nil
 }
 
+// resolveDeadline computes the deadline of a new task from its creation options.
+// It returns the earlier of opts.Deadline and now+opts.Timeout, falls back to TaskTimeout
+// when neither is set, and returns the zero time for tasks without a deadline.
+func (s *OrchestratorService) resolveDeadline(now time.Time, opts domain.TaskOptions) (time.Time, error) {
+	if opts.Timeout < 0 {
+		return time.Time{}, fmt.Errorf("%w: timeout cannot be negative: %s", domain.ErrInvalidDeadline, opts.Timeout)
+	}
+	if !opts.Deadline.IsZero() && !opts.Deadline.After(now) {
+		return time.Time{}, fmt.Errorf("%w: deadline %s has already passed", domain.ErrInvalidDeadline, opts.Deadline.Format(time.RFC3339))
+	}
+
+	deadline := opts.Deadline
+	if opts.Timeout > 0 {
+		if relative := now.Add(opts.Timeout); deadline.IsZero() || relative.Before(deadline) {
+			deadline = relative
+		}
+	}
+	if deadline.IsZero() && s.cfg.TaskTimeout > 0 {
+		deadline = now.Add(s.cfg.TaskTimeout)
+	}
+
+	return deadline, nil
+}
+
+// RunTask drives a queued task through planning and execution.
+// Purpose: Called by the worker pool for every dequeued task. Runs the task lifecycle
+//          (PLANNING → EXECUTING → VERIFYING → DONE/FAILED), replanning when verification
+//          finds the goal unmet. Tasks in WAITING_SUBTASKS were queued again by a finishing
+//          child and resume their plan, tasks in BUDGET_EXCEEDED by a raised budget and
+//          tasks in WAITING_APPROVAL by an approval decision, and tasks that were in flight
+//          at a crash by RecoverTasks; other
+//          tasks that are no longer NEW (e.g., canceled while queued) are skipped.
+//          Planning or step failures are reflected in the task status.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (owned by the worker)
+//   - taskID: Unique identifier of the dequeued task
+// Outputs:
+//   - error: Returns error if the task is not found or its state could not be persisted
+func (s *OrchestratorService) RunTask(ctx context.Context, taskID string) error {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to load task: %w", err)
+	}
+	if task.Status == domain.TaskStatusWaitingSubTasks {
+		if err := s.resumeSubTasks(ctx, task); err != nil {
+			return fmt.Errorf("failed to resume task %s: %w", taskID, err)
+		}
+		return nil
+	}
+	if task.Status == domain.TaskStatusBudgetExceeded {
+		if err := s.resumeBudget(ctx, task); err != nil {
+			return fmt.Errorf("failed to resume task %s: %w", taskID, err)
+		}
+		return nil
+	}
+	if task.Status == domain.TaskStatusWaitingApproval {
+		if err := s.resumeApproval(ctx, task); err != nil {
+			return fmt.Errorf("failed to resume task %s: %w", taskID, err)
+		}
+		return nil
+	}
+	if s.claimRecovered(task.ID) {
+		if err := s.resumeRecovered(ctx, task); err != nil {
+			return fmt.Errorf("failed to resume task %s: %w", taskID, err)
+		}
+		return nil
+	}
+	if task.Status != domain.TaskStatusNew {
+		return nil
+	}
+
+	if err := s.runExecution(ctx, task, func(execCtx context.Context) error {
+		return s.runTask(execCtx, task)
+	}); err != nil {
+		return fmt.Errorf("failed to run task %s: %w", taskID, err)
+	}
+
+	return nil
+}
+
+// enqueueTask hands a task to the queue and emits TASK_QUEUED.
+// Purpose: Shared by StartTask, crash recovery, approval decisions and parents woken by
+//          their sub-tasks.
+//          A task the queue rejects is FAILED so it does not linger unqueued.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The NEW (or WAITING_SUBTASKS / BUDGET_EXCEEDED / WAITING_APPROVAL) task to queue
+// Outputs:
+//   - error: Returns error if the queue rejects the task (wraps domain.ErrQueueFull)
+func (s *OrchestratorService) enqueueTask(ctx context.Context, task *domain.Task) error {
+	if err := s.queue.Enqueue(ctx, task); err != nil {
+		if finishErr := s.finishTask(ctx, task, domain.TaskStatusFailed, fmt.Sprintf("task could not be queued: %v", err)); finishErr != nil {
+			return finishErr
+		}
+		return fmt.Errorf("failed to queue task: %w", err)
+	}
+
+	s.recordEvent(ctx, task, "TASK_QUEUED", systemActor, map[string]interface{}{
+		"task_id":      task.ID,
+		"priority":     string(task.Priority),
+		"queue_length": s.queue.Len(),
+	})
+
+	return nil
+}
+
 // GetTaskStatus retrieves the current state and progress of a task.
 // Purpose: Allows clients to poll for task status and results.
-//          Simple pass-through to the repository layer.
+//          Loads the task from the repository and, while it is still queued,
+//          reports its current position in the TaskQueue. UsageTokens and CostEstimate
+//          are refreshed from the usage ledger, so they include calls still in progress,
+//          and Artifacts from the artifact store.
 // Inputs:
 //   - ctx: Context for cancellation and timeout control
 //   - taskID: Unique identifier of the task to query
 // Outputs:
-//   - *domain.Task: Current task state including status, steps, and artifacts
+//   - *domain.Task: Current task state including status, steps, artifacts and queue position
 //   - error: Returns error if task is not found or access is denied
 func (s *OrchestratorService) GetTaskStatus(ctx context.Context, taskID string) (*domain.Task, error) {
 	if taskID == "" {
@@ -145,21 +421,91 @@ func (s *OrchestratorService) GetTaskStatus(ctx context.Context, taskID string)
 		return nil, fmt.Errorf("failed to get task status: %w", err)
 	}
 
+	if task.Status == domain.TaskStatusNew {
+		if position, queued := s.queue.Position(taskID); queued {
+			task.QueuePosition = position
+		}
+	}
+	s.applyUsage(ctx, task)
+	s.applyArtifacts(ctx, task)
+
 	return task, nil
 }
 
+// GetTaskPlan retrieves the execution plan of a task with current step statuses.
+// Purpose: Shows clients what the agent intends to do and how far it has got.
+//          Resolves the plan through Task.PlanID.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task whose plan is requested
+// Outputs:
+//   - *domain.Plan: The task's plan including step statuses
+//   - error: Returns error if the task is not found or has no plan yet
+func (s *OrchestratorService) GetTaskPlan(ctx context.Context, taskID string) (*domain.Plan, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("taskID cannot be empty")
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+	if task.PlanID == "" {
+		return nil, fmt.Errorf("plan not found for task %s", taskID)
+	}
+
+	plan, err := s.plans.GetPlan(ctx, task.PlanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// GetTaskPlans retrieves every plan revision of a task.
+// Purpose: Shows how the plan evolved when failed verification triggered replanning.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task
+// Outputs:
+//   - []*domain.Plan: Plans of the task in creation order (the last one is current)
+//   - error: Returns error if the task is not found or plans cannot be loaded
+func (s *OrchestratorService) GetTaskPlans(ctx context.Context, taskID string) ([]*domain.Plan, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("taskID cannot be empty")
+	}
+
+	if _, err := s.repo.GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+
+	plans, err := s.plans.ListTaskPlans(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plans: %w", err)
+	}
+
+	return plans, nil
+}
+
 // HandleApproval processes user approval or rejection for high-risk steps.
 // Purpose: Implements the human-in-the-loop pattern for risky operations.
-//          Updates task status based on user decision and logs the approval decision.
+//          Records the decision on the open ApprovalRequest and queues the task; the worker
+//          that picks it up resumes execution: approved steps run (approval gates complete),
+//          rejected steps are SKIPPED and execution continues, or FAILED and the task
+//          CANCELED, per RejectionPolicy. Only one of several concurrent decisions for the
+//          same approval is recorded; the others fail with domain.ErrApprovalNotOpen.
 // Inputs:
 //   - ctx: Context for cancellation and timeout control
 //   - taskID: Unique identifier of the task awaiting approval
 //   - stepID: Unique identifier of the step requiring approval
 //   - approved: User decision (true = approve, false = reject)
 //   - userID: Unique identifier of the user making the decision
+//   - comment: Optional reviewer comment stored with the decision
 // Outputs:
-//   - error: Returns error if task/step not found, not awaiting approval, or persistence fails
-func (s *OrchestratorService) HandleApproval(ctx context.Context, taskID string, stepID string, approved bool, userID string) error {
+//   - error: Returns error if task/step not found, not awaiting approval, persistence fails
+//            or the task cannot be queued (wraps domain.ErrQueueFull).
+//            Conflicts wrap domain.ErrTaskNotWaitingApproval or domain.ErrStepMismatch.
+func (s *OrchestratorService) HandleApproval(ctx context.Context, taskID string, stepID string, approved bool, userID string, comment string) error {
 	// Validate inputs
 	if taskID == "" {
 		return fmt.Errorf("taskID cannot be empty")
@@ -171,6 +517,10 @@ func (s *OrchestratorService) HandleApproval(ctx context.Context, taskID string,
 		return fmt.Errorf("userID cannot be empty")
 	}
 
+	// Check and record the decision atomically, so concurrent decisions cannot both claim it
+	s.approvalMu.Lock()
+	defer s.approvalMu.Unlock()
+
 	// Load the task
 	task, err := s.repo.GetTask(ctx, taskID)
 	if err != nil {
@@ -179,55 +529,108 @@ func (s *OrchestratorService) HandleApproval(ctx context.Context, taskID string,
 
 	// Verify task is waiting for approval
 	if task.Status != domain.TaskStatusWaitingApproval {
-		return fmt.Errorf("task %s is not waiting for approval (current status: %s)", taskID, task.Status)
+		return fmt.Errorf("%w: task %s (current status: %s)", domain.ErrTaskNotWaitingApproval, taskID, task.Status)
 	}
 
 	// Verify the current step matches
 	if task.CurrentStepID != stepID {
-		return fmt.Errorf("step mismatch: expected %s but got %s", task.CurrentStepID, stepID)
+		return fmt.Errorf("%w: expected %s but got %s", domain.ErrStepMismatch, task.CurrentStepID, stepID)
 	}
 
-	// Update task status based on approval decision
-	now := s.clock.Now()
+	// Load the open approval request and the plan it belongs to
+	approval, err := s.findStepApproval(ctx, taskID, stepID)
+	if err != nil {
+		return err
+	}
+	if approval == nil || approval.Status != domain.ApprovalStatusOpen {
+		return fmt.Errorf("%w: step %s", domain.ErrApprovalNotOpen, stepID)
+	}
+
+	plan, err := s.plans.GetPlan(ctx, task.PlanID)
+	if err != nil {
+		return fmt.Errorf("failed to load plan: %w", err)
+	}
+	step := findStep(plan, stepID)
+	if step == nil {
+		return fmt.Errorf("step not found in plan: %s", stepID)
+	}
+
+	// Record the decision
+	approval.ApprovedBy = userID
+	approval.Comment = comment
+	approval.DecidedAt = s.clock.Now()
 	if approved {
-		task.Status = domain.TaskStatusExecuting
+		approval.Status = domain.ApprovalStatusApproved
 	} else {
-		task.Status = domain.TaskStatusCanceled
-		task.FinishedAt = now
+		approval.Status = domain.ApprovalStatusRejected
 	}
-	task.UpdatedAt = now
-
-	// Persist updated task
-	if err := s.repo.SaveTask(ctx, task); err != nil {
-		return fmt.Errorf("failed to save task after approval: %w", err)
+	if err := s.approvals.SaveApproval(ctx, approval); err != nil {
+		return fmt.Errorf("failed to save approval decision: %w", err)
 	}
 
 	// Create audit event for approval decision
-	auditEvent := &domain.AuditEvent{
-		ID:              s.idGen.Generate(),
-		TaskID:          taskID,
-		CorrelationID:   taskID,
-		Timestamp:       now,
-		EventType:       "APPROVAL_DECISION",
-		Actor:           userID,
-		BehaviorVersion: "v1",
-		Payload: map[string]interface{}{
-			"task_id":    taskID,
-			"step_id":    stepID,
-			"approved":   approved,
-			"user_id":    userID,
-			"new_status": string(task.Status),
-		},
-	}
-
-	// Log the audit event (non-blocking)
-	if err := s.audit.SaveEvent(ctx, auditEvent); err != nil {
-		s.logger.Warn("failed to save audit event", map[string]interface{}{
-			"error":   err.Error(),
-			"task_id": taskID,
-			"step_id": stepID,
-		})
+	s.recordEvent(ctx, task, "APPROVAL_DECISION", userID, map[string]interface{}{
+		"task_id":     taskID,
+		"step_id":     stepID,
+		"approval_id": approval.ID,
+		"approved":    approved,
+		"user_id":     userID,
+		"comment":     comment,
+		"policy":      string(s.cfg.RejectionPolicy),
+	})
+
+	// The worker that dequeues the task applies the decision and resumes the plan
+	return s.enqueueTask(ctx, task)
+}
+
+// ListApprovals returns approval requests across tasks that match the filter.
+// Purpose: Lets reviewers find pending decisions without knowing task IDs.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - filter: Criteria such as task owner and approval status
+// Outputs:
+//   - []*domain.ApprovalRequest: Matching approval requests
+//   - error: Returns error if approvals cannot be loaded
+func (s *OrchestratorService) ListApprovals(ctx context.Context, filter domain.ApprovalFilter) ([]*domain.ApprovalRequest, error) {
+	approvals, err := s.approvals.ListApprovals(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// GetTaskApprovals returns the approval history of a single task.
+// Purpose: Shows which steps were gated and how each was decided.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task
+// Outputs:
+//   - []*domain.ApprovalRequest: Approval requests of the task in creation order
+//   - error: Returns error if the task is not found or approvals cannot be loaded
+func (s *OrchestratorService) GetTaskApprovals(ctx context.Context, taskID string) ([]*domain.ApprovalRequest, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("taskID cannot be empty")
+	}
+
+	if _, err := s.repo.GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
 	}
 
+	approvals, err := s.approvals.ListTaskApprovals(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// findStep returns a pointer to the plan step with the given ID, or nil if absent.
+func findStep(plan *domain.Plan, stepID string) *domain.Step {
+	for i := range plan.Steps {
+		if plan.Steps[i].ID == stepID {
+			return &plan.Steps[i]
+		}
+	}
 	return nil
 }
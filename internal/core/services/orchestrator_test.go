@@ -0,0 +1,582 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/adapters/memory"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/execctx"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// testTimeout bounds every wait on background work so a broken scenario fails instead of hanging.
+const testTimeout = 5 * time.Second
+
+// fakeClock is a ports.Clock whose time only moves when the test advances it.
+// Every After call is reported on waits so tests can assert the requested delays.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []clockWaiter
+	waits   chan time.Duration
+}
+
+type clockWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:   time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+		waits: make(chan time.Duration, 64),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, clockWaiter{at: c.now.Add(d), ch: ch})
+	c.waits <- d
+	return ch
+}
+
+// Advance moves the clock forward and fires the waiters that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, waiter := range c.waiters {
+		if waiter.at.After(c.now) {
+			pending = append(pending, waiter)
+			continue
+		}
+		waiter.ch <- c.now
+	}
+	c.waiters = pending
+}
+
+// nextWait returns the delay of the next After call.
+func (c *fakeClock) nextWait(t *testing.T) time.Duration {
+	t.Helper()
+	select {
+	case d := <-c.waits:
+		return d
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for a clock wait")
+		return 0
+	}
+}
+
+type sequenceIDs struct{ n atomic.Int64 }
+
+func (g *sequenceIDs) Generate() string { return fmt.Sprintf("id-%d", g.n.Add(1)) }
+
+type fixedRandom float64
+
+func (r fixedRandom) Float64() float64 { return float64(r) }
+
+type nopLogger struct{}
+
+func (nopLogger) Info(msg string, fields map[string]interface{})             {}
+func (nopLogger) Error(msg string, err error, fields map[string]interface{}) {}
+func (nopLogger) Warn(msg string, fields map[string]interface{})             {}
+
+// recordingAudit keeps the audit events for inspection.
+type recordingAudit struct {
+	mu     sync.Mutex
+	events []*domain.AuditEvent
+}
+
+func (a *recordingAudit) SaveEvent(ctx context.Context, event *domain.AuditEvent) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.events = append(a.events, event)
+	return nil
+}
+
+func (a *recordingAudit) count(eventType string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	n := 0
+	for _, event := range a.events {
+		if event.EventType == eventType {
+			n++
+		}
+	}
+	return n
+}
+
+// fixedPlanner returns the same steps for every task, or the steps scripted for its input.
+type fixedPlanner struct {
+	steps     []domain.Step
+	byInput   map[string][]domain.Step // Steps of tasks with this input, e.g., sub-tasks
+	revisions [][]domain.Step          // Steps of the revision replacing plan revision i
+}
+
+func (p fixedPlanner) CreatePlan(ctx context.Context, task *domain.Task, tools []domain.ToolMetadata) (*domain.Plan, error) {
+	planned, scripted := p.byInput[task.Input]
+	if !scripted {
+		planned = p.steps
+	}
+	steps := make([]domain.Step, len(planned))
+	copy(steps, planned)
+	return &domain.Plan{ID: "plan-" + task.ID, TaskID: task.ID, Goal: task.Input, Steps: steps}, nil
+}
+
+func (p fixedPlanner) RevisePlan(ctx context.Context, task *domain.Task, previous *domain.Plan, verification *domain.Verification, tools []domain.ToolMetadata) (*domain.Plan, error) {
+	if previous.Revision >= len(p.revisions) {
+		return nil, fmt.Errorf("no revision scripted for revision %d", previous.Revision)
+	}
+	steps := make([]domain.Step, len(p.revisions[previous.Revision]))
+	copy(steps, p.revisions[previous.Revision])
+	return &domain.Plan{TaskID: task.ID, Goal: previous.Goal, Steps: steps}, nil
+}
+
+// scriptedExecutor fails or blocks steps as configured and counts the calls per step.
+type scriptedExecutor struct {
+	mu       sync.Mutex
+	failures map[string][]error           // Errors returned by the next calls of a step, in order
+	block    map[string]bool              // Steps that run until their context is canceled
+	usage    map[string]domain.TokenUsage // LLM usage reported by every call of a step
+	outputs  map[string]string            // Output of a step (default: "output of <id>")
+	calls    map[string]int
+	started  chan string
+}
+
+func newScriptedExecutor() *scriptedExecutor {
+	return &scriptedExecutor{
+		failures: make(map[string][]error),
+		block:    make(map[string]bool),
+		usage:    make(map[string]domain.TokenUsage),
+		outputs:  make(map[string]string),
+		calls:    make(map[string]int),
+		started:  make(chan string, 64),
+	}
+}
+
+func (e *scriptedExecutor) ExecuteStep(ctx context.Context, task *domain.Task, step *domain.Step) (*domain.StepResult, error) {
+	e.mu.Lock()
+	e.calls[step.ID]++
+	var err error
+	if failures := e.failures[step.ID]; len(failures) > 0 {
+		err, e.failures[step.ID] = failures[0], failures[1:]
+	}
+	block := e.block[step.ID]
+	usage, metered := e.usage[step.ID]
+	output, scripted := e.outputs[step.ID]
+	e.mu.Unlock()
+
+	if metered {
+		execctx.RecordLLMUsage(ctx, usage)
+	}
+	e.started <- step.ID
+	if err != nil {
+		return nil, err
+	}
+	if block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if !scripted {
+		output = "output of " + step.ID
+	}
+	return &domain.StepResult{StepID: step.ID, Success: true, Output: output}, nil
+}
+
+func (e *scriptedExecutor) callCount(stepID string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls[stepID]
+}
+
+type harness struct {
+	orchestrator ports.Orchestrator
+	deps         services.OrchestratorDeps
+	clock        *fakeClock
+	audit        *recordingAudit
+	executor     *scriptedExecutor
+	stopWorkers  func() // Shuts the worker pool down as a process exit would; safe to call twice
+}
+
+// harnessOption adjusts the dependencies or configuration before the orchestrator is built.
+type harnessOption func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig)
+
+// newHarness wires an orchestrator on in-memory adapters and starts a worker pool that is
+// stopped when the test ends.
+func newHarness(t *testing.T, steps []domain.Step, executor *scriptedExecutor, opts ...harnessOption) *harness {
+	t.Helper()
+
+	cfg := services.DefaultOrchestratorConfig()
+	cfg.StepTimeout = 0 // Only retry backoffs wait on the clock
+	cfg.RetryPolicy = domain.RetryPolicy{
+		MaxAttempts:      3,
+		InitialBackoffMs: 1000,
+		MaxBackoffMs:     30000,
+		Multiplier:       2,
+		Jitter:           0.2,
+	}
+
+	clock := newFakeClock()
+	audit := &recordingAudit{}
+	deps := services.OrchestratorDeps{
+		Planner:   fixedPlanner{steps: steps},
+		Executor:  executor,
+		Verifier:  memory.NewRuleVerifier(0),
+		Repo:      memory.NewTaskRepository(),
+		Plans:     memory.NewPlanRepository(),
+		Approvals: memory.NewApprovalRepository(),
+		Queue:     memory.NewTaskQueue(100, 0, clock),
+		Audit:     audit,
+		Clock:     clock,
+		IDGen:     &sequenceIDs{},
+		Random:    fixedRandom(0.5),
+		Logger:    nopLogger{},
+	}
+	for _, opt := range opts {
+		opt(&deps, &cfg)
+	}
+
+	orchestrator, err := services.NewOrchestrator(deps, cfg)
+	if err != nil {
+		t.Fatalf("NewOrchestrator() = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := services.NewWorkerPool(deps.Queue, orchestrator, nopLogger{}, 2)
+	pool.Start(ctx)
+	stopWorkers := func() {
+		cancel()
+		pool.Wait()
+	}
+	t.Cleanup(stopWorkers)
+
+	return &harness{orchestrator: orchestrator, deps: deps, clock: clock, audit: audit, executor: executor, stopWorkers: stopWorkers}
+}
+
+// waitForStatus polls the task until it reaches the wanted status.
+func (h *harness) waitForStatus(t *testing.T, taskID string, want domain.TaskStatus) *domain.Task {
+	t.Helper()
+	deadline := time.Now().Add(testTimeout)
+	for {
+		task, err := h.orchestrator.GetTaskStatus(context.Background(), taskID)
+		if err != nil {
+			t.Fatalf("GetTaskStatus(%s) = %v", taskID, err)
+		}
+		if task.Status == want {
+			return task
+		}
+		if task.Status.IsTerminal() || time.Now().After(deadline) {
+			t.Fatalf("task %s reached %s, want %s", taskID, task.Status, want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitForStart returns once the executor has started the given step.
+func (h *harness) waitForStart(t *testing.T, stepID string) {
+	t.Helper()
+	timeout := time.After(testTimeout)
+	for {
+		select {
+		case started := <-h.executor.started:
+			if started == stepID {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("step %s was never started", stepID)
+		}
+	}
+}
+
+func (h *harness) step(t *testing.T, taskID string, stepID string) domain.Step {
+	t.Helper()
+	plan, err := h.orchestrator.GetTaskPlan(context.Background(), taskID)
+	if err != nil {
+		t.Fatalf("GetTaskPlan(%s) = %v", taskID, err)
+	}
+	for _, step := range plan.Steps {
+		if step.ID == stepID {
+			return step
+		}
+	}
+	t.Fatalf("plan of task %s has no step %s", taskID, stepID)
+	return domain.Step{}
+}
+
+func TestNewOrchestratorRequiresDependencies(t *testing.T) {
+	clock := newFakeClock()
+	complete := func() services.OrchestratorDeps {
+		return services.OrchestratorDeps{
+			Planner:   fixedPlanner{},
+			Executor:  newScriptedExecutor(),
+			Verifier:  memory.NewRuleVerifier(0),
+			Repo:      memory.NewTaskRepository(),
+			Plans:     memory.NewPlanRepository(),
+			Approvals: memory.NewApprovalRepository(),
+			Queue:     memory.NewTaskQueue(100, 0, clock),
+			Audit:     &recordingAudit{},
+			Clock:     clock,
+			IDGen:     &sequenceIDs{},
+			Logger:    nopLogger{},
+		}
+	}
+
+	if _, err := services.NewOrchestrator(complete(), services.DefaultOrchestratorConfig()); err != nil {
+		t.Fatalf("NewOrchestrator() with only the required dependencies = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		remove func(deps *services.OrchestratorDeps)
+	}{
+		{name: "Planner", remove: func(d *services.OrchestratorDeps) { d.Planner = nil }},
+		{name: "Executor", remove: func(d *services.OrchestratorDeps) { d.Executor = nil }},
+		{name: "Verifier", remove: func(d *services.OrchestratorDeps) { d.Verifier = nil }},
+		{name: "Repo", remove: func(d *services.OrchestratorDeps) { d.Repo = nil }},
+		{name: "Plans", remove: func(d *services.OrchestratorDeps) { d.Plans = nil }},
+		{name: "Approvals", remove: func(d *services.OrchestratorDeps) { d.Approvals = nil }},
+		{name: "Queue", remove: func(d *services.OrchestratorDeps) { d.Queue = nil }},
+		{name: "Audit", remove: func(d *services.OrchestratorDeps) { d.Audit = nil }},
+		{name: "Clock", remove: func(d *services.OrchestratorDeps) { d.Clock = nil }},
+		{name: "IDGen", remove: func(d *services.OrchestratorDeps) { d.IDGen = nil }},
+		{name: "Logger", remove: func(d *services.OrchestratorDeps) { d.Logger = nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := complete()
+			tt.remove(&deps)
+			orchestrator, err := services.NewOrchestrator(deps, services.DefaultOrchestratorConfig())
+			if err == nil || orchestrator != nil {
+				t.Fatalf("NewOrchestrator() without %s = (%v, %v), want an error", tt.name, orchestrator, err)
+			}
+			if !strings.Contains(err.Error(), tt.name) {
+				t.Errorf("error %q does not name %s", err, tt.name)
+			}
+		})
+	}
+}
+
+func TestOrchestratorRetriesFailedSteps(t *testing.T) {
+	transient := fmt.Errorf("rate limited: %w", domain.ErrTransient)
+	permanent := fmt.Errorf("bad request: %w", domain.ErrPermanent)
+
+	tests := []struct {
+		name        string
+		failures    []error
+		wantStatus  domain.TaskStatus
+		wantStep    domain.StepStatus
+		wantWaits   []time.Duration
+		wantRetries int
+	}{
+		{
+			name:        "recovers after transient failures",
+			failures:    []error{transient, transient},
+			wantStatus:  domain.TaskStatusDone,
+			wantStep:    domain.StepStatusCompleted,
+			wantWaits:   []time.Duration{time.Second, 2 * time.Second},
+			wantRetries: 2,
+		},
+		{
+			name:        "gives up after the last attempt",
+			failures:    []error{transient, transient, transient},
+			wantStatus:  domain.TaskStatusFailed,
+			wantStep:    domain.StepStatusFailed,
+			wantWaits:   []time.Duration{time.Second, 2 * time.Second},
+			wantRetries: 2,
+		},
+		{
+			name:        "permanent failure is not retried",
+			failures:    []error{permanent},
+			wantStatus:  domain.TaskStatusFailed,
+			wantStep:    domain.StepStatusFailed,
+			wantRetries: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := newScriptedExecutor()
+			executor.failures["a"] = tt.failures
+			h := newHarness(t, []domain.Step{{ID: "a", Type: domain.StepTypeThink, Status: domain.StepStatusPending}}, executor)
+
+			task, err := h.orchestrator.StartTask(context.Background(), "retry me", "alice", domain.TaskOptions{})
+			if err != nil {
+				t.Fatalf("StartTask = %v", err)
+			}
+
+			// The backoff only elapses when the clock is advanced; with a midpoint jitter
+			// sample the delays are exactly the policy's exponential steps
+			for _, want := range tt.wantWaits {
+				if got := h.clock.nextWait(t); got != want {
+					t.Fatalf("backoff = %v, want %v", got, want)
+				}
+				h.clock.Advance(want)
+			}
+
+			h.waitForStatus(t, task.ID, tt.wantStatus)
+			select {
+			case d := <-h.clock.waits:
+				t.Fatalf("unexpected extra clock wait of %v", d)
+			default:
+			}
+
+			step := h.step(t, task.ID, "a")
+			if step.Status != tt.wantStep {
+				t.Errorf("step status = %s, want %s", step.Status, tt.wantStep)
+			}
+			if step.RetryCount != tt.wantRetries {
+				t.Errorf("step RetryCount = %d, want %d", step.RetryCount, tt.wantRetries)
+			}
+			if got := h.audit.count("STEP_RETRIED"); got != tt.wantRetries {
+				t.Errorf("STEP_RETRIED events = %d, want %d", got, tt.wantRetries)
+			}
+			if got := executor.callCount("a"); got != len(tt.wantWaits)+1 {
+				t.Errorf("executor calls = %d, want %d", got, len(tt.wantWaits)+1)
+			}
+		})
+	}
+}
+
+func TestOrchestratorCancelsRunningTask(t *testing.T) {
+	executor := newScriptedExecutor()
+	executor.block["a"] = true
+	h := newHarness(t, []domain.Step{
+		{ID: "a", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+		{ID: "b", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+	}, executor)
+
+	ctx := context.Background()
+	task, err := h.orchestrator.StartTask(ctx, "cancel me", "alice", domain.TaskOptions{})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	h.waitForStart(t, "a")
+
+	if err := h.orchestrator.CancelTask(ctx, task.ID, "alice", "no longer needed"); err != nil {
+		t.Fatalf("CancelTask = %v", err)
+	}
+
+	// CancelTask waits for the interrupted step, so the outcome is visible right away
+	got, err := h.orchestrator.GetTaskStatus(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetTaskStatus = %v", err)
+	}
+	if got.Status != domain.TaskStatusCanceled {
+		t.Fatalf("task status = %s, want %s", got.Status, domain.TaskStatusCanceled)
+	}
+	for _, stepID := range []string{"a", "b"} {
+		if status := h.step(t, task.ID, stepID).Status; status != domain.StepStatusSkipped {
+			t.Errorf("step %s status = %s, want %s", stepID, status, domain.StepStatusSkipped)
+		}
+	}
+	if calls := executor.callCount("b"); calls != 0 {
+		t.Errorf("step b ran %d times after cancellation", calls)
+	}
+	if n := h.audit.count("TASK_CANCELED"); n != 1 {
+		t.Errorf("TASK_CANCELED events = %d, want 1", n)
+	}
+
+	if err := h.orchestrator.CancelTask(ctx, task.ID, "alice", "again"); !errors.Is(err, domain.ErrTaskFinished) {
+		t.Errorf("second CancelTask = %v, want ErrTaskFinished", err)
+	}
+}
+
+func TestOrchestratorResumesAfterApproval(t *testing.T) {
+	tests := []struct {
+		name         string
+		approved     bool
+		wantStatus   domain.TaskStatus
+		wantApproval domain.ApprovalStatus
+		wantStep     domain.StepStatus
+		wantCalls    int
+	}{
+		{
+			name:         "approved step runs",
+			approved:     true,
+			wantStatus:   domain.TaskStatusDone,
+			wantApproval: domain.ApprovalStatusApproved,
+			wantStep:     domain.StepStatusCompleted,
+			wantCalls:    1,
+		},
+		{
+			name:         "rejected step cancels the task",
+			approved:     false,
+			wantStatus:   domain.TaskStatusCanceled,
+			wantApproval: domain.ApprovalStatusRejected,
+			wantStep:     domain.StepStatusFailed,
+			wantCalls:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := newScriptedExecutor()
+			h := newHarness(t, []domain.Step{
+				{ID: "a", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+				{ID: "b", Type: domain.StepTypeThink, Status: domain.StepStatusPending, RequiresApproval: true},
+			}, executor)
+
+			ctx := context.Background()
+			task, err := h.orchestrator.StartTask(ctx, "needs a human", "alice", domain.TaskOptions{})
+			if err != nil {
+				t.Fatalf("StartTask = %v", err)
+			}
+			h.waitForStatus(t, task.ID, domain.TaskStatusWaitingApproval)
+
+			approvals, err := h.orchestrator.GetTaskApprovals(ctx, task.ID)
+			if err != nil {
+				t.Fatalf("GetTaskApprovals = %v", err)
+			}
+			if len(approvals) != 1 || approvals[0].StepID != "b" || approvals[0].Status != domain.ApprovalStatusOpen {
+				t.Fatalf("approvals = %+v, want one OPEN approval for step b", approvals)
+			}
+			if calls := executor.callCount("b"); calls != 0 {
+				t.Fatalf("gated step ran %d times before the decision", calls)
+			}
+
+			// The decision only queues the task; a worker resumes it
+			if err := h.orchestrator.HandleApproval(ctx, task.ID, "b", tt.approved, "bob", "reviewed"); err != nil {
+				t.Fatalf("HandleApproval = %v", err)
+			}
+			h.waitForStatus(t, task.ID, tt.wantStatus)
+
+			if err := h.orchestrator.HandleApproval(ctx, task.ID, "b", true, "bob", "again"); !errors.Is(err, domain.ErrTaskNotWaitingApproval) {
+				t.Errorf("second HandleApproval = %v, want ErrTaskNotWaitingApproval", err)
+			}
+
+			approvals, err = h.orchestrator.GetTaskApprovals(ctx, task.ID)
+			if err != nil {
+				t.Fatalf("GetTaskApprovals = %v", err)
+			}
+			if approvals[0].Status != tt.wantApproval || approvals[0].ApprovedBy != "bob" {
+				t.Errorf("approval = %s by %q, want %s by bob", approvals[0].Status, approvals[0].ApprovedBy, tt.wantApproval)
+			}
+			if status := h.step(t, task.ID, "b").Status; status != tt.wantStep {
+				t.Errorf("step b status = %s, want %s", status, tt.wantStep)
+			}
+			if calls := executor.callCount("b"); calls != tt.wantCalls {
+				t.Errorf("step b ran %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
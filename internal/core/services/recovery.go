@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// Recovery actions recorded in TASK_RECOVERED audit events
+const (
+	recoveryRequeued         = "REQUEUED"
+	recoveryAwaitingApproval = "AWAITING_APPROVAL"
+	recoveryAwaitingSubTasks = "AWAITING_SUBTASKS"
+	recoveryAwaitingBudget   = "AWAITING_BUDGET"
+	recoveryFailed           = "FAILED"
+)
+
+// RecoverTasks resumes or fails every non-terminal task left behind by a previous process.
+// Purpose: Called once at boot, before new work is accepted. Each task found in a
+//          non-terminal status is reloaded with its plan and CurrentStepID and then:
+//          - NEW: queued again for the worker pool
+//          - PLANNING without a plan: queued to be planned again from scratch
+//          - PLANNING, EXECUTING: queued to resume after the last completed step; interrupted
+//            steps are reset to PENDING, unless they are gated/high-risk (the task is FAILED
+//            instead)
+//          - VERIFYING: queued to run verification again
+//          - WAITING_APPROVAL: left waiting, or queued again if the decision was already recorded
+//          - WAITING_SUBTASKS: left waiting for its children (recovered like any other task),
+//            or queued again if a SUB_TASK step has already settled
+//          - BUDGET_EXCEEDED: left paused, or queued again if its budget now suffices
+//          Every task gets a TASK_RECOVERED audit event describing the action taken.
+//          Queued tasks are resumed by the worker pool (see resumeRecovered), so RecoverTasks
+//          returns without running any of them.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+// Outputs:
+//   - []*domain.Task: The recovered tasks in their state after recovery
+//   - error: Returns error if tasks could not be listed; per-task failures are joined
+//            into the error but do not stop recovery of the remaining tasks
+func (s *OrchestratorService) RecoverTasks(ctx context.Context) ([]*domain.Task, error) {
+	tasks, err := s.repo.ListTasks(ctx, domain.TaskFilter{ActiveOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unfinished tasks: %w", err)
+	}
+
+	recovered := make([]*domain.Task, 0, len(tasks))
+	var errs []error
+	for _, task := range tasks {
+		if s.isRunning(task.ID) {
+			continue
+		}
+		if err := s.recoverTask(ctx, task); err != nil {
+			s.logger.Error("failed to recover task", err, map[string]interface{}{
+				"task_id": task.ID,
+				"status":  string(task.Status),
+			})
+			errs = append(errs, fmt.Errorf("task %s: %w", task.ID, err))
+		}
+		recovered = append(recovered, task)
+	}
+
+	if len(errs) > 0 {
+		return recovered, fmt.Errorf("failed to recover %d task(s): %w", len(errs), errors.Join(errs...))
+	}
+
+	return recovered, nil
+}
+
+// recoverTask decides how a single unfinished task continues after a restart.
+// Purpose: Implements the per-status recovery rules documented on RecoverTasks.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The unfinished task (mutated in place)
+// Outputs:
+//   - error: Returns error if task or plan state could not be persisted
+func (s *OrchestratorService) recoverTask(ctx context.Context, task *domain.Task) error {
+	previous := task.Status
+
+	// Never picked up by a worker: queue again
+	if task.Status == domain.TaskStatusNew {
+		s.recordRecovery(ctx, task, previous, recoveryRequeued, "task was still queued", nil)
+		return s.enqueueTask(ctx, task)
+	}
+
+	// Paused over budget: resumes through RunTask once the budget suffices
+	if task.Status == domain.TaskStatusBudgetExceeded {
+		return s.recoverBudget(ctx, task)
+	}
+
+	// Nothing durable to resume from: plan again
+	if task.Status == domain.TaskStatusPlanning && task.PlanID == "" {
+		s.recordRecovery(ctx, task, previous, recoveryRequeued, "planning restarts", nil)
+		return s.requeueRecovered(ctx, task)
+	}
+
+	if task.PlanID == "" {
+		return s.failRecovery(ctx, task, previous, fmt.Sprintf("task in status %s has no plan", previous))
+	}
+	plan, err := s.plans.GetPlan(ctx, task.PlanID)
+	if err != nil {
+		return s.failRecovery(ctx, task, previous, fmt.Sprintf("plan %s could not be loaded: %v", task.PlanID, err))
+	}
+
+	switch task.Status {
+	case domain.TaskStatusWaitingApproval:
+		return s.recoverApproval(ctx, task, plan)
+
+	case domain.TaskStatusWaitingSubTasks:
+		return s.recoverSubTasks(ctx, task)
+
+	case domain.TaskStatusVerifying:
+		s.recordRecovery(ctx, task, previous, recoveryRequeued, "verification restarts", nil)
+		return s.requeueRecovered(ctx, task)
+	}
+
+	// PLANNING (with a plan) or EXECUTING: settle steps that were running at the crash
+	requeued := make([]string, 0)
+	for i := range plan.Steps {
+		step := &plan.Steps[i]
+		if step.Status != domain.StepStatusInProgress {
+			continue
+		}
+
+		// The step finished but its status was not saved: apply the stored result
+		if result, err := s.loadStepResult(ctx, plan.ID, step.ID); err == nil {
+			if err := s.finishStep(ctx, task, plan, step, result); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if step.NeedsApproval() {
+			if err := s.setStepStatus(ctx, task, plan.ID, step, domain.StepStatusFailed); err != nil {
+				return err
+			}
+			return s.failRecovery(ctx, task, previous,
+				fmt.Sprintf("gated step %s was interrupted by a restart and is not safe to re-run", step.ID))
+		}
+
+		if err := s.setStepStatus(ctx, task, plan.ID, step, domain.StepStatusPending); err != nil {
+			return err
+		}
+		requeued = append(requeued, step.ID)
+	}
+
+	s.recordRecovery(ctx, task, previous, recoveryRequeued, "execution resumes after the last completed step", requeued)
+	return s.requeueRecovered(ctx, task)
+}
+
+// requeueRecovered queues a task that was in flight at the crash for the worker pool.
+// Purpose: Marks the task as recovered so RunTask resumes it in its current status (tasks in
+//          PLANNING, EXECUTING or VERIFYING are otherwise skipped as stale queue entries).
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task with its interrupted steps already settled
+// Outputs:
+//   - error: Returns error if the task could not be queued
+func (s *OrchestratorService) requeueRecovered(ctx context.Context, task *domain.Task) error {
+	s.mu.Lock()
+	s.recovered[task.ID] = struct{}{}
+	s.mu.Unlock()
+
+	if err := s.enqueueTask(ctx, task); err != nil {
+		s.mu.Lock()
+		delete(s.recovered, task.ID)
+		s.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// claimRecovered reports whether a dequeued task was queued by RecoverTasks and removes its
+// mark, so a task queued twice is resumed only once.
+func (s *OrchestratorService) claimRecovered(taskID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, recovered := s.recovered[taskID]
+	delete(s.recovered, taskID)
+	return recovered
+}
+
+// resumeRecovered continues a task queued by crash recovery.
+// Purpose: Called by RunTask when a recovered task is dequeued (see claimRecovered). The task
+//          is planned again (PLANNING without a plan), its plan resumed (PLANNING, EXECUTING)
+//          or verification run again (VERIFYING); a task that moved on meanwhile (e.g.,
+//          canceled) is skipped.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (owned by the worker)
+//   - task: The recovered task (mutated in place)
+// Outputs:
+//   - error: Returns error if the plan could not be loaded or task state could not be persisted
+func (s *OrchestratorService) resumeRecovered(ctx context.Context, task *domain.Task) error {
+	if task.Status == domain.TaskStatusPlanning && task.PlanID == "" {
+		return s.runExecution(ctx, task, func(execCtx context.Context) error {
+			return s.runTask(execCtx, task)
+		})
+	}
+	if task.Status != domain.TaskStatusPlanning && task.Status != domain.TaskStatusExecuting && task.Status != domain.TaskStatusVerifying {
+		return nil
+	}
+
+	plan, err := s.plans.GetPlan(ctx, task.PlanID)
+	if err != nil {
+		return fmt.Errorf("failed to load plan: %w", err)
+	}
+	return s.runExecution(ctx, task, func(execCtx context.Context) error {
+		if task.Status == domain.TaskStatusVerifying {
+			return s.verifyPlan(execCtx, task, plan)
+		}
+		return s.executePlan(execCtx, task, plan)
+	})
+}
+
+// recoverApproval handles a task that was suspended on an approval gate.
+// Purpose: Keeps the task waiting if the approval is still OPEN, re-creates a missing
+//          approval request, and queues the task again if a decision was saved before the
+//          crash (the worker applies it).
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The suspended task (mutated in place)
+//   - plan: The task's current plan
+// Outputs:
+//   - error: Returns error if task, plan or approval state could not be persisted
+func (s *OrchestratorService) recoverApproval(ctx context.Context, task *domain.Task, plan *domain.Plan) error {
+	previous := task.Status
+
+	step := findStep(plan, task.CurrentStepID)
+	if step == nil {
+		return s.failRecovery(ctx, task, previous, fmt.Sprintf("gated step %s not found in plan", task.CurrentStepID))
+	}
+
+	approval, err := s.findStepApproval(ctx, task.ID, step.ID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case approval == nil:
+		s.recordRecovery(ctx, task, previous, recoveryAwaitingApproval, "approval request re-created", nil)
+		return s.requestApproval(ctx, task, step)
+	case approval.Status == domain.ApprovalStatusOpen:
+		s.recordRecovery(ctx, task, previous, recoveryAwaitingApproval, "approval still open", nil)
+		return nil
+	default:
+		s.recordRecovery(ctx, task, previous, recoveryRequeued, fmt.Sprintf("approval already decided: %s", approval.Status), nil)
+		return s.enqueueTask(ctx, task)
+	}
+}
+
+// recoverSubTasks handles a task that was suspended on its sub-tasks.
+// Purpose: Children are recovered on their own and wake the task when they finish; a task
+//          whose children finished before the crash is queued again right away.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The suspended task
+// Outputs:
+//   - error: Returns error if the children could not be checked or the task could not be queued
+func (s *OrchestratorService) recoverSubTasks(ctx context.Context, task *domain.Task) error {
+	previous := task.Status
+
+	s.subTaskMu.Lock()
+	settled, err := s.subTasksSettled(ctx, task)
+	s.subTaskMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if !settled {
+		s.recordRecovery(ctx, task, previous, recoveryAwaitingSubTasks, "sub-tasks still running", nil)
+		return nil
+	}
+
+	s.recordRecovery(ctx, task, previous, recoveryRequeued, "sub-tasks finished", nil)
+	return s.enqueueTask(ctx, task)
+}
+
+// recoverBudget handles a task that was paused over budget.
+// Purpose: Keeps the task paused while its budget is still exhausted; a task whose limit was
+//          raised, or whose daily or monthly period has rolled over, is queued again.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The paused task
+// Outputs:
+//   - error: Returns error if the task could not be queued
+func (s *OrchestratorService) recoverBudget(ctx context.Context, task *domain.Task) error {
+	previous := task.Status
+
+	s.budgetMu.Lock()
+	reason := s.budgetExceeded(ctx, task)
+	s.budgetMu.Unlock()
+
+	if reason != "" {
+		s.recordRecovery(ctx, task, previous, recoveryAwaitingBudget, reason, nil)
+		return nil
+	}
+
+	s.recordRecovery(ctx, task, previous, recoveryRequeued, "budget available again", nil)
+	return s.enqueueTask(ctx, task)
+}
+
+// failRecovery finishes a task that cannot be resumed safely.
+func (s *OrchestratorService) failRecovery(ctx context.Context, task *domain.Task, previous domain.TaskStatus, reason string) error {
+	s.recordRecovery(ctx, task, previous, recoveryFailed, reason, nil)
+	return s.finishTask(ctx, task, domain.TaskStatusFailed, fmt.Sprintf("recovery failed: %s", reason))
+}
+
+// recordRecovery emits a TASK_RECOVERED audit event.
+func (s *OrchestratorService) recordRecovery(ctx context.Context, task *domain.Task, previous domain.TaskStatus, action string, reason string, requeued []string) {
+	s.recordEvent(ctx, task, "TASK_RECOVERED", systemActor, map[string]interface{}{
+		"task_id":         task.ID,
+		"previous_status": string(previous),
+		"action":          action,
+		"reason":          reason,
+		"plan_id":         task.PlanID,
+		"current_step_id": task.CurrentStepID,
+		"requeued_steps":  requeued,
+	})
+}
+
+// isRunning reports whether an execution of the task is active in this process.
+func (s *OrchestratorService) isRunning(taskID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, running := s.running[taskID]
+	return running
+}
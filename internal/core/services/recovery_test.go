@@ -0,0 +1,293 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// crashedTask is the state a previous process left in the stores.
+type crashedTask struct {
+	status        domain.TaskStatus
+	steps         []domain.Step // No plan is saved if empty
+	results       []string      // Steps whose result was saved
+	currentStepID string
+	approval      domain.ApprovalStatus // Approval of currentStepID saved before the crash, if set
+}
+
+// seed saves the crashed task, its plan, results and approval as a restarted process finds them.
+func (h *harness) seed(t *testing.T, crashed crashedTask) *domain.Task {
+	t.Helper()
+	ctx := context.Background()
+	now := h.clock.Now()
+
+	task := &domain.Task{
+		ID:            "crashed-task",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Status:        crashed.status,
+		Priority:      domain.TaskPriorityNormal,
+		Input:         "survive a restart",
+		UserID:        "alice",
+		CurrentStepID: crashed.currentStepID,
+	}
+	if len(crashed.steps) > 0 {
+		plan := &domain.Plan{ID: "crashed-plan", TaskID: task.ID, Goal: task.Input, Steps: crashed.steps}
+		if err := h.deps.Plans.SavePlan(ctx, plan); err != nil {
+			t.Fatalf("SavePlan = %v", err)
+		}
+		task.PlanID = plan.ID
+		for _, stepID := range crashed.results {
+			result := &domain.StepResult{StepID: stepID, Success: true, Output: "output of " + stepID}
+			if err := h.deps.Plans.SaveStepResult(ctx, plan.ID, result); err != nil {
+				t.Fatalf("SaveStepResult(%s) = %v", stepID, err)
+			}
+		}
+	}
+	if crashed.approval != "" {
+		approval := &domain.ApprovalRequest{
+			ID:        "crashed-approval",
+			TaskID:    task.ID,
+			StepID:    crashed.currentStepID,
+			UserID:    task.UserID,
+			Status:    crashed.approval,
+			CreatedAt: now,
+		}
+		if crashed.approval != domain.ApprovalStatusOpen {
+			approval.ApprovedBy = "bob"
+			approval.DecidedAt = now
+		}
+		if err := h.deps.Approvals.SaveApproval(ctx, approval); err != nil {
+			t.Fatalf("SaveApproval = %v", err)
+		}
+	}
+	if err := h.deps.Repo.SaveTask(ctx, task); err != nil {
+		t.Fatalf("SaveTask = %v", err)
+	}
+	return task
+}
+
+func TestOrchestratorRecoverTasks(t *testing.T) {
+	step := func(id string, status domain.StepStatus, dependsOn ...string) domain.Step {
+		return domain.Step{ID: id, Type: domain.StepTypeThink, Status: status, DependsOn: dependsOn}
+	}
+	gated := func(id string, status domain.StepStatus, dependsOn ...string) domain.Step {
+		s := step(id, status, dependsOn...)
+		s.RequiresApproval = true
+		return s
+	}
+	pending := []domain.Step{
+		step("a", domain.StepStatusPending),
+		step("b", domain.StepStatusPending, "a"),
+		step("c", domain.StepStatusPending, "b"),
+	}
+
+	tests := []struct {
+		name          string
+		crashed       crashedTask
+		wantRecovered domain.TaskStatus // Status returned by RecoverTasks
+		approve       bool              // Approve step b after recovery
+		wantStatus    domain.TaskStatus
+		wantCalls     map[string]int
+		wantReason    string
+	}{
+		{
+			name:          "queued task runs from scratch",
+			crashed:       crashedTask{status: domain.TaskStatusNew},
+			wantRecovered: domain.TaskStatusNew,
+			wantStatus:    domain.TaskStatusDone,
+			wantCalls:     map[string]int{"a": 1, "b": 1, "c": 1},
+		},
+		{
+			name:          "planning without a plan plans again",
+			crashed:       crashedTask{status: domain.TaskStatusPlanning},
+			wantRecovered: domain.TaskStatusPlanning,
+			wantStatus:    domain.TaskStatusDone,
+			wantCalls:     map[string]int{"a": 1, "b": 1, "c": 1},
+		},
+		{
+			name: "interrupted step runs again after the completed ones",
+			crashed: crashedTask{
+				status: domain.TaskStatusExecuting,
+				steps: []domain.Step{
+					step("a", domain.StepStatusCompleted),
+					step("b", domain.StepStatusInProgress, "a"),
+					step("c", domain.StepStatusPending, "b"),
+				},
+				results: []string{"a"},
+			},
+			wantRecovered: domain.TaskStatusExecuting,
+			wantStatus:    domain.TaskStatusDone,
+			wantCalls:     map[string]int{"a": 0, "b": 1, "c": 1},
+		},
+		{
+			name: "finished step whose status was not saved keeps its result",
+			crashed: crashedTask{
+				status: domain.TaskStatusExecuting,
+				steps: []domain.Step{
+					step("a", domain.StepStatusCompleted),
+					step("b", domain.StepStatusInProgress, "a"),
+					step("c", domain.StepStatusPending, "b"),
+				},
+				results: []string{"a", "b"},
+			},
+			wantRecovered: domain.TaskStatusExecuting,
+			wantStatus:    domain.TaskStatusDone,
+			wantCalls:     map[string]int{"a": 0, "b": 0, "c": 1},
+		},
+		{
+			name: "interrupted gated step is not re-run",
+			crashed: crashedTask{
+				status: domain.TaskStatusExecuting,
+				steps: []domain.Step{
+					step("a", domain.StepStatusCompleted),
+					gated("b", domain.StepStatusInProgress, "a"),
+					step("c", domain.StepStatusPending, "b"),
+				},
+				results: []string{"a"},
+			},
+			wantRecovered: domain.TaskStatusFailed,
+			wantStatus:    domain.TaskStatusFailed,
+			wantCalls:     map[string]int{"a": 0, "b": 0, "c": 0},
+			wantReason:    "recovery failed: gated step b was interrupted by a restart and is not safe to re-run",
+		},
+		{
+			name: "verification runs again",
+			crashed: crashedTask{
+				status: domain.TaskStatusVerifying,
+				steps: []domain.Step{
+					step("a", domain.StepStatusCompleted),
+					step("b", domain.StepStatusCompleted, "a"),
+				},
+				results: []string{"a", "b"},
+			},
+			wantRecovered: domain.TaskStatusVerifying,
+			wantStatus:    domain.TaskStatusDone,
+			wantCalls:     map[string]int{"a": 0, "b": 0},
+		},
+		{
+			name: "open approval keeps waiting",
+			crashed: crashedTask{
+				status: domain.TaskStatusWaitingApproval,
+				steps: []domain.Step{
+					step("a", domain.StepStatusCompleted),
+					gated("b", domain.StepStatusPending, "a"),
+					step("c", domain.StepStatusPending, "b"),
+				},
+				results:       []string{"a"},
+				currentStepID: "b",
+				approval:      domain.ApprovalStatusOpen,
+			},
+			wantRecovered: domain.TaskStatusWaitingApproval,
+			approve:       true,
+			wantStatus:    domain.TaskStatusDone,
+			wantCalls:     map[string]int{"a": 0, "b": 1, "c": 1},
+		},
+		{
+			name: "lost approval request is re-created",
+			crashed: crashedTask{
+				status: domain.TaskStatusWaitingApproval,
+				steps: []domain.Step{
+					step("a", domain.StepStatusCompleted),
+					gated("b", domain.StepStatusPending, "a"),
+					step("c", domain.StepStatusPending, "b"),
+				},
+				results:       []string{"a"},
+				currentStepID: "b",
+			},
+			wantRecovered: domain.TaskStatusWaitingApproval,
+			approve:       true,
+			wantStatus:    domain.TaskStatusDone,
+			wantCalls:     map[string]int{"a": 0, "b": 1, "c": 1},
+		},
+		{
+			name: "approval decided before the crash is applied",
+			crashed: crashedTask{
+				status: domain.TaskStatusWaitingApproval,
+				steps: []domain.Step{
+					step("a", domain.StepStatusCompleted),
+					gated("b", domain.StepStatusPending, "a"),
+					step("c", domain.StepStatusPending, "b"),
+				},
+				results:       []string{"a"},
+				currentStepID: "b",
+				approval:      domain.ApprovalStatusApproved,
+			},
+			wantRecovered: domain.TaskStatusWaitingApproval,
+			wantStatus:    domain.TaskStatusDone,
+			wantCalls:     map[string]int{"a": 0, "b": 1, "c": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHarness(t, pending, newScriptedExecutor())
+			ctx := context.Background()
+			task := h.seed(t, tt.crashed)
+
+			recovered, err := h.orchestrator.RecoverTasks(ctx)
+			if err != nil {
+				t.Fatalf("RecoverTasks = %v", err)
+			}
+			if len(recovered) != 1 || recovered[0].ID != task.ID {
+				t.Fatalf("RecoverTasks returned %d tasks, want the crashed task", len(recovered))
+			}
+			if recovered[0].Status != tt.wantRecovered {
+				t.Errorf("recovered status = %s, want %s", recovered[0].Status, tt.wantRecovered)
+			}
+			if n := h.audit.count("TASK_RECOVERED"); n != 1 {
+				t.Errorf("TASK_RECOVERED events = %d, want 1", n)
+			}
+
+			if tt.approve {
+				h.waitForStatus(t, task.ID, domain.TaskStatusWaitingApproval)
+				approvals, err := h.orchestrator.GetTaskApprovals(ctx, task.ID)
+				if err != nil {
+					t.Fatalf("GetTaskApprovals = %v", err)
+				}
+				if len(approvals) != 1 || approvals[0].Status != domain.ApprovalStatusOpen {
+					t.Fatalf("approvals = %+v, want one OPEN approval", approvals)
+				}
+				if n := h.executor.callCount("b"); n != 0 {
+					t.Fatalf("gated step b ran %d times before it was approved", n)
+				}
+				if err := h.orchestrator.HandleApproval(ctx, task.ID, "b", true, "bob", "after restart"); err != nil {
+					t.Fatalf("HandleApproval = %v", err)
+				}
+			}
+
+			done := h.waitForStatus(t, task.ID, tt.wantStatus)
+			if reason := done.Metadata["failure_reason"]; reason != tt.wantReason {
+				t.Errorf("failure_reason = %q, want %q", reason, tt.wantReason)
+			}
+			for stepID, want := range tt.wantCalls {
+				if n := h.executor.callCount(stepID); n != want {
+					t.Errorf("step %s ran %d times, want %d", stepID, n, want)
+				}
+			}
+		})
+	}
+}
+
+func TestOrchestratorRecoverTasksSkipsFinishedTasks(t *testing.T) {
+	h := newHarness(t, []domain.Step{{ID: "a", Type: domain.StepTypeThink, Status: domain.StepStatusPending}}, newScriptedExecutor())
+	ctx := context.Background()
+
+	task, err := h.orchestrator.StartTask(ctx, "finish first", "alice", domain.TaskOptions{})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	h.waitForStatus(t, task.ID, domain.TaskStatusDone)
+
+	recovered, err := h.orchestrator.RecoverTasks(ctx)
+	if err != nil {
+		t.Fatalf("RecoverTasks = %v", err)
+	}
+	if len(recovered) != 0 {
+		t.Errorf("RecoverTasks returned %d tasks, want none", len(recovered))
+	}
+	if n := h.executor.callCount("a"); n != 1 {
+		t.Errorf("step a ran %d times, want 1", n)
+	}
+}
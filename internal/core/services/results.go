@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// inlineResult serves an output kept in StepResult.Output like a stored one.
+type inlineResult struct {
+	*strings.Reader
+}
+
+// Close implements io.Closer; inline outputs hold no resources.
+func (inlineResult) Close() error {
+	return nil
+}
+
+// offloadResult moves an output larger than ResultInlineMaxBytes to the ResultStore.
+// Purpose: Keeps step results small for task and plan reads. The result keeps a preview of
+//          the output (up to ResultPreviewBytes), its full size and the store reference, which is mirrored in
+//          Step.ResultRef (persisted with the step's next status change).
+//          If storing fails the output stays inline: fidelity wins over size.
+// Inputs:
+//   - ctx: Context of the task execution
+//   - task: The parent task
+//   - planID: Unique identifier of the plan owning the step
+//   - step: The executed step (ResultRef is set in place)
+//   - result: The execution result (Output is replaced by its preview in place)
+// Outputs: None
+func (s *OrchestratorService) offloadResult(ctx context.Context, task *domain.Task, planID string, step *domain.Step, result *domain.StepResult) {
+	limit := s.cfg.ResultInlineMaxBytes
+	if s.results == nil || limit <= 0 || len(result.Output) <= limit || result.Offloaded() {
+		return
+	}
+
+	ref, err := s.results.PutResult(context.WithoutCancel(ctx), task.ID, planID, step.ID, result.Output)
+	if err != nil {
+		s.logger.Warn("failed to offload step output, keeping it inline", map[string]interface{}{
+			"error":       err.Error(),
+			"task_id":     task.ID,
+			"step_id":     step.ID,
+			"output_size": len(result.Output),
+		})
+		return
+	}
+
+	result.OutputRef = ref
+	result.OutputSize = int64(len(result.Output))
+	result.Output = previewOutput(result.Output, min(limit, s.cfg.ResultPreviewBytes))
+	step.ResultRef = ref
+}
+
+// previewOutput returns at most limit bytes of output, cut at a UTF-8 character boundary.
+func previewOutput(output string, limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	if len(output) <= limit {
+		return output
+	}
+	for limit > 0 && !utf8.RuneStart(output[limit]) {
+		limit--
+	}
+	return output[:limit]
+}
+
+// loadStepResult loads a step result with its full output.
+// Purpose: Gives verification, sub-task aggregation and recovery the output as the executor
+//          returned it, reading offloaded outputs back from the ResultStore.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - planID: Unique identifier of the plan owning the step
+//   - stepID: Unique identifier of the step
+// Outputs:
+//   - *domain.StepResult: The result with its full Output (OutputRef and OutputSize cleared)
+//   - error: Returns error if no result is stored or an offloaded output cannot be read
+func (s *OrchestratorService) loadStepResult(ctx context.Context, planID string, stepID string) (*domain.StepResult, error) {
+	result, err := s.plans.GetStepResult(ctx, planID, stepID)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Offloaded() {
+		return result, nil
+	}
+
+	output, err := s.openResult(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	defer output.Close()
+
+	full, err := io.ReadAll(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output of step %s: %w", stepID, err)
+	}
+
+	result.Output = string(full)
+	result.OutputRef = ""
+	result.OutputSize = 0
+	return result, nil
+}
+
+// openResult opens the full output of a result, wherever it is kept.
+func (s *OrchestratorService) openResult(ctx context.Context, result *domain.StepResult) (io.ReadSeekCloser, error) {
+	if !result.Offloaded() {
+		return inlineResult{strings.NewReader(result.Output)}, nil
+	}
+	if s.results == nil {
+		return nil, fmt.Errorf("result storage is not configured")
+	}
+
+	output, err := s.results.OpenResult(ctx, result.OutputRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open output of step %s: %w", result.StepID, err)
+	}
+	return output, nil
+}
+
+// GetStepOutput opens the full output of an executed step.
+// Purpose: Serves outputs of any size for debugging, whether kept inline or offloaded to
+//          the ResultStore, without loading them into memory.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task
+//   - stepID: Unique identifier of the step
+//   - planID: Plan revision owning the step (empty: the task's current plan)
+// Outputs:
+//   - *domain.StepResult: The stored result (Output is a preview if it was offloaded)
+//   - io.ReadSeekCloser: The full output; the caller must close it
+//   - error: Returns error if the task, plan or step result is not found, or the output
+//            cannot be opened
+func (s *OrchestratorService) GetStepOutput(ctx context.Context, taskID string, stepID string, planID string) (*domain.StepResult, io.ReadSeekCloser, error) {
+	if taskID == "" {
+		return nil, nil, fmt.Errorf("taskID cannot be empty")
+	}
+	if stepID == "" {
+		return nil, nil, fmt.Errorf("stepID cannot be empty")
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load task: %w", err)
+	}
+	if planID == "" {
+		planID = task.PlanID
+	}
+	if planID == "" {
+		return nil, nil, fmt.Errorf("plan not found for task %s", taskID)
+	}
+
+	plan, err := s.plans.GetPlan(ctx, planID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load plan: %w", err)
+	}
+	if plan.TaskID != taskID {
+		return nil, nil, fmt.Errorf("plan %s not found for task %s", planID, taskID)
+	}
+
+	result, err := s.plans.GetStepResult(ctx, planID, stepID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load step result: %w", err)
+	}
+
+	output, err := s.openResult(ctx, result)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, output, nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPreviewOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		limit  int
+		want   string
+	}{
+		{name: "shorter than the limit", output: "hello", limit: 10, want: "hello"},
+		{name: "exactly the limit", output: "hello", limit: 5, want: "hello"},
+		{name: "ascii cut", output: "hello world", limit: 5, want: "hello"},
+		{name: "cut inside a two-byte rune", output: "aé", limit: 2, want: "a"},
+		{name: "cut after a two-byte rune", output: "aéb", limit: 3, want: "aé"},
+		{name: "cut inside a three-byte rune", output: "ab€", limit: 4, want: "ab"},
+		{name: "cut inside a four-byte rune", output: "😀😀", limit: 7, want: "😀"},
+		{name: "limit smaller than the first rune", output: "😀", limit: 3, want: ""},
+		{name: "zero limit", output: "hello", limit: 0, want: ""},
+		{name: "negative limit", output: "hello", limit: -1, want: ""},
+		{name: "empty output", output: "", limit: 4, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := previewOutput(tt.output, tt.limit)
+			if got != tt.want {
+				t.Errorf("previewOutput(%q, %d) = %q, want %q", tt.output, tt.limit, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("previewOutput(%q, %d) = %q is not valid UTF-8", tt.output, tt.limit, got)
+			}
+		})
+	}
+}
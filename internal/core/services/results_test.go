@@ -0,0 +1,184 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/JAROBOTAI/jaro/internal/adapters/filesystem"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// failingResults is a ResultStore that is always unavailable.
+type failingResults struct{}
+
+func (failingResults) PutResult(ctx context.Context, taskID string, planID string, stepID string, output string) (string, error) {
+	return "", errors.New("disk full")
+}
+
+func (failingResults) OpenResult(ctx context.Context, ref string) (io.ReadSeekCloser, error) {
+	return nil, errors.New("disk full")
+}
+
+// withResults offloads outputs above inlineMax bytes to store, keeping previewBytes inline.
+func withResults(store ports.ResultStore, inlineMax int, previewBytes int) harnessOption {
+	return func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		deps.Results = store
+		cfg.ResultInlineMaxBytes = inlineMax
+		cfg.ResultPreviewBytes = previewBytes
+	}
+}
+
+func TestOrchestratorOffloadsLargeOutputs(t *testing.T) {
+	const (
+		small = "exactly sixteen!"                    // 16 bytes: at the limit, kept inline
+		large = "héllo wörld, this output is too big" // 37 bytes with two-byte runes
+	)
+	store, err := filesystem.NewResultStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewResultStore = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		store       ports.ResultStore
+		preview     int
+		wantOffload bool
+		wantPreview string
+	}{
+		{name: "above the threshold", store: store, preview: 2, wantOffload: true, wantPreview: "h"}, // Cut inside the two-byte é
+		{name: "preview larger than the threshold", store: store, preview: 64, wantOffload: true, wantPreview: "héllo wörld, t"},
+		{name: "no preview", store: store, preview: 0, wantOffload: true, wantPreview: ""},
+		{name: "store unavailable keeps it inline", store: failingResults{}, preview: 8, wantOffload: false, wantPreview: large},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := newScriptedExecutor()
+			executor.outputs["small"] = small
+			executor.outputs["large"] = large
+			h := newHarness(t, []domain.Step{
+				{ID: "small", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+				{ID: "large", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+			}, executor, withResults(tt.store, len(small), tt.preview))
+
+			task, err := h.orchestrator.StartTask(context.Background(), "produce output", "alice", domain.TaskOptions{})
+			if err != nil {
+				t.Fatalf("StartTask = %v", err)
+			}
+			h.waitForStatus(t, task.ID, domain.TaskStatusDone)
+
+			result := h.stepResult(t, task.ID, "small")
+			if result.Offloaded() || result.Output != small || h.step(t, task.ID, "small").ResultRef != "" {
+				t.Errorf("output at the threshold = %+v, want it inline", result)
+			}
+
+			result = h.stepResult(t, task.ID, "large")
+			if result.Offloaded() != tt.wantOffload {
+				t.Fatalf("Offloaded() = %v, want %v", result.Offloaded(), tt.wantOffload)
+			}
+			if result.Output != tt.wantPreview || !utf8.ValidString(result.Output) {
+				t.Errorf("Output = %q, want %q", result.Output, tt.wantPreview)
+			}
+			if !tt.wantOffload {
+				return
+			}
+			if result.OutputSize != int64(len(large)) {
+				t.Errorf("OutputSize = %d, want %d", result.OutputSize, len(large))
+			}
+			if ref := h.step(t, task.ID, "large").ResultRef; ref != result.OutputRef {
+				t.Errorf("step ResultRef = %q, want %q", ref, result.OutputRef)
+			}
+		})
+	}
+}
+
+func TestOrchestratorGetStepOutput(t *testing.T) {
+	const large = "0123456789abcdefghijklmnopqrstuvwxyz"
+	store, err := filesystem.NewResultStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewResultStore = %v", err)
+	}
+
+	executor := newScriptedExecutor()
+	executor.outputs["inline"] = "short"
+	executor.outputs["stored"] = large
+	h := newHarness(t, []domain.Step{
+		{ID: "inline", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+		{ID: "stored", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+	}, executor, withResults(store, 16, 4))
+
+	task, err := h.orchestrator.StartTask(context.Background(), "produce output", "alice", domain.TaskOptions{})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	h.waitForStatus(t, task.ID, domain.TaskStatusDone)
+
+	tests := []struct {
+		name   string
+		stepID string
+		offset int64
+		whence int
+		length int
+		want   string
+	}{
+		{name: "whole inline output", stepID: "inline", whence: io.SeekStart, length: 100, want: "short"},
+		{name: "inline range", stepID: "inline", offset: 1, whence: io.SeekStart, length: 3, want: "hor"},
+		{name: "whole stored output", stepID: "stored", whence: io.SeekStart, length: 100, want: large},
+		{name: "stored range from the start", stepID: "stored", offset: 10, whence: io.SeekStart, length: 6, want: "abcdef"},
+		{name: "stored suffix", stepID: "stored", offset: -4, whence: io.SeekEnd, length: 100, want: "wxyz"},
+		{name: "beyond the end", stepID: "stored", offset: 100, whence: io.SeekStart, length: 10, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, output, err := h.orchestrator.GetStepOutput(context.Background(), task.ID, tt.stepID, "")
+			if err != nil {
+				t.Fatalf("GetStepOutput(%s) = %v", tt.stepID, err)
+			}
+			defer output.Close()
+			if result.StepID != tt.stepID {
+				t.Errorf("result StepID = %s, want %s", result.StepID, tt.stepID)
+			}
+
+			if _, err := output.Seek(tt.offset, tt.whence); err != nil {
+				t.Fatalf("Seek(%d, %d) = %v", tt.offset, tt.whence, err)
+			}
+			got, err := io.ReadAll(io.LimitReader(output, int64(tt.length)))
+			if err != nil {
+				t.Fatalf("read = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("range = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, _, err := h.orchestrator.GetStepOutput(context.Background(), task.ID, "missing", ""); err == nil {
+		t.Error("GetStepOutput of an unknown step succeeded")
+	}
+	if _, _, err := h.orchestrator.GetStepOutput(context.Background(), task.ID, "stored", "other-plan"); err == nil {
+		t.Error("GetStepOutput of another plan succeeded")
+	}
+	if _, _, err := h.orchestrator.GetStepOutput(context.Background(), "missing", "stored", ""); err == nil {
+		t.Error("GetStepOutput of an unknown task succeeded")
+	}
+}
+
+// stepResult returns the stored result of a step of the task's current plan.
+func (h *harness) stepResult(t *testing.T, taskID string, stepID string) *domain.StepResult {
+	t.Helper()
+	task, err := h.orchestrator.GetTaskStatus(context.Background(), taskID)
+	if err != nil {
+		t.Fatalf("GetTaskStatus(%s) = %v", taskID, err)
+	}
+	result, err := h.deps.Plans.GetStepResult(context.Background(), task.PlanID, stepID)
+	if err != nil {
+		t.Fatalf("GetStepResult(%s) = %v", stepID, err)
+	}
+	return result
+}
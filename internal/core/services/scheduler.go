@@ -0,0 +1,492 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// Task metadata keys set on tasks started by a schedule
+const (
+	metadataScheduleID   = "schedule_id"
+	metadataScheduledFor = "scheduled_for"
+)
+
+// SchedulerService is the core implementation of the Scheduler interface.
+// It stores schedules through the ScheduleRepository port and runs a loop driven by
+// ports.Clock that starts a task through the Orchestrator whenever a schedule is due.
+type SchedulerService struct {
+	schedules    ports.ScheduleRepository
+	orchestrator ports.Orchestrator
+	audit        ports.AuditRepository
+	clock        ports.Clock
+	idGen        ports.IDGenerator
+	logger       ports.Logger
+	cfg          SchedulerConfig
+
+	mu sync.Mutex // serializes schedule updates between the loop and API calls
+	wg sync.WaitGroup
+}
+
+// NewScheduler creates a new SchedulerService instance with the required dependencies.
+// Purpose: Factory function for creating the scheduler with dependency injection.
+// Inputs:
+//   - schedules: Implementation of the ScheduleRepository port for schedule persistence
+//   - orchestrator: Orchestrator whose StartTask is called for every due run
+//   - audit: Implementation of the AuditRepository port for audit logging
+//   - clock: Implementation of the Clock port that drives due checks
+//   - idGen: Implementation of the IDGenerator port for ID generation
+//   - logger: Implementation of the Logger port for structured logging
+//   - cfg: Scheduling policies (see DefaultSchedulerConfig)
+// Outputs:
+//   - *SchedulerService: Scheduler ready to serve API calls and to be started
+func NewScheduler(
+	schedules ports.ScheduleRepository,
+	orchestrator ports.Orchestrator,
+	audit ports.AuditRepository,
+	clock ports.Clock,
+	idGen ports.IDGenerator,
+	logger ports.Logger,
+	cfg SchedulerConfig,
+) *SchedulerService {
+	return &SchedulerService{
+		schedules:    schedules,
+		orchestrator: orchestrator,
+		audit:        audit,
+		clock:        clock,
+		idGen:        idGen,
+		logger:       logger,
+		cfg:          cfg,
+	}
+}
+
+// Start launches the scheduler loop in the background.
+// Purpose: Checks for due schedules immediately and then every PollInterval until ctx is done.
+//          Deadlines of suspended tasks are expired by the DeadlineWatcher, not by this loop.
+// Inputs:
+//   - ctx: Lifetime of the loop (cancel it to stop the scheduler)
+// Outputs: None
+func (s *SchedulerService) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			if _, err := s.RunDue(ctx); err != nil {
+				s.logger.Error("failed to run due schedules", err, nil)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.clock.After(s.cfg.PollInterval):
+			}
+		}
+	}()
+
+	s.logger.Info("scheduler started", map[string]interface{}{
+		"poll_interval": s.cfg.PollInterval.String(),
+	})
+}
+
+// Wait blocks until the scheduler loop has stopped.
+// Purpose: Lets the caller finish a graceful shutdown after canceling the loop context.
+// Inputs: None
+// Outputs: None
+func (s *SchedulerService) Wait() {
+	s.wg.Wait()
+}
+
+// RunDue starts tasks for every ACTIVE schedule whose next run is due.
+// Purpose: One iteration of the scheduler loop; exported so callers can trigger a check.
+//          Runs missed while the process was down are handled per MissedRunPolicy:
+//          SKIP starts only the latest run and only within MisfireGrace, CATCH_UP starts
+//          up to MaxCatchUpRuns missed runs oldest first. Dropped runs emit SCHEDULE_RUNS_SKIPPED
+//          with the first dropped run and the run before which all were dropped.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+// Outputs:
+//   - int: Number of tasks started
+//   - error: Returns error if schedules could not be listed; per-schedule failures are
+//            joined into the error but do not stop the remaining schedules
+func (s *SchedulerService) RunDue(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	due, err := s.schedules.ListSchedules(ctx, domain.ScheduleFilter{
+		Status: domain.ScheduleStatusActive,
+		DueBy:  now,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due schedules: %w", err)
+	}
+
+	started := 0
+	var errs []error
+	for _, schedule := range due {
+		n, err := s.runSchedule(ctx, schedule, now)
+		started += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("schedule %s: %w", schedule.ID, err))
+		}
+	}
+
+	return started, errors.Join(errs...)
+}
+
+// runSchedule starts the runs of one due schedule and advances its NextRunAt.
+// Only the runs that may still start are walked: at most MaxCatchUpRuns under CATCH_UP and
+// those within MisfireGrace under SKIP, so a schedule missed for months costs no more
+// than one missed for minutes. Skipped runs are therefore reported as a range, not counted.
+func (s *SchedulerService) runSchedule(ctx context.Context, schedule *domain.Schedule, now time.Time) (int, error) {
+	next, err := schedule.NextRunAfter(now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute next run: %w", err)
+	}
+
+	// Decide which occurrences still run; every other one from NextRunAt up to now is skipped
+	var runs []time.Time
+	var firstSkipped time.Time
+	skippedUntil := next
+	switch schedule.MissedRunPolicy {
+	case domain.MissedRunCatchUp:
+		runs, err = dueRuns(schedule, schedule.NextRunAt, now, max(s.cfg.MaxCatchUpRuns, 1))
+		if err != nil {
+			return 0, err
+		}
+		if len(runs) > 0 {
+			following, err := schedule.NextRunAfter(runs[len(runs)-1])
+			if err != nil {
+				return 0, fmt.Errorf("failed to compute next run: %w", err)
+			}
+			if !following.IsZero() && !following.After(now) {
+				firstSkipped = following
+			}
+		}
+	default:
+		// Look only at the grace window; the latest run in it starts
+		from := schedule.NextRunAt
+		if windowStart := now.Add(-s.cfg.MisfireGrace); from.Before(windowStart) {
+			if from, err = schedule.NextRunAfter(windowStart.Add(-time.Nanosecond)); err != nil {
+				return 0, fmt.Errorf("failed to compute next run: %w", err)
+			}
+		}
+		window, err := dueRuns(schedule, from, now, math.MaxInt)
+		if err != nil {
+			return 0, err
+		}
+		if len(window) > 0 {
+			runs = window[len(window)-1:]
+			skippedUntil = runs[0]
+		}
+		if !schedule.NextRunAt.Equal(skippedUntil) {
+			firstSkipped = schedule.NextRunAt
+		}
+	}
+
+	started := 0
+	for _, scheduledFor := range runs {
+		task, err := s.orchestrator.StartTask(ctx, schedule.Input, schedule.UserID, domain.TaskOptions{
+			Priority: schedule.Priority,
+			Metadata: map[string]string{
+				metadataScheduleID:   schedule.ID,
+				metadataScheduledFor: scheduledFor.Format(time.RFC3339),
+			},
+		})
+		if err != nil {
+			s.logger.Error("failed to start scheduled task", err, map[string]interface{}{
+				"schedule_id":   schedule.ID,
+				"scheduled_for": scheduledFor,
+			})
+			s.recordEvent(ctx, schedule, "", "SCHEDULE_RUN_FAILED", systemActor, map[string]interface{}{
+				"schedule_id":   schedule.ID,
+				"scheduled_for": scheduledFor,
+				"error":         err.Error(),
+			})
+			continue
+		}
+
+		started++
+		schedule.RunCount++
+		schedule.LastRunAt = scheduledFor
+		schedule.LastTaskID = task.ID
+		s.recordEvent(ctx, schedule, task.ID, "SCHEDULE_TRIGGERED", systemActor, map[string]interface{}{
+			"schedule_id":   schedule.ID,
+			"task_id":       task.ID,
+			"scheduled_for": scheduledFor,
+			"late_by_ms":    now.Sub(scheduledFor).Milliseconds(),
+		})
+	}
+
+	if !firstSkipped.IsZero() {
+		s.recordEvent(ctx, schedule, "", "SCHEDULE_RUNS_SKIPPED", systemActor, map[string]interface{}{
+			"schedule_id": schedule.ID,
+			"policy":      string(schedule.MissedRunPolicy),
+			"first":       firstSkipped,
+			"until":       skippedUntil, // Exclusive; zero if the schedule has no further runs
+		})
+	}
+
+	// Advance to the next run; schedules without one are done
+	schedule.NextRunAt = next
+	if next.IsZero() {
+		schedule.Status = domain.ScheduleStatusCompleted
+	}
+	schedule.UpdatedAt = now
+	if err := s.schedules.SaveSchedule(ctx, schedule); err != nil {
+		return started, fmt.Errorf("failed to save schedule: %w", err)
+	}
+
+	return started, nil
+}
+
+// dueRuns returns up to limit runs of a schedule from the run at from (inclusive) that are
+// due by now, oldest first.
+func dueRuns(schedule *domain.Schedule, from time.Time, now time.Time, limit int) ([]time.Time, error) {
+	var runs []time.Time
+	for next := from; !next.IsZero() && !next.After(now) && len(runs) < limit; {
+		runs = append(runs, next)
+		following, err := schedule.NextRunAfter(next)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute next run: %w", err)
+		}
+		next = following
+	}
+	return runs, nil
+}
+
+// CreateSchedule registers a recurring (cron) or one-shot schedule.
+// Purpose: Validates the definition, computes the first run and persists an ACTIVE schedule.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - spec: Schedule definition (exactly one of CronExpr or RunAt)
+// Outputs:
+//   - *domain.Schedule: The ACTIVE schedule with its first NextRunAt
+//   - error: Returns error wrapping domain.ErrInvalidSchedule or domain.ErrInvalidPriority
+//            if the definition is malformed, or if persistence fails
+func (s *SchedulerService) CreateSchedule(ctx context.Context, spec domain.ScheduleSpec) (*domain.Schedule, error) {
+	if err := spec.Normalize(); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	if !spec.IsRecurring() && !spec.RunAt.After(now) {
+		return nil, fmt.Errorf("%w: run_at must be in the future", domain.ErrInvalidSchedule)
+	}
+	next, err := spec.NextRunAfter(now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidSchedule, err)
+	}
+	if next.IsZero() {
+		return nil, fmt.Errorf("%w: cron expression %q never matches", domain.ErrInvalidSchedule, spec.CronExpr)
+	}
+
+	schedule := &domain.Schedule{
+		ID:           s.idGen.Generate(),
+		ScheduleSpec: spec,
+		Status:       domain.ScheduleStatusActive,
+		NextRunAt:    next,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.schedules.SaveSchedule(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to save schedule: %w", err)
+	}
+
+	s.recordEvent(ctx, schedule, "", "SCHEDULE_CREATED", spec.UserID, map[string]interface{}{
+		"schedule_id":       schedule.ID,
+		"cron_expr":         spec.CronExpr,
+		"run_at":            spec.RunAt,
+		"timezone":          spec.Timezone,
+		"missed_run_policy": string(spec.MissedRunPolicy),
+		"next_run_at":       next,
+	})
+
+	return schedule, nil
+}
+
+// GetSchedule retrieves a schedule by its unique identifier.
+// Purpose: Simple pass-through to the repository layer.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - scheduleID: Unique identifier of the schedule
+// Outputs:
+//   - *domain.Schedule: The schedule
+//   - error: Returns error if the schedule is not found
+func (s *SchedulerService) GetSchedule(ctx context.Context, scheduleID string) (*domain.Schedule, error) {
+	if scheduleID == "" {
+		return nil, fmt.Errorf("scheduleID cannot be empty")
+	}
+
+	schedule, err := s.schedules.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// ListSchedules returns schedules that match the filter.
+// Purpose: Simple pass-through to the repository layer.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - filter: Criteria such as owner and status
+// Outputs:
+//   - []*domain.Schedule: Matching schedules in creation order
+//   - error: Returns error if schedules cannot be loaded
+func (s *SchedulerService) ListSchedules(ctx context.Context, filter domain.ScheduleFilter) ([]*domain.Schedule, error) {
+	schedules, err := s.schedules.ListSchedules(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// PauseSchedule stops a schedule from starting tasks until it is resumed.
+// Purpose: Marks the schedule PAUSED and emits SCHEDULE_PAUSED. Pausing a paused schedule is a no-op.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - scheduleID: Unique identifier of the schedule
+//   - userID: Unique identifier of the user pausing the schedule
+// Outputs:
+//   - *domain.Schedule: The PAUSED schedule
+//   - error: Returns error if not found; wraps domain.ErrScheduleCompleted for finished one-shots
+func (s *SchedulerService) PauseSchedule(ctx context.Context, scheduleID string, userID string) (*domain.Schedule, error) {
+	return s.setScheduleStatus(ctx, scheduleID, userID, domain.ScheduleStatusPaused)
+}
+
+// ResumeSchedule reactivates a paused schedule.
+// Purpose: Marks the schedule ACTIVE and emits SCHEDULE_RESUMED. Recurring schedules continue
+//          with the next run after now, so runs that fell inside the pause are not caught up;
+//          a one-shot whose time passed during the pause is handled by its MissedRunPolicy.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - scheduleID: Unique identifier of the schedule
+//   - userID: Unique identifier of the user resuming the schedule
+// Outputs:
+//   - *domain.Schedule: The ACTIVE schedule with a recomputed NextRunAt
+//   - error: Returns error if not found; wraps domain.ErrScheduleCompleted for finished one-shots
+func (s *SchedulerService) ResumeSchedule(ctx context.Context, scheduleID string, userID string) (*domain.Schedule, error) {
+	return s.setScheduleStatus(ctx, scheduleID, userID, domain.ScheduleStatusActive)
+}
+
+// setScheduleStatus moves a schedule between ACTIVE and PAUSED.
+func (s *SchedulerService) setScheduleStatus(ctx context.Context, scheduleID string, userID string, status domain.ScheduleStatus) (*domain.Schedule, error) {
+	if scheduleID == "" {
+		return nil, fmt.Errorf("scheduleID cannot be empty")
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("userID cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, err := s.schedules.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schedule: %w", err)
+	}
+	if schedule.Status == domain.ScheduleStatusCompleted {
+		return nil, fmt.Errorf("%w: schedule %s", domain.ErrScheduleCompleted, scheduleID)
+	}
+	if schedule.Status == status {
+		return schedule, nil
+	}
+
+	now := s.clock.Now()
+	if status == domain.ScheduleStatusActive && schedule.IsRecurring() {
+		next, err := schedule.NextRunAfter(now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute next run: %w", err)
+		}
+		schedule.NextRunAt = next
+	}
+	schedule.Status = status
+	schedule.UpdatedAt = now
+
+	if err := s.schedules.SaveSchedule(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to save schedule: %w", err)
+	}
+
+	eventType := "SCHEDULE_RESUMED"
+	if status == domain.ScheduleStatusPaused {
+		eventType = "SCHEDULE_PAUSED"
+	}
+	s.recordEvent(ctx, schedule, "", eventType, userID, map[string]interface{}{
+		"schedule_id": schedule.ID,
+		"next_run_at": schedule.NextRunAt,
+	})
+
+	return schedule, nil
+}
+
+// DeleteSchedule removes a schedule permanently.
+// Purpose: Deletes the schedule and emits SCHEDULE_DELETED; tasks it started keep running.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - scheduleID: Unique identifier of the schedule
+//   - userID: Unique identifier of the user deleting the schedule
+// Outputs:
+//   - error: Returns error if the schedule is not found or cannot be deleted
+func (s *SchedulerService) DeleteSchedule(ctx context.Context, scheduleID string, userID string) error {
+	if scheduleID == "" {
+		return fmt.Errorf("scheduleID cannot be empty")
+	}
+	if userID == "" {
+		return fmt.Errorf("userID cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, err := s.schedules.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return fmt.Errorf("failed to load schedule: %w", err)
+	}
+	if err := s.schedules.DeleteSchedule(ctx, scheduleID); err != nil {
+		return fmt.Errorf("failed to delete schedule: %w", err)
+	}
+
+	s.recordEvent(ctx, schedule, "", "SCHEDULE_DELETED", userID, map[string]interface{}{
+		"schedule_id": schedule.ID,
+		"run_count":   schedule.RunCount,
+	})
+
+	return nil
+}
+
+// recordEvent persists an audit event correlated with the schedule.
+// taskID is set for events about a task the schedule started and empty otherwise.
+// Audit failures are logged but never fail the scheduling operation.
+func (s *SchedulerService) recordEvent(ctx context.Context, schedule *domain.Schedule, taskID string, eventType string, actor string, payload map[string]interface{}) {
+	event := &domain.AuditEvent{
+		ID:              s.idGen.Generate(),
+		TaskID:          taskID,
+		CorrelationID:   schedule.ID,
+		Timestamp:       s.clock.Now(),
+		EventType:       eventType,
+		Actor:           actor,
+		BehaviorVersion: behaviorVersion,
+		Payload:         payload,
+	}
+
+	if err := s.audit.SaveEvent(ctx, event); err != nil {
+		s.logger.Warn("failed to save audit event", map[string]interface{}{
+			"error":       err.Error(),
+			"schedule_id": schedule.ID,
+			"event_type":  eventType,
+		})
+	}
+}
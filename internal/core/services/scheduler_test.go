@@ -0,0 +1,300 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/adapters/memory"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// newScheduler creates a scheduler that starts tasks through the harness orchestrator on its clock.
+func (h *harness) newScheduler(cfg services.SchedulerConfig) *services.SchedulerService {
+	return services.NewScheduler(memory.NewScheduleRepository(), h.orchestrator, h.audit, h.clock, &sequenceIDs{}, nopLogger{}, cfg)
+}
+
+// last returns the most recent event of a type, or nil.
+func (a *recordingAudit) last(eventType string) *domain.AuditEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i := len(a.events) - 1; i >= 0; i-- {
+		if a.events[i].EventType == eventType {
+			return a.events[i]
+		}
+	}
+	return nil
+}
+
+// scheduledRuns returns the scheduled_for metadata of the user's tasks, oldest run first.
+func (h *harness) scheduledRuns(t *testing.T, userID string) []string {
+	t.Helper()
+	tasks, err := h.deps.Repo.ListTasks(context.Background(), domain.TaskFilter{UserID: userID})
+	if err != nil {
+		t.Fatalf("ListTasks = %v", err)
+	}
+	runs := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		runs = append(runs, task.Metadata["scheduled_for"])
+	}
+	slices.Sort(runs)
+	return runs
+}
+
+func TestSchedulerHandlesMissedRuns(t *testing.T) {
+	// The harness clock starts at 2026-01-01 12:00 UTC, so hourly schedules first run at 13:00
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2026, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+	rfc := func(times ...time.Time) []string {
+		out := make([]string, 0, len(times))
+		for _, at := range times {
+			out = append(out, at.Format(time.RFC3339))
+		}
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		cron      string
+		policy    domain.MissedRunPolicy
+		advance   time.Duration
+		wantRuns  []string
+		wantFirst time.Time // First skipped run (zero: none skipped)
+		wantUntil time.Time
+		wantNext  time.Time
+	}{
+		{
+			name:     "SKIP on time",
+			cron:     "0 * * * *",
+			policy:   domain.MissedRunSkip,
+			advance:  time.Hour,
+			wantRuns: rfc(at(13, 0)),
+			wantNext: at(14, 0),
+		},
+		{
+			name:     "SKIP late within grace",
+			cron:     "0 * * * *",
+			policy:   domain.MissedRunSkip,
+			advance:  time.Hour + time.Minute,
+			wantRuns: rfc(at(13, 0)),
+			wantNext: at(14, 0),
+		},
+		{
+			name:      "SKIP late outside grace",
+			cron:      "0 * * * *",
+			policy:    domain.MissedRunSkip,
+			advance:   time.Hour + time.Minute + time.Second,
+			wantRuns:  rfc(),
+			wantFirst: at(13, 0),
+			wantUntil: at(14, 0),
+			wantNext:  at(14, 0),
+		},
+		{
+			name:      "SKIP starts only the latest missed run within grace",
+			cron:      "0 * * * *",
+			policy:    domain.MissedRunSkip,
+			advance:   5*time.Hour + 30*time.Second,
+			wantRuns:  rfc(at(17, 0)),
+			wantFirst: at(13, 0),
+			wantUntil: at(17, 0),
+			wantNext:  at(18, 0),
+		},
+		{
+			name:      "SKIP drops every missed run outside grace",
+			cron:      "0 * * * *",
+			policy:    domain.MissedRunSkip,
+			advance:   5*time.Hour + 30*time.Minute,
+			wantRuns:  rfc(),
+			wantFirst: at(13, 0),
+			wantUntil: at(18, 0),
+			wantNext:  at(18, 0),
+		},
+		{
+			name:     "CATCH_UP below the cap",
+			cron:     "0 * * * *",
+			policy:   domain.MissedRunCatchUp,
+			advance:  3*time.Hour + 30*time.Minute,
+			wantRuns: rfc(at(13, 0), at(14, 0), at(15, 0)),
+			wantNext: at(16, 0),
+		},
+		{
+			name:      "CATCH_UP capped at MaxCatchUpRuns",
+			cron:      "0 * * * *",
+			policy:    domain.MissedRunCatchUp,
+			advance:   10 * time.Hour,
+			wantRuns:  rfc(at(13, 0), at(14, 0), at(15, 0), at(16, 0)),
+			wantFirst: at(17, 0),
+			wantUntil: at(23, 0),
+			wantNext:  at(23, 0),
+		},
+		{
+			name:      "CATCH_UP after a year of minutely runs",
+			cron:      "* * * * *",
+			policy:    domain.MissedRunCatchUp,
+			advance:   365 * 24 * time.Hour,
+			wantRuns:  rfc(at(12, 1), at(12, 2), at(12, 3), at(12, 4)),
+			wantFirst: at(12, 5),
+			wantUntil: at(12, 1).AddDate(1, 0, 0),
+			wantNext:  at(12, 1).AddDate(1, 0, 0),
+		},
+		{
+			name:      "SKIP after a year of minutely runs",
+			cron:      "* * * * *",
+			policy:    domain.MissedRunSkip,
+			advance:   365 * 24 * time.Hour,
+			wantRuns:  rfc(at(12, 0).AddDate(1, 0, 0)),
+			wantFirst: at(12, 1),
+			wantUntil: at(12, 0).AddDate(1, 0, 0),
+			wantNext:  at(12, 1).AddDate(1, 0, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHarness(t, thinkStep("a"), newScriptedExecutor())
+			scheduler := h.newScheduler(services.SchedulerConfig{PollInterval: time.Minute, MisfireGrace: time.Minute, MaxCatchUpRuns: 4})
+			ctx := context.Background()
+
+			schedule, err := scheduler.CreateSchedule(ctx, domain.ScheduleSpec{UserID: "alice", Input: "report", CronExpr: tt.cron, MissedRunPolicy: tt.policy})
+			if err != nil {
+				t.Fatalf("CreateSchedule = %v", err)
+			}
+			h.clock.Advance(tt.advance)
+
+			started, err := scheduler.RunDue(ctx)
+			if err != nil {
+				t.Fatalf("RunDue = %v", err)
+			}
+			if started != len(tt.wantRuns) {
+				t.Errorf("RunDue started %d tasks, want %d", started, len(tt.wantRuns))
+			}
+			if got := h.scheduledRuns(t, "alice"); !slices.Equal(got, tt.wantRuns) {
+				t.Errorf("started runs = %v, want %v", got, tt.wantRuns)
+			}
+
+			skipped := h.audit.last("SCHEDULE_RUNS_SKIPPED")
+			switch {
+			case tt.wantFirst.IsZero() && skipped != nil:
+				t.Errorf("SCHEDULE_RUNS_SKIPPED = %v, want none", skipped.Payload)
+			case !tt.wantFirst.IsZero() && skipped == nil:
+				t.Errorf("no SCHEDULE_RUNS_SKIPPED, want runs from %s skipped", tt.wantFirst)
+			case skipped != nil && (!skipped.Payload["first"].(time.Time).Equal(tt.wantFirst) || !skipped.Payload["until"].(time.Time).Equal(tt.wantUntil)):
+				t.Errorf("skipped [%v, %v), want [%s, %s)", skipped.Payload["first"], skipped.Payload["until"], tt.wantFirst, tt.wantUntil)
+			}
+
+			got, err := scheduler.GetSchedule(ctx, schedule.ID)
+			if err != nil {
+				t.Fatalf("GetSchedule = %v", err)
+			}
+			if !got.NextRunAt.Equal(tt.wantNext) || got.RunCount != len(tt.wantRuns) || got.Status != domain.ScheduleStatusActive {
+				t.Errorf("schedule = (next %s, runs %d, %s), want (next %s, runs %d, ACTIVE)", got.NextRunAt, got.RunCount, got.Status, tt.wantNext, len(tt.wantRuns))
+			}
+
+			// Nothing is due again until the next run
+			if started, err := scheduler.RunDue(ctx); err != nil || started != 0 {
+				t.Errorf("repeated RunDue = (%d, %v), want nothing started", started, err)
+			}
+		})
+	}
+}
+
+func TestSchedulerRecomputesNextRunOnResume(t *testing.T) {
+	h := newHarness(t, thinkStep("a"), newScriptedExecutor())
+	scheduler := h.newScheduler(services.SchedulerConfig{PollInterval: time.Minute, MisfireGrace: time.Minute, MaxCatchUpRuns: 10})
+	ctx := context.Background()
+	start := h.clock.Now()
+
+	hourly, err := scheduler.CreateSchedule(ctx, domain.ScheduleSpec{UserID: "alice", Input: "report", CronExpr: "0 * * * *", MissedRunPolicy: domain.MissedRunCatchUp})
+	if err != nil {
+		t.Fatalf("CreateSchedule = %v", err)
+	}
+	once, err := scheduler.CreateSchedule(ctx, domain.ScheduleSpec{UserID: "bob", Input: "remind me", RunAt: start.Add(2 * time.Hour)})
+	if err != nil {
+		t.Fatalf("CreateSchedule = %v", err)
+	}
+	for _, id := range []string{hourly.ID, hourly.ID, once.ID} { // Pausing twice is a no-op
+		if _, err := scheduler.PauseSchedule(ctx, id, "alice"); err != nil {
+			t.Fatalf("PauseSchedule(%s) = %v", id, err)
+		}
+	}
+	if n := h.audit.count("SCHEDULE_PAUSED"); n != 2 {
+		t.Errorf("SCHEDULE_PAUSED events = %d, want 2", n)
+	}
+
+	// Paused schedules are not due, however late they are
+	h.clock.Advance(3*time.Hour + 30*time.Minute)
+	if started, err := scheduler.RunDue(ctx); err != nil || started != 0 {
+		t.Fatalf("RunDue while paused = (%d, %v), want nothing started", started, err)
+	}
+
+	// A recurring schedule continues after now without catching up the pause
+	resumed, err := scheduler.ResumeSchedule(ctx, hourly.ID, "alice")
+	if err != nil {
+		t.Fatalf("ResumeSchedule = %v", err)
+	}
+	if want := start.Add(4 * time.Hour); !resumed.NextRunAt.Equal(want) {
+		t.Errorf("NextRunAt after resume = %s, want %s", resumed.NextRunAt, want)
+	}
+	// A one-shot keeps its time and is handled by its MissedRunPolicy (SKIP)
+	resumedOnce, err := scheduler.ResumeSchedule(ctx, once.ID, "bob")
+	if err != nil {
+		t.Fatalf("ResumeSchedule = %v", err)
+	}
+	if !resumedOnce.NextRunAt.Equal(once.RunAt) {
+		t.Errorf("one-shot NextRunAt after resume = %s, want %s", resumedOnce.NextRunAt, once.RunAt)
+	}
+
+	if started, err := scheduler.RunDue(ctx); err != nil || started != 0 {
+		t.Fatalf("RunDue after resume = (%d, %v), want nothing started", started, err)
+	}
+	finished, err := scheduler.GetSchedule(ctx, once.ID)
+	if err != nil {
+		t.Fatalf("GetSchedule = %v", err)
+	}
+	if finished.Status != domain.ScheduleStatusCompleted || !finished.NextRunAt.IsZero() {
+		t.Errorf("one-shot = (%s, next %s), want COMPLETED without a next run", finished.Status, finished.NextRunAt)
+	}
+	if _, err := scheduler.ResumeSchedule(ctx, once.ID, "bob"); !errors.Is(err, domain.ErrScheduleCompleted) {
+		t.Errorf("ResumeSchedule of a completed one-shot = %v, want ErrScheduleCompleted", err)
+	}
+
+	h.clock.Advance(30 * time.Minute)
+	if started, err := scheduler.RunDue(ctx); err != nil || started != 1 {
+		t.Fatalf("RunDue at the next run = (%d, %v), want 1 started", started, err)
+	}
+	if got := h.scheduledRuns(t, "alice"); !slices.Equal(got, []string{start.Add(4 * time.Hour).Format(time.RFC3339)}) {
+		t.Errorf("started runs = %v, want only the run after the resume", got)
+	}
+}
+
+func TestSchedulerLoopRunsOnClock(t *testing.T) {
+	const poll = 15 * time.Second
+	h := newHarness(t, thinkStep("a"), newScriptedExecutor())
+	scheduler := h.newScheduler(services.SchedulerConfig{PollInterval: poll, MisfireGrace: time.Minute, MaxCatchUpRuns: 10})
+	if _, err := scheduler.CreateSchedule(context.Background(), domain.ScheduleSpec{UserID: "alice", Input: "report", CronExpr: "0 * * * *"}); err != nil {
+		t.Fatalf("CreateSchedule = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Start(ctx)
+	defer func() {
+		cancel()
+		scheduler.Wait()
+	}()
+
+	// The first check finds nothing due and waits a poll interval
+	h.clock.awaitWait(t, poll)
+	if n := h.taskCount(t, "alice"); n != 0 {
+		t.Fatalf("tasks before the run = %d, want 0", n)
+	}
+
+	h.clock.Advance(time.Hour)
+	h.clock.awaitWait(t, poll)
+	if got := h.scheduledRuns(t, "alice"); len(got) != 1 {
+		t.Errorf("started runs = %v, want the run at 13:00", got)
+	}
+}
@@ -0,0 +1,457 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+)
+
+// spawnSubTasks starts the child tasks declared by a SUB_TASK step.
+// Purpose: Creates one task per SubTaskSpec through StartTask, so every child is queued,
+//          planned and audited like any other task. Children inherit the parent's user and
+//          deadline, what is left of its Budget and, unless overridden, its priority and
+//          target agent. Children spawned together each get the whole remainder; their
+//          spend counts against the parent's Budget, which is checked again before the
+//          parent continues. Children that were
+//          already spawned (recorded in ChildTaskIDs) are reused, so a step re-run after a
+//          restart never spawns duplicates.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The parent task
+//   - planID: Unique identifier of the plan owning the step
+//   - step: The IN_PROGRESS SUB_TASK step (ChildTaskIDs is updated in place and persisted)
+// Outputs:
+//   - *domain.StepResult: A failed result if the children cannot be spawned
+//                         (e.g., MaxSubTaskDepth reached), or nil once all children exist
+//   - error: Returns error if step state could not be persisted
+func (s *OrchestratorService) spawnSubTasks(ctx context.Context, task *domain.Task, planID string, step *domain.Step) (*domain.StepResult, error) {
+	depth := s.subTaskDepth(ctx, task)
+	if depth >= s.cfg.MaxSubTaskDepth {
+		return &domain.StepResult{
+			StepID:       step.ID,
+			Success:      false,
+			ErrorMessage: fmt.Sprintf("sub-task depth limit %d reached", s.cfg.MaxSubTaskDepth),
+		}, nil
+	}
+
+	budget := s.subTaskBudget(ctx, task)
+	for i := len(step.ChildTaskIDs); i < len(step.SubTasks); i++ {
+		spec := step.SubTasks[i]
+		opts := domain.TaskOptions{
+			Priority:     spec.Priority,
+			Deadline:     task.Deadline,
+			Budget:       budget,
+			TargetAgent:  spec.TargetAgent,
+			ParentTaskID: task.ID,
+			ParentStepID: step.ID,
+			Metadata:     map[string]string{"sub_task_index": strconv.Itoa(i)},
+		}
+		if opts.Priority == "" {
+			opts.Priority = task.Priority
+		}
+		if opts.TargetAgent == "" {
+			opts.TargetAgent = task.TargetAgent
+		}
+
+		// A child rejected by the queue exists in FAILED status and fails the step on collection
+		child, err := s.StartTask(ctx, spec.Input, task.UserID, opts)
+		if child == nil {
+			return &domain.StepResult{
+				StepID:       step.ID,
+				Success:      false,
+				ErrorMessage: fmt.Sprintf("sub-task %d could not be started: %v", i, err),
+			}, nil
+		}
+
+		step.ChildTaskIDs = append(step.ChildTaskIDs, child.ID)
+		if err := s.plans.UpdateStep(ctx, planID, step); err != nil {
+			return nil, fmt.Errorf("failed to save children of step %s: %w", step.ID, err)
+		}
+	}
+
+	s.recordEvent(ctx, task, "SUBTASKS_SPAWNED", systemActor, map[string]interface{}{
+		"step_id":        step.ID,
+		"child_task_ids": step.ChildTaskIDs,
+		"depth":          depth + 1,
+	})
+
+	return nil, nil
+}
+
+// subTaskBudget returns the part of a task's Budget its sub-tasks may still spend.
+// Without usage accounting, or if the spend cannot be loaded, children get the whole Budget.
+func (s *OrchestratorService) subTaskBudget(ctx context.Context, task *domain.Task) domain.BudgetLimit {
+	if task.Budget.IsZero() || s.usage == nil {
+		return task.Budget
+	}
+
+	spent, err := s.taskTreeUsage(ctx, task)
+	if err != nil {
+		s.logger.Warn("failed to load task spend for sub-task budgets", map[string]interface{}{
+			"error":   err.Error(),
+			"task_id": task.ID,
+		})
+		return task.Budget
+	}
+	return task.Budget.Remaining(spent)
+}
+
+// collectSubTasks returns the outcomes of SUB_TASK steps whose children have all finished.
+// Purpose: Called by executePlan once no other work is runnable; steps with children still
+//          running are left IN_PROGRESS.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - plan: The parent task's plan
+// Outputs:
+//   - []stepOutcome: Aggregated results of the settled steps, in plan order
+//   - error: Returns error if a child task could not be loaded
+func (s *OrchestratorService) collectSubTasks(ctx context.Context, plan *domain.Plan) ([]stepOutcome, error) {
+	outcomes := make([]stepOutcome, 0)
+	for _, step := range plan.WaitingSubTaskSteps() {
+		result, settled, err := s.aggregateSubTasks(ctx, step)
+		if err != nil {
+			return nil, err
+		}
+		if settled {
+			outcomes = append(outcomes, stepOutcome{step: step, result: result})
+		}
+	}
+
+	return outcomes, nil
+}
+
+// aggregateSubTasks combines the children of a SUB_TASK step into the step's result.
+// Purpose: The result succeeds only if every child finished DONE; its Output is the JSON
+//          array of domain.SubTaskResult. A failed result carries ErrorCode SUBTASK_FAILED
+//          and names the first child that did not finish DONE.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - step: The SUB_TASK step
+// Outputs:
+//   - *domain.StepResult: The aggregated result (nil while children are still running)
+//   - bool: True if every child has reached a terminal status
+//   - error: Returns error if a child task could not be loaded
+func (s *OrchestratorService) aggregateSubTasks(ctx context.Context, step *domain.Step) (*domain.StepResult, bool, error) {
+	if len(step.ChildTaskIDs) == 0 {
+		return &domain.StepResult{
+			StepID:       step.ID,
+			Success:      false,
+			ErrorMessage: "no sub-tasks were started",
+			ErrorCode:    domain.ErrorCodeSubTaskFailed,
+		}, true, nil
+	}
+
+	results := make([]domain.SubTaskResult, 0, len(step.ChildTaskIDs))
+	failure := ""
+	for _, childID := range step.ChildTaskIDs {
+		child, err := s.repo.GetTask(ctx, childID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to load sub-task %s: %w", childID, err)
+		}
+		if !child.Status.IsTerminal() {
+			return nil, false, nil
+		}
+
+		result := domain.SubTaskResult{
+			TaskID:      child.ID,
+			Input:       child.Input,
+			TargetAgent: child.TargetAgent,
+			Status:      child.Status,
+		}
+		if child.Status == domain.TaskStatusDone {
+			result.Output = s.taskOutput(ctx, child)
+		} else {
+			result.Error = child.Metadata["failure_reason"]
+			if failure == "" {
+				failure = fmt.Sprintf("sub-task %s finished %s", child.ID, child.Status)
+				if result.Error != "" {
+					failure = fmt.Sprintf("%s: %s", failure, result.Error)
+				}
+			}
+		}
+		results = append(results, result)
+	}
+
+	output, err := json.Marshal(results)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encode results of step %s: %w", step.ID, err)
+	}
+
+	result := &domain.StepResult{
+		StepID:       step.ID,
+		Success:      failure == "",
+		Output:       string(output),
+		ErrorMessage: failure,
+	}
+	if failure != "" {
+		result.ErrorCode = domain.ErrorCodeSubTaskFailed
+	}
+
+	return result, true, nil
+}
+
+// taskOutput returns the output of the last completed step of a task's current plan,
+// or an empty string if the task has no completed step.
+func (s *OrchestratorService) taskOutput(ctx context.Context, task *domain.Task) string {
+	if task.PlanID == "" {
+		return ""
+	}
+	plan, err := s.plans.GetPlan(ctx, task.PlanID)
+	if err != nil {
+		return ""
+	}
+
+	for i := len(plan.Steps) - 1; i >= 0; i-- {
+		if plan.Steps[i].Status != domain.StepStatusCompleted {
+			continue
+		}
+		if result, err := s.loadStepResult(ctx, plan.ID, plan.Steps[i].ID); err == nil {
+			return result.Output
+		}
+	}
+
+	return ""
+}
+
+// awaitSubTasks suspends a task until the children of its SUB_TASK steps finish.
+// Purpose: Moves the task to WAITING_SUBTASKS so it releases its worker while the children
+//          run. The status change and the settlement check happen under subTaskMu, the lock
+//          wakeParent takes, so a child finishing concurrently cannot be missed: if a step
+//          has already settled, the task is queued again right away.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task to suspend (mutated in place)
+//   - plan: The task's current plan
+// Outputs:
+//   - error: Returns error if task state could not be persisted or the task could not be queued
+func (s *OrchestratorService) awaitSubTasks(ctx context.Context, task *domain.Task, plan *domain.Plan) error {
+	s.subTaskMu.Lock()
+	if err := s.setTaskStatus(ctx, task, domain.TaskStatusWaitingSubTasks); err != nil {
+		s.subTaskMu.Unlock()
+		return err
+	}
+
+	waiting := make([]string, 0)
+	for _, step := range plan.WaitingSubTaskSteps() {
+		waiting = append(waiting, step.ID)
+	}
+	s.recordEvent(ctx, task, "SUBTASKS_AWAITED", systemActor, map[string]interface{}{
+		"task_id":  task.ID,
+		"step_ids": waiting,
+	})
+
+	settled, err := s.subTasksSettled(ctx, task)
+	s.subTaskMu.Unlock()
+	if err != nil {
+		return err
+	}
+	if settled {
+		return s.enqueueTask(ctx, task)
+	}
+
+	return nil
+}
+
+// wakeParent queues a parent task in WAITING_SUBTASKS once a finished child settles one
+// of its SUB_TASK steps.
+// Purpose: Called by finishTask for every task with a ParentTaskID. The worker that picks
+//          the parent up resumes it through resumeSubTasks. Failures are logged, not
+//          returned: they must not affect the child's own outcome.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - child: The child task that just finished
+// Outputs: None
+func (s *OrchestratorService) wakeParent(ctx context.Context, child *domain.Task) {
+	s.subTaskMu.Lock()
+	parent, err := s.repo.GetTask(ctx, child.ParentTaskID)
+	if err != nil || parent.Status != domain.TaskStatusWaitingSubTasks {
+		s.subTaskMu.Unlock()
+		return
+	}
+	settled, err := s.subTasksSettled(ctx, parent)
+	s.subTaskMu.Unlock()
+
+	if err != nil {
+		s.logger.Warn("failed to check sub-tasks of parent task", map[string]interface{}{
+			"error":          err.Error(),
+			"task_id":        child.ID,
+			"parent_task_id": parent.ID,
+		})
+		return
+	}
+	if !settled {
+		return
+	}
+	if _, queued := s.queue.Position(parent.ID); queued {
+		return
+	}
+
+	if err := s.enqueueTask(ctx, parent); err != nil {
+		s.logger.Warn("failed to queue parent task", map[string]interface{}{
+			"error":          err.Error(),
+			"task_id":        child.ID,
+			"parent_task_id": parent.ID,
+		})
+	}
+}
+
+// resumeSubTasks continues a task in WAITING_SUBTASKS after its children settled.
+// Purpose: Called by RunTask when a woken parent is dequeued. The task is claimed by moving
+//          it back to EXECUTING under subTaskMu, so a parent queued twice runs only once;
+//          executePlan then records the aggregated results and continues with dependent steps.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control (owned by the worker)
+//   - task: The suspended task (refreshed and mutated in place)
+// Outputs:
+//   - error: Returns error if task or plan state could not be loaded or persisted
+func (s *OrchestratorService) resumeSubTasks(ctx context.Context, task *domain.Task) error {
+	s.subTaskMu.Lock()
+	latest, err := s.repo.GetTask(ctx, task.ID)
+	if err != nil {
+		s.subTaskMu.Unlock()
+		return fmt.Errorf("failed to reload task: %w", err)
+	}
+	*task = *latest
+	if task.Status != domain.TaskStatusWaitingSubTasks || s.isRunning(task.ID) {
+		s.subTaskMu.Unlock()
+		return nil
+	}
+
+	settled, err := s.subTasksSettled(ctx, task)
+	if err != nil || !settled {
+		s.subTaskMu.Unlock()
+		return err
+	}
+
+	plan, err := s.plans.GetPlan(ctx, task.PlanID)
+	if err != nil {
+		s.subTaskMu.Unlock()
+		return fmt.Errorf("failed to load plan: %w", err)
+	}
+	if err := s.setTaskStatus(ctx, task, domain.TaskStatusExecuting); err != nil {
+		s.subTaskMu.Unlock()
+		return err
+	}
+	s.subTaskMu.Unlock()
+
+	return s.runExecution(ctx, task, func(execCtx context.Context) error {
+		return s.executePlan(execCtx, task, plan)
+	})
+}
+
+// subTasksSettled reports whether a suspended task can continue: true if any of its
+// SUB_TASK steps has all children finished, or if no step is waiting anymore.
+func (s *OrchestratorService) subTasksSettled(ctx context.Context, task *domain.Task) (bool, error) {
+	plan, err := s.plans.GetPlan(ctx, task.PlanID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load plan: %w", err)
+	}
+
+	waiting := plan.WaitingSubTaskSteps()
+	if len(waiting) == 0 {
+		return true, nil
+	}
+	for _, step := range waiting {
+		_, settled, err := s.aggregateSubTasks(ctx, step)
+		if err != nil {
+			return false, err
+		}
+		if settled {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// cancelSubTasks cancels the unfinished children of a task that did not finish DONE.
+// Purpose: Cascades cancellation down the task tree; CancelTask on a child cancels its own
+//          children in turn. Failures are logged, not returned: the parent is already final.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The finished parent task
+//   - reason: Why the parent finished, recorded on every canceled child
+// Outputs: None
+func (s *OrchestratorService) cancelSubTasks(ctx context.Context, task *domain.Task, reason string) {
+	children, err := s.repo.ListTasks(ctx, domain.TaskFilter{ParentTaskID: task.ID, ActiveOnly: true})
+	if err != nil {
+		s.logger.Warn("failed to list sub-tasks", map[string]interface{}{
+			"error":   err.Error(),
+			"task_id": task.ID,
+		})
+		return
+	}
+	if len(children) == 0 {
+		return
+	}
+
+	childReason := fmt.Sprintf("parent task %s finished %s", task.ID, task.Status)
+	if reason != "" {
+		childReason = fmt.Sprintf("%s: %s", childReason, reason)
+	}
+
+	canceled := make([]string, 0, len(children))
+	for _, child := range children {
+		err := s.CancelTask(ctx, child.ID, systemActor, childReason)
+		if err != nil && !errors.Is(err, domain.ErrTaskFinished) {
+			s.logger.Warn("failed to cancel sub-task", map[string]interface{}{
+				"error":         err.Error(),
+				"task_id":       task.ID,
+				"child_task_id": child.ID,
+			})
+			continue
+		}
+		if err == nil {
+			canceled = append(canceled, child.ID)
+		}
+	}
+
+	s.recordEvent(ctx, task, "SUBTASKS_CANCELED", systemActor, map[string]interface{}{
+		"task_id":        task.ID,
+		"child_task_ids": canceled,
+		"reason":         childReason,
+	})
+}
+
+// subTaskDepth returns how many ancestors a task has (0 for a top-level task).
+func (s *OrchestratorService) subTaskDepth(ctx context.Context, task *domain.Task) int {
+	depth := 0
+	for parentID := task.ParentTaskID; parentID != "" && depth <= s.cfg.MaxSubTaskDepth; depth++ {
+		parent, err := s.repo.GetTask(ctx, parentID)
+		if err != nil {
+			break
+		}
+		parentID = parent.ParentTaskID
+	}
+	return depth
+}
+
+// GetTaskChildren returns the child tasks spawned by a task's SUB_TASK steps.
+// Purpose: Lets clients walk a task tree one level at a time; every child is a full task
+//          with its own plan, status and audit trail.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the parent task
+// Outputs:
+//   - []*domain.Task: Direct children of the task in creation order
+//   - error: Returns error if the task is not found or children cannot be loaded
+func (s *OrchestratorService) GetTaskChildren(ctx context.Context, taskID string) ([]*domain.Task, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("taskID cannot be empty")
+	}
+
+	if _, err := s.repo.GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+
+	children, err := s.repo.ListTasks(ctx, domain.TaskFilter{ParentTaskID: taskID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load children: %w", err)
+	}
+
+	return children, nil
+}
@@ -0,0 +1,212 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// fanOut is a parent plan that spawns one child per input and then runs step "after".
+func fanOut(inputs ...string) []domain.Step {
+	specs := make([]domain.SubTaskSpec, 0, len(inputs))
+	for _, input := range inputs {
+		specs = append(specs, domain.SubTaskSpec{Input: input})
+	}
+	return []domain.Step{
+		{ID: "spawn", Type: domain.StepTypeSubTask, Status: domain.StepStatusPending, SubTasks: specs},
+		{ID: "after", Type: domain.StepTypeThink, Status: domain.StepStatusPending, DependsOn: []string{"spawn"}},
+	}
+}
+
+// thinkStep is a single THINK step plan.
+func thinkStep(id string) []domain.Step {
+	return []domain.Step{{ID: id, Type: domain.StepTypeThink, Status: domain.StepStatusPending}}
+}
+
+// subTaskResults decodes the output of a settled SUB_TASK step.
+func (h *harness) subTaskResults(t *testing.T, taskID string, stepID string) []domain.SubTaskResult {
+	t.Helper()
+	var results []domain.SubTaskResult
+	if err := json.Unmarshal([]byte(h.stepResult(t, taskID, stepID).Output), &results); err != nil {
+		t.Fatalf("decode results of step %s = %v", stepID, err)
+	}
+	return results
+}
+
+func TestOrchestratorRunsSubTasksAndWakesParent(t *testing.T) {
+	executor := newScriptedExecutor()
+	executor.failures["work-1"] = []error{fmt.Errorf("rate limited: %w", domain.ErrTransient)}
+	executor.outputs["work-1"] = "one"
+	executor.outputs["work-2"] = "two"
+	h := newHarness(t, fanOut("child 1", "child 2"), executor, withSubTaskPlans(map[string][]domain.Step{
+		"child 1": thinkStep("work-1"),
+		"child 2": thinkStep("work-2"),
+	}))
+
+	parent, err := h.orchestrator.StartTask(context.Background(), "fan out", "alice", domain.TaskOptions{Priority: domain.TaskPriorityHigh})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+
+	// Child 1 waits for its retry backoff, so the parent releases its worker
+	if got := h.clock.nextWait(t); got != time.Second {
+		t.Fatalf("backoff = %v, want 1s", got)
+	}
+	h.waitForStatus(t, parent.ID, domain.TaskStatusWaitingSubTasks)
+	if n := executor.callCount("after"); n != 0 {
+		t.Fatalf("step after ran %d times before the children finished", n)
+	}
+
+	// The last child to finish wakes the parent
+	h.clock.Advance(time.Second)
+	h.waitForStatus(t, parent.ID, domain.TaskStatusDone)
+	if n := executor.callCount("after"); n != 1 {
+		t.Errorf("step after ran %d times, want 1", n)
+	}
+
+	children := h.step(t, parent.ID, "spawn").ChildTaskIDs
+	if len(children) != 2 {
+		t.Fatalf("ChildTaskIDs = %v, want 2 children", children)
+	}
+	for i, childID := range children {
+		child := h.waitForStatus(t, childID, domain.TaskStatusDone)
+		if child.ParentTaskID != parent.ID || child.ParentStepID != "spawn" {
+			t.Errorf("child %s parent = (%s, %s), want (%s, spawn)", childID, child.ParentTaskID, child.ParentStepID, parent.ID)
+		}
+		if child.UserID != "alice" || child.Priority != domain.TaskPriorityHigh {
+			t.Errorf("child %s = (%s, %s), want the parent's user and priority", childID, child.UserID, child.Priority)
+		}
+		if index := child.Metadata["sub_task_index"]; index != fmt.Sprint(i) {
+			t.Errorf("child %s sub_task_index = %q, want %d", childID, index, i)
+		}
+	}
+
+	results := h.subTaskResults(t, parent.ID, "spawn")
+	if len(results) != 2 {
+		t.Fatalf("results = %+v, want 2", results)
+	}
+	for i, want := range []string{"one", "two"} {
+		if results[i].TaskID != children[i] || results[i].Status != domain.TaskStatusDone || results[i].Output != want {
+			t.Errorf("results[%d] = %+v, want child %s DONE with output %q", i, results[i], children[i], want)
+		}
+	}
+}
+
+func TestOrchestratorFailsParentWhenSubTaskFails(t *testing.T) {
+	executor := newScriptedExecutor()
+	executor.failures["work-2"] = []error{fmt.Errorf("bad request: %w", domain.ErrPermanent)}
+	h := newHarness(t, fanOut("child 1", "child 2"), executor, withSubTaskPlans(map[string][]domain.Step{
+		"child 1": thinkStep("work-1"),
+		"child 2": thinkStep("work-2"),
+	}))
+
+	parent, err := h.orchestrator.StartTask(context.Background(), "fan out", "alice", domain.TaskOptions{})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	failed := h.waitForStatus(t, parent.ID, domain.TaskStatusFailed)
+
+	children := h.step(t, parent.ID, "spawn").ChildTaskIDs
+	if len(children) != 2 {
+		t.Fatalf("ChildTaskIDs = %v, want 2 children", children)
+	}
+	h.waitForStatus(t, children[0], domain.TaskStatusDone)
+	h.waitForStatus(t, children[1], domain.TaskStatusFailed)
+
+	if reason := failed.Metadata["failure_reason"]; !strings.Contains(reason, fmt.Sprintf("sub-task %s finished FAILED", children[1])) {
+		t.Errorf("failure_reason = %q, want it to name the failed child", reason)
+	}
+	if step := h.step(t, parent.ID, "spawn"); step.Status != domain.StepStatusFailed {
+		t.Errorf("step spawn is %s, want FAILED", step.Status)
+	}
+	if result := h.stepResult(t, parent.ID, "spawn"); result.ErrorCode != domain.ErrorCodeSubTaskFailed {
+		t.Errorf("step spawn error_code = %q, want %s", result.ErrorCode, domain.ErrorCodeSubTaskFailed)
+	}
+	results := h.subTaskResults(t, parent.ID, "spawn")
+	if len(results) != 2 || results[0].Status != domain.TaskStatusDone || results[1].Status != domain.TaskStatusFailed || results[1].Error == "" {
+		t.Errorf("results = %+v, want child 1 DONE and child 2 FAILED with its reason", results)
+	}
+	if n := executor.callCount("after"); n != 0 {
+		t.Errorf("step after ran %d times after a child failed", n)
+	}
+}
+
+func TestOrchestratorCancelsSubTasksWithParent(t *testing.T) {
+	executor := newScriptedExecutor()
+	executor.block["deep"] = true
+	executor.block["work-2"] = true
+	h := newHarness(t, fanOut("child 1", "child 2"), executor, withSubTaskPlans(map[string][]domain.Step{
+		"child 1":    {{ID: "nested", Type: domain.StepTypeSubTask, Status: domain.StepStatusPending, SubTasks: []domain.SubTaskSpec{{Input: "grandchild"}}}},
+		"child 2":    thinkStep("work-2"),
+		"grandchild": thinkStep("deep"),
+	}))
+	ctx := context.Background()
+
+	parent, err := h.orchestrator.StartTask(ctx, "fan out", "alice", domain.TaskOptions{})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	h.waitForStart(t, "deep")
+	h.waitForStatus(t, parent.ID, domain.TaskStatusWaitingSubTasks)
+
+	children := h.step(t, parent.ID, "spawn").ChildTaskIDs
+	if len(children) != 2 {
+		t.Fatalf("ChildTaskIDs = %v, want 2 children", children)
+	}
+	h.waitForStatus(t, children[0], domain.TaskStatusWaitingSubTasks)
+	grandchildren := h.step(t, children[0], "nested").ChildTaskIDs
+	if len(grandchildren) != 1 {
+		t.Fatalf("grandchildren = %v, want 1", grandchildren)
+	}
+
+	if err := h.orchestrator.CancelTask(ctx, parent.ID, "alice", "no longer needed"); err != nil {
+		t.Fatalf("CancelTask = %v", err)
+	}
+	h.waitForStatus(t, parent.ID, domain.TaskStatusCanceled)
+	for _, id := range append(children, grandchildren...) {
+		task := h.waitForStatus(t, id, domain.TaskStatusCanceled)
+		if reason := task.Metadata["failure_reason"]; !strings.Contains(reason, "finished CANCELED") {
+			t.Errorf("task %s failure_reason = %q, want the parent's cancellation", id, reason)
+		}
+	}
+	// The parent and child 1 each cancel their unfinished children
+	if n := h.audit.count("SUBTASKS_CANCELED"); n != 2 {
+		t.Errorf("SUBTASKS_CANCELED events = %d, want 2", n)
+	}
+	if n := executor.callCount("after"); n != 0 {
+		t.Errorf("step after ran %d times after cancellation", n)
+	}
+}
+
+func TestOrchestratorLimitsSubTaskDepth(t *testing.T) {
+	executor := newScriptedExecutor()
+	h := newHarness(t, fanOut("child"), executor, withSubTaskPlans(map[string][]domain.Step{
+		"child": {{ID: "nested", Type: domain.StepTypeSubTask, Status: domain.StepStatusPending, SubTasks: []domain.SubTaskSpec{{Input: "grandchild"}}}},
+	}), func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		cfg.MaxSubTaskDepth = 1
+	})
+
+	parent, err := h.orchestrator.StartTask(context.Background(), "fan out", "alice", domain.TaskOptions{})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	h.waitForStatus(t, parent.ID, domain.TaskStatusFailed)
+
+	children := h.step(t, parent.ID, "spawn").ChildTaskIDs
+	if len(children) != 1 {
+		t.Fatalf("ChildTaskIDs = %v, want 1 child", children)
+	}
+	child := h.waitForStatus(t, children[0], domain.TaskStatusFailed)
+	if reason := child.Metadata["failure_reason"]; !strings.Contains(reason, "sub-task depth limit 1 reached") {
+		t.Errorf("child failure_reason = %q, want the depth limit", reason)
+	}
+	if grandchildren := h.step(t, children[0], "nested").ChildTaskIDs; len(grandchildren) != 0 {
+		t.Errorf("grandchildren = %v, want none beyond the depth limit", grandchildren)
+	}
+}
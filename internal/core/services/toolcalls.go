@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/execctx"
+)
+
+// toolInvoker is the ports.ToolInvoker attached to the context of a step attempt.
+// It persists every call right away, so the calls of an attempt that hangs or crashes are kept.
+type toolInvoker struct {
+	s       *OrchestratorService
+	ctx     context.Context // Detached from cancellation: calls are recorded even if the task is interrupted
+	profile domain.AgentProfile
+	taskID  string
+	stepID  string
+	attempt int
+}
+
+// InvokeTool runs a tool the agent may use, timing it on the Clock, and records the call.
+func (i *toolInvoker) InvokeTool(ctx context.Context, tool domain.Tool, input string) (string, error) {
+	call := domain.ToolCall{
+		ToolName:     tool.Name(),
+		InputPayload: input,
+	}
+
+	if !i.profile.AllowsTool(call.ToolName) {
+		err := fmt.Errorf("%w: agent %s may not call %s: %w", domain.ErrToolNotAllowed, i.profile.Name, call.ToolName, domain.ErrPermanent)
+		call.Status = domain.ToolCallStatusRejected
+		call.ErrorMessage = err.Error()
+		i.record(call)
+		return "", err
+	}
+
+	start := i.s.clock.Now()
+	output, err := tool.Execute(input)
+	call.DurationMs = i.s.clock.Now().Sub(start).Milliseconds()
+	call.OutputPayload = output
+	call.Status = domain.ToolCallStatusSucceeded
+	if err != nil {
+		call.Status = domain.ToolCallStatusFailed
+		call.ErrorMessage = err.Error()
+	}
+	i.record(call)
+
+	return output, err
+}
+
+// record completes the call with its task, step, agent and attempt and saves it.
+// Save failures are logged, never returned, so recording cannot fail a step.
+// Without a tool call repository nothing is recorded.
+func (i *toolInvoker) record(call domain.ToolCall) {
+	if i.s.toolCalls == nil {
+		return
+	}
+
+	call.ID = i.s.idGen.Generate()
+	call.TaskID = i.taskID
+	call.StepID = i.stepID
+	call.Agent = i.profile.Name
+	call.Attempt = i.attempt
+	call.CreatedAt = i.s.clock.Now()
+
+	if err := i.s.toolCalls.SaveToolCall(i.ctx, &call); err != nil {
+		i.s.logger.Error("failed to record tool call", err, map[string]interface{}{
+			"task_id":   i.taskID,
+			"step_id":   i.stepID,
+			"tool_name": call.ToolName,
+		})
+	}
+}
+
+// invokingTools returns a copy of ctx whose tools run through a ports.ToolInvoker for one
+// step attempt of the given agent.
+func (s *OrchestratorService) invokingTools(ctx context.Context, task *domain.Task, step *domain.Step, profile domain.AgentProfile, attempt int) context.Context {
+	return execctx.WithToolInvoker(ctx, s.newToolInvoker(ctx, task, step, profile, attempt))
+}
+
+// newToolInvoker creates the invoker of the tools of one step attempt.
+func (s *OrchestratorService) newToolInvoker(ctx context.Context, task *domain.Task, step *domain.Step, profile domain.AgentProfile, attempt int) *toolInvoker {
+	return &toolInvoker{
+		s:       s,
+		ctx:     context.WithoutCancel(ctx),
+		profile: profile,
+		taskID:  task.ID,
+		stepID:  step.ID,
+		attempt: attempt,
+	}
+}
+
+// recordRejectedToolCall records the tool of a step that its agent may not use and that was therefore not run.
+func (s *OrchestratorService) recordRejectedToolCall(ctx context.Context, task *domain.Task, step *domain.Step, profile domain.AgentProfile, message string) {
+	s.newToolInvoker(ctx, task, step, profile, 1).record(domain.ToolCall{
+		ToolName:     step.ToolName,
+		InputPayload: step.ToolInput,
+		Status:       domain.ToolCallStatusRejected,
+		ErrorMessage: message,
+	})
+}
+
+// GetTaskToolCalls returns the tool calls made by a task's steps.
+// Purpose: Shows the exact tool inputs and outputs of a task, the first thing to check
+//          when an agent misbehaves.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task
+// Outputs:
+//   - []*domain.ToolCall: Calls of the task, oldest first (empty if tool calls are not recorded)
+//   - error: Returns error if the task is not found or calls cannot be loaded
+func (s *OrchestratorService) GetTaskToolCalls(ctx context.Context, taskID string) ([]*domain.ToolCall, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("taskID cannot be empty")
+	}
+	if _, err := s.repo.GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return s.ListToolCalls(ctx, domain.ToolCallFilter{TaskID: taskID})
+}
+
+// ListToolCalls returns the tool calls matching the filter across tasks.
+// Purpose: Supports analysis such as failing calls of a tool since a given time.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - filter: Optional TaskID, ToolName, Status, Since and Limit (most recent calls)
+// Outputs:
+//   - []*domain.ToolCall: Matching calls, oldest first (empty if tool calls are not recorded)
+//   - error: Returns error wrapping domain.ErrInvalidToolCallStatus for unknown statuses,
+//            or if calls cannot be loaded
+func (s *OrchestratorService) ListToolCalls(ctx context.Context, filter domain.ToolCallFilter) ([]*domain.ToolCall, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if s.toolCalls == nil {
+		return []*domain.ToolCall{}, nil
+	}
+
+	calls, err := s.toolCalls.ListToolCalls(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tool calls: %w", err)
+	}
+
+	return calls, nil
+}
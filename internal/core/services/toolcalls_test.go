@@ -0,0 +1,367 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/adapters/memory"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/execctx"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// scriptedTool is a domain.Tool that takes a fixed time on the fake clock and then returns
+// its scripted results in order. With release set, every call waits for it first.
+type scriptedTool struct {
+	name    string
+	clock   *fakeClock
+	took    time.Duration
+	errs    []error // Errors of the next calls, in order; nil entries succeed
+	release chan struct{}
+	started chan struct{}
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (t *scriptedTool) Name() string        { return t.name }
+func (t *scriptedTool) Description() string { return "scripted " + t.name }
+
+func (t *scriptedTool) Execute(input string) (string, error) {
+	t.mu.Lock()
+	t.calls++
+	var err error
+	if len(t.errs) > 0 {
+		err, t.errs = t.errs[0], t.errs[1:]
+	}
+	t.mu.Unlock()
+
+	if t.release != nil {
+		t.started <- struct{}{}
+		<-t.release
+	}
+	t.clock.Advance(t.took)
+	if err != nil {
+		return "", err
+	}
+	return t.name + " result for " + input, nil
+}
+
+func (t *scriptedTool) callCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.calls
+}
+
+// toolExecutor runs the tools scripted for each step through the ports.ToolInvoker in ctx,
+// as real executors must, and returns the first tool error.
+type toolExecutor struct {
+	tools map[string]*scriptedTool
+	steps map[string][]string  // Tools each step calls, in order
+	ctxs  chan context.Context // Receives the context of every attempt, if set
+
+	mu   sync.Mutex
+	errs []error
+}
+
+func (e *toolExecutor) ExecuteStep(ctx context.Context, task *domain.Task, step *domain.Step) (*domain.StepResult, error) {
+	invoker, ok := execctx.ToolInvokerFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("no tool invoker in context: %w", domain.ErrPermanent)
+	}
+	if e.ctxs != nil {
+		e.ctxs <- ctx
+	}
+
+	outputs := make([]string, 0, len(e.steps[step.ID]))
+	for _, name := range e.steps[step.ID] {
+		output, err := invoker.InvokeTool(ctx, e.tools[name], step.ToolInput)
+		if err != nil {
+			e.mu.Lock()
+			e.errs = append(e.errs, err)
+			e.mu.Unlock()
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return &domain.StepResult{StepID: step.ID, Success: true, Output: strings.Join(outputs, "\n")}, nil
+}
+
+func (e *toolExecutor) lastError() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.errs) == 0 {
+		return nil
+	}
+	return e.errs[len(e.errs)-1]
+}
+
+// withToolExecutor runs steps with the executor, recording tool calls in memory.
+func withToolExecutor(executor *toolExecutor) harnessOption {
+	return func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		deps.Executor = executor
+		deps.ToolCalls = memory.NewToolCallRepository()
+	}
+}
+
+// toolCalls returns the recorded calls of a task.
+func (h *harness) toolCalls(t *testing.T, taskID string) []*domain.ToolCall {
+	t.Helper()
+	calls, err := h.orchestrator.GetTaskToolCalls(context.Background(), taskID)
+	if err != nil {
+		t.Fatalf("GetTaskToolCalls = %v", err)
+	}
+	return calls
+}
+
+func TestOrchestratorRecordsToolCalls(t *testing.T) {
+	lookup := []domain.Step{{ID: "lookup", Type: domain.StepTypeToolCall, Status: domain.StepStatusPending, ToolName: "search", ToolInput: "query"}}
+
+	type wantCall struct {
+		tool       string
+		status     domain.ToolCallStatus
+		attempt    int
+		durationMs int64
+		errorText  string
+	}
+	tests := []struct {
+		name       string
+		input      string
+		calls      []string // Tools step lookup calls
+		searchErrs []error
+		backoff    time.Duration // Retry backoff to wait out before the second attempt
+		wantStatus domain.TaskStatus
+		wantCalls  []wantCall
+		wantErr    error // Error the executor got from the invoker
+	}{
+		{
+			name:       "success",
+			input:      "look it up",
+			calls:      []string{"search"},
+			wantStatus: domain.TaskStatusDone,
+			wantCalls:  []wantCall{{tool: "search", status: domain.ToolCallStatusSucceeded, attempt: 1, durationMs: 1500}},
+		},
+		{
+			name:       "error",
+			input:      "look it up",
+			calls:      []string{"search"},
+			searchErrs: []error{fmt.Errorf("index missing: %w", domain.ErrPermanent)},
+			wantStatus: domain.TaskStatusFailed,
+			wantCalls:  []wantCall{{tool: "search", status: domain.ToolCallStatusFailed, attempt: 1, durationMs: 1500, errorText: "index missing"}},
+		},
+		{
+			name:       "error of each attempt",
+			input:      "look it up",
+			calls:      []string{"search"},
+			searchErrs: []error{fmt.Errorf("rate limited: %w", domain.ErrTransient), nil},
+			backoff:    time.Second,
+			wantStatus: domain.TaskStatusDone,
+			wantCalls: []wantCall{
+				{tool: "search", status: domain.ToolCallStatusFailed, attempt: 1, durationMs: 1500, errorText: "rate limited"},
+				{tool: "search", status: domain.ToolCallStatusSucceeded, attempt: 2, durationMs: 1500},
+			},
+		},
+		{
+			name:       "disallowed tool through the invoker",
+			input:      "research the topic",
+			calls:      []string{"search", "shell"},
+			wantStatus: domain.TaskStatusFailed,
+			wantCalls: []wantCall{
+				{tool: "search", status: domain.ToolCallStatusSucceeded, attempt: 1, durationMs: 1500},
+				{tool: "shell", status: domain.ToolCallStatusRejected, attempt: 1, errorText: "agent RESEARCH may not call shell"},
+			},
+			wantErr: domain.ErrToolNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &toolExecutor{
+				tools: map[string]*scriptedTool{
+					"search": {name: "search", took: 1500 * time.Millisecond, errs: tt.searchErrs},
+					"shell":  {name: "shell", took: time.Second},
+				},
+				steps: map[string][]string{"lookup": tt.calls},
+			}
+			h := newHarness(t, lookup, newScriptedExecutor(), withToolExecutor(executor), withAgents(t, fixedPlanner{steps: lookup}, executor))
+			for _, tool := range executor.tools {
+				tool.clock = h.clock
+			}
+
+			task, err := h.orchestrator.StartTask(context.Background(), tt.input, "alice", domain.TaskOptions{})
+			if err != nil {
+				t.Fatalf("StartTask = %v", err)
+			}
+			if tt.backoff > 0 {
+				h.clock.awaitWait(t, tt.backoff)
+				h.clock.Advance(tt.backoff)
+			}
+			done := h.waitForStatus(t, task.ID, tt.wantStatus)
+
+			calls := h.toolCalls(t, task.ID)
+			if len(calls) != len(tt.wantCalls) {
+				t.Fatalf("tool calls = %+v, want %d", calls, len(tt.wantCalls))
+			}
+			for i, want := range tt.wantCalls {
+				call := calls[i]
+				if call.ToolName != want.tool || call.Status != want.status || call.Attempt != want.attempt || call.DurationMs != want.durationMs {
+					t.Errorf("call %d = (%s, %s, attempt %d, %dms), want (%s, %s, attempt %d, %dms)",
+						i, call.ToolName, call.Status, call.Attempt, call.DurationMs, want.tool, want.status, want.attempt, want.durationMs)
+				}
+				if !strings.Contains(call.ErrorMessage, want.errorText) || (want.errorText == "") != (call.ErrorMessage == "") {
+					t.Errorf("call %d error_message = %q, want %q", i, call.ErrorMessage, want.errorText)
+				}
+				if call.TaskID != task.ID || call.StepID != "lookup" || call.Agent != done.TargetAgent || call.InputPayload != "query" {
+					t.Errorf("call %d = %+v, want task %s, step lookup, agent %s and input query", i, call, task.ID, done.TargetAgent)
+				}
+				if want.status == domain.ToolCallStatusSucceeded && call.OutputPayload != want.tool+" result for query" {
+					t.Errorf("call %d output_payload = %q, want the tool output", i, call.OutputPayload)
+				}
+			}
+
+			if err := executor.lastError(); tt.wantErr != nil && (!errors.Is(err, tt.wantErr) || !errors.Is(err, domain.ErrPermanent)) {
+				t.Errorf("invoker error = %v, want a permanent %v so the step is not retried", err, tt.wantErr)
+			}
+			if n := executor.tools["shell"].callCount(); n != 0 {
+				t.Errorf("shell ran %d times, want never", n)
+			}
+		})
+	}
+}
+
+func TestOrchestratorRecordsToolCallInterruptedByCancel(t *testing.T) {
+	lookup := []domain.Step{{ID: "lookup", Type: domain.StepTypeToolCall, Status: domain.StepStatusPending, ToolName: "search", ToolInput: "query"}}
+	search := &scriptedTool{name: "search", release: make(chan struct{}), started: make(chan struct{}, 1)}
+	executor := &toolExecutor{
+		tools: map[string]*scriptedTool{"search": search},
+		steps: map[string][]string{"lookup": {"search"}},
+		ctxs:  make(chan context.Context, 1),
+	}
+	h := newHarness(t, lookup, newScriptedExecutor(), withToolExecutor(executor))
+	search.clock = h.clock
+	ctx := context.Background()
+
+	task, err := h.orchestrator.StartTask(ctx, "look it up", "alice", domain.TaskOptions{})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	var attemptCtx context.Context
+	select {
+	case attemptCtx = <-executor.ctxs:
+	case <-time.After(testTimeout):
+		t.Fatal("step lookup was never started")
+	}
+	select {
+	case <-search.started:
+	case <-time.After(testTimeout):
+		t.Fatal("tool search was never started")
+	}
+
+	// CancelTask waits for the step, which waits for the tool to return
+	canceled := make(chan error, 1)
+	go func() {
+		canceled <- h.orchestrator.CancelTask(ctx, task.ID, "alice", "changed my mind")
+	}()
+	select {
+	case <-attemptCtx.Done():
+	case <-time.After(testTimeout):
+		t.Fatal("CancelTask did not interrupt the step")
+	}
+	h.clock.Advance(3 * time.Second)
+	close(search.release)
+	select {
+	case err := <-canceled:
+		if err != nil {
+			t.Fatalf("CancelTask = %v", err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("CancelTask did not return")
+	}
+	h.waitForStatus(t, task.ID, domain.TaskStatusCanceled)
+
+	// The call finished after the task context was canceled and is still recorded
+	calls := h.toolCalls(t, task.ID)
+	if len(calls) != 1 {
+		t.Fatalf("tool calls = %+v, want the interrupted call", calls)
+	}
+	if calls[0].Status != domain.ToolCallStatusSucceeded || calls[0].DurationMs != 3000 || calls[0].OutputPayload != "search result for query" {
+		t.Errorf("call = %+v, want SUCCEEDED after 3000ms with the tool output", calls[0])
+	}
+}
+
+func TestOrchestratorListToolCallsFilters(t *testing.T) {
+	h := newHarness(t, thinkStep("a"), newScriptedExecutor(), func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		deps.ToolCalls = memory.NewToolCallRepository()
+	})
+	ctx := context.Background()
+	start := h.clock.Now()
+
+	tasks := make([]string, 0, 2)
+	for _, input := range []string{"first", "second"} {
+		task, err := h.orchestrator.StartTask(ctx, input, "alice", domain.TaskOptions{})
+		if err != nil {
+			t.Fatalf("StartTask = %v", err)
+		}
+		h.waitForStatus(t, task.ID, domain.TaskStatusDone)
+		tasks = append(tasks, task.ID)
+	}
+	ledger := []*domain.ToolCall{
+		{ID: "c1", TaskID: tasks[0], ToolName: "search", Status: domain.ToolCallStatusSucceeded, CreatedAt: start},
+		{ID: "c2", TaskID: tasks[0], ToolName: "shell", Status: domain.ToolCallStatusRejected, CreatedAt: start.Add(time.Minute)},
+		{ID: "c3", TaskID: tasks[1], ToolName: "search", Status: domain.ToolCallStatusFailed, CreatedAt: start.Add(2 * time.Minute)},
+		{ID: "c4", TaskID: tasks[1], ToolName: "search", Status: domain.ToolCallStatusSucceeded, CreatedAt: start.Add(3 * time.Minute)},
+	}
+	for _, call := range ledger {
+		if err := h.deps.ToolCalls.SaveToolCall(ctx, call); err != nil {
+			t.Fatalf("SaveToolCall = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		filter  domain.ToolCallFilter
+		want    string
+		wantErr error
+	}{
+		{name: "everything", filter: domain.ToolCallFilter{}, want: "c1,c2,c3,c4"},
+		{name: "by task", filter: domain.ToolCallFilter{TaskID: tasks[1]}, want: "c3,c4"},
+		{name: "by tool", filter: domain.ToolCallFilter{ToolName: "search"}, want: "c1,c3,c4"},
+		{name: "by status", filter: domain.ToolCallFilter{Status: domain.ToolCallStatusRejected}, want: "c2"},
+		{name: "since is inclusive", filter: domain.ToolCallFilter{Since: start.Add(2 * time.Minute)}, want: "c3,c4"},
+		{name: "limit keeps the most recent", filter: domain.ToolCallFilter{ToolName: "search", Limit: 2}, want: "c3,c4"},
+		{name: "combined", filter: domain.ToolCallFilter{TaskID: tasks[1], Status: domain.ToolCallStatusFailed}, want: "c3"},
+		{name: "no match", filter: domain.ToolCallFilter{ToolName: "browser"}, want: ""},
+		{name: "unknown status", filter: domain.ToolCallFilter{Status: "LOST"}, wantErr: domain.ErrInvalidToolCallStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls, err := h.orchestrator.ListToolCalls(ctx, tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListToolCalls = %v, want %v", err, tt.wantErr)
+			}
+			ids := make([]string, 0, len(calls))
+			for _, call := range calls {
+				ids = append(ids, call.ID)
+			}
+			if got := strings.Join(ids, ","); got != tt.want {
+				t.Errorf("ListToolCalls = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := h.orchestrator.ListToolCalls(ctx, domain.ToolCallFilter{Limit: -1}); err == nil {
+		t.Error("ListToolCalls with a negative limit succeeded")
+	}
+	if calls := h.toolCalls(t, tasks[0]); len(calls) != 2 {
+		t.Errorf("GetTaskToolCalls = %+v, want the 2 calls of the first task", calls)
+	}
+	if _, err := h.orchestrator.GetTaskToolCalls(ctx, "missing"); err == nil {
+		t.Error("GetTaskToolCalls of an unknown task succeeded")
+	}
+}
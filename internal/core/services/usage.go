@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/execctx"
+)
+
+// usageMeter is the ports.UsageMeter attached to the context of LLM-backed component calls.
+// It is safe for concurrent use, e.g., by an executor calling the LLM from several goroutines.
+type usageMeter struct {
+	mu    sync.Mutex
+	calls []domain.TokenUsage
+}
+
+// RecordUsage adds the usage of one LLM call.
+func (m *usageMeter) RecordUsage(usage domain.TokenUsage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, usage)
+}
+
+// drain returns the collected usage and resets the meter.
+func (m *usageMeter) drain() []domain.TokenUsage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	calls := m.calls
+	m.calls = nil
+	return calls
+}
+
+// metered returns a copy of ctx with a fresh usage meter attached.
+func metered(ctx context.Context) (context.Context, *usageMeter) {
+	meter := &usageMeter{}
+	return execctx.WithUsageMeter(ctx, meter), meter
+}
+
+// chargeUsage accounts the LLM calls a meter collected for a task.
+// Purpose: Prices every call with the configured price table and appends it to the usage
+//          ledger, attributed to the task, step and phase it served. Usage is recorded even
+//          if the task was interrupted meanwhile: the tokens were spent either way.
+//          Ledger failures are logged, never returned, so accounting cannot fail a task.
+// Inputs:
+//   - ctx: Context of the task execution
+//   - task: The task the calls were made for
+//   - stepID: The step that made the calls (empty for calls outside step execution)
+//   - phase: One of the domain.UsagePhase constants
+//   - meter: The meter attached to the calls' context (drained)
+// Outputs: None
+func (s *OrchestratorService) chargeUsage(ctx context.Context, task *domain.Task, stepID string, phase string, meter *usageMeter) {
+	calls := meter.drain()
+	if s.usage == nil {
+		return
+	}
+
+	ledgerCtx := context.WithoutCancel(ctx)
+	for _, call := range calls {
+		cost, priced := s.cfg.Prices.Cost(call)
+		if !priced {
+			s.logger.Warn("LLM model has no price, usage recorded without cost", map[string]interface{}{
+				"model":   call.Model,
+				"task_id": task.ID,
+			})
+		}
+
+		record := &domain.UsageRecord{
+			ID:               s.idGen.Generate(),
+			TaskID:           task.ID,
+			StepID:           stepID,
+			UserID:           task.UserID,
+			Agent:            task.TargetAgent,
+			Phase:            phase,
+			Model:            call.Model,
+			PromptTokens:     call.PromptTokens,
+			CompletionTokens: call.CompletionTokens,
+			CostUSD:          cost,
+			Unpriced:         !priced,
+			CreatedAt:        s.clock.Now(),
+		}
+		if err := s.usage.SaveUsage(ledgerCtx, record); err != nil {
+			s.logger.Error("failed to record LLM usage", err, map[string]interface{}{
+				"task_id": task.ID,
+				"model":   call.Model,
+			})
+		}
+	}
+}
+
+// applyUsage sets Task.UsageTokens and Task.CostEstimate to the totals of the usage ledger.
+// The ledger is the source of truth; failures to read it leave the task's totals unchanged.
+func (s *OrchestratorService) applyUsage(ctx context.Context, task *domain.Task) {
+	if s.usage == nil {
+		return
+	}
+
+	records, err := s.usage.ListUsage(ctx, domain.UsageFilter{TaskID: task.ID})
+	if err != nil {
+		s.logger.Warn("failed to load task usage", map[string]interface{}{
+			"error":   err.Error(),
+			"task_id": task.ID,
+		})
+		return
+	}
+
+	totals := domain.SummarizeUsage(records, domain.UsageFilter{TaskID: task.ID})
+	task.UsageTokens = int(totals.TotalTokens)
+	task.CostEstimate = totals.CostUSD
+}
+
+// GetUsage aggregates the LLM usage of all tasks matching the filter.
+// Purpose: Answers what the agents cost per user and time range, broken down by model and phase.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - filter: Optional UserID, TaskID and [From, To) time range of the calls
+// Outputs:
+//   - *domain.UsageSummary: Totals with breakdowns (empty if no usage repository is configured)
+//   - error: Returns error wrapping domain.ErrInvalidTimeRange if To is not after From,
+//            or if usage cannot be loaded
+func (s *OrchestratorService) GetUsage(ctx context.Context, filter domain.UsageFilter) (*domain.UsageSummary, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if s.usage == nil {
+		return domain.SummarizeUsage(nil, filter), nil
+	}
+
+	records, err := s.usage.ListUsage(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usage: %w", err)
+	}
+
+	return domain.SummarizeUsage(records, filter), nil
+}
+
+// GetTaskUsage returns the LLM usage of a task.
+// Purpose: Shows what a task cost, broken down by step, phase and model.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - taskID: Unique identifier of the task
+// Outputs:
+//   - *domain.UsageSummary: Usage of the task (ByStep lists calls made by steps)
+//   - error: Returns error if the task is not found or usage cannot be loaded
+func (s *OrchestratorService) GetTaskUsage(ctx context.Context, taskID string) (*domain.UsageSummary, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("taskID cannot be empty")
+	}
+	if _, err := s.repo.GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return s.GetUsage(ctx, domain.UsageFilter{TaskID: taskID})
+}
@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/JAROBOTAI/jaro/internal/adapters/memory"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// withUsage records LLM usage in an in-memory ledger priced with the given table.
+func withUsage(prices domain.PriceTable) harnessOption {
+	return func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		deps.Usage = memory.NewUsageRepository()
+		cfg.Prices = prices
+	}
+}
+
+func TestOrchestratorPricesUsageWithConfiguredTable(t *testing.T) {
+	steps := []domain.Step{
+		{ID: "a", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+		{ID: "b", Type: domain.StepTypeThink, Status: domain.StepStatusPending, DependsOn: []string{"a"}},
+	}
+
+	tests := []struct {
+		name         string
+		prices       domain.PriceTable
+		wantCost     float64
+		wantUnpriced int
+	}{
+		{
+			name:         "injected prices",
+			prices:       domain.PriceTable{"model-a": {PromptPerMillion: 1, CompletionPerMillion: 2}},
+			wantCost:     0.002, // (1000*1 + 500*2) / 1e6; model-b is unpriced
+			wantUnpriced: 1,
+		},
+		{
+			name:         "no price table",
+			prices:       nil,
+			wantCost:     0,
+			wantUnpriced: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := newScriptedExecutor()
+			executor.usage["a"] = domain.TokenUsage{Model: "model-a", PromptTokens: 1000, CompletionTokens: 500}
+			executor.usage["b"] = domain.TokenUsage{Model: "model-b", PromptTokens: 100, CompletionTokens: 100}
+			h := newHarness(t, steps, executor, withUsage(tt.prices))
+
+			task, err := h.orchestrator.StartTask(context.Background(), "count my tokens", "alice", domain.TaskOptions{})
+			if err != nil {
+				t.Fatalf("StartTask = %v", err)
+			}
+			done := h.waitForStatus(t, task.ID, domain.TaskStatusDone)
+
+			usage, err := h.orchestrator.GetTaskUsage(context.Background(), task.ID)
+			if err != nil {
+				t.Fatalf("GetTaskUsage = %v", err)
+			}
+			if usage.Calls != 2 || usage.TotalTokens != 1700 || usage.UnpricedCalls != tt.wantUnpriced {
+				t.Errorf("usage = %+v, want 2 calls, 1700 tokens and %d unpriced", usage.UsageTotals, tt.wantUnpriced)
+			}
+			if math.Abs(usage.CostUSD-tt.wantCost) > 1e-12 {
+				t.Errorf("CostUSD = %v, want %v", usage.CostUSD, tt.wantCost)
+			}
+			if usage.ByStep["a"] == nil || usage.ByStep["a"].TotalTokens != 1500 {
+				t.Errorf("ByStep[a] = %+v, want 1500 tokens", usage.ByStep["a"])
+			}
+			if done.UsageTokens != 1700 || math.Abs(done.CostEstimate-tt.wantCost) > 1e-12 {
+				t.Errorf("task totals = (%d tokens, $%v), want (1700, $%v)", done.UsageTokens, done.CostEstimate, tt.wantCost)
+			}
+		})
+	}
+}
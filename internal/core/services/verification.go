@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/execctx"
+)
+
+// collectResults loads the results of all completed steps of a plan in plan order.
+// Purpose: Gathers the evidence handed to the Verifier.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - plan: The plan whose results are collected
+// Outputs:
+//   - []*domain.StepResult: Results of COMPLETED steps
+//   - error: Returns error if a stored result could not be loaded
+func (s *OrchestratorService) collectResults(ctx context.Context, plan *domain.Plan) ([]*domain.StepResult, error) {
+	results := make([]*domain.StepResult, 0, len(plan.Steps))
+	for i := range plan.Steps {
+		if plan.Steps[i].Status != domain.StepStatusCompleted {
+			continue
+		}
+		result, err := s.loadStepResult(ctx, plan.ID, plan.Steps[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load result of step %s: %w", plan.Steps[i].ID, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// verifyStep executes a VERIFY step by running the Verifier instead of the Executor.
+// Purpose: Runs on a worker goroutine, so it only reads the snapshots it is given.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: Snapshot of the parent task
+//   - plan: Snapshot of the plan at the time the step started
+//   - results: Results of the steps completed before the VERIFY step
+//   - step: The VERIFY step
+// Outputs:
+//   - *domain.StepResult: Successful if verification passed; failed otherwise
+//   - *domain.Verification: The verdict, or nil if the verifier itself failed
+//   - error: Returns the interruption cause if CancelTask or the deadline interrupted the execution
+func (s *OrchestratorService) verifyStep(ctx context.Context, task *domain.Task, plan *domain.Plan, results []*domain.StepResult, step *domain.Step) (*domain.StepResult, *domain.Verification, error) {
+	start := s.clock.Now()
+	verification, err := s.verifier.Verify(ctx, task, plan, results)
+	if isInterrupted(ctx) {
+		return nil, nil, context.Cause(ctx)
+	}
+
+	result := &domain.StepResult{
+		StepID:     step.ID,
+		DurationMs: s.clock.Now().Sub(start).Milliseconds(),
+	}
+	switch {
+	case err != nil:
+		result.ErrorMessage = fmt.Sprintf("verification could not be performed: %v", err)
+		return result, nil, nil
+	case verification == nil:
+		result.ErrorMessage = "verifier returned no verdict"
+		return result, nil, nil
+	}
+
+	result.Success = verification.Passed
+	result.Output = verification.Reason
+	if !verification.Passed {
+		result.ErrorMessage = verificationFailure(verification)
+	}
+
+	return result, verification, nil
+}
+
+// verifyPlan runs the verification phase after every step of a plan has finished.
+// Purpose: Checks the accumulated results against Plan.Goal before reporting DONE and
+//          replans if the goal was not met.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task being executed (mutated in place)
+//   - plan: The finished plan
+// Outputs:
+//   - error: Returns error if task or plan state could not be persisted,
+//            or the interruption cause if CancelTask or the deadline stopped it
+func (s *OrchestratorService) verifyPlan(ctx context.Context, task *domain.Task, plan *domain.Plan) error {
+	// Recovered tasks are already VERIFYING
+	if task.Status != domain.TaskStatusVerifying {
+		if err := s.setTaskStatus(ctx, task, domain.TaskStatusVerifying); err != nil {
+			return err
+		}
+	}
+
+	results, err := s.collectResults(ctx, plan)
+	if err != nil {
+		return err
+	}
+
+	verifyCtx, meter := metered(s.streaming(ctx, task, "", domain.UsagePhaseVerification))
+	verification, err := s.verifier.Verify(verifyCtx, task, plan, results)
+	s.chargeUsage(ctx, task, "", domain.UsagePhaseVerification, meter)
+	if isInterrupted(ctx) {
+		return context.Cause(ctx)
+	}
+	if err != nil {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, fmt.Sprintf("verification could not be performed: %v", err))
+	}
+	if verification == nil {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, "verifier returned no verdict")
+	}
+
+	s.recordVerification(ctx, task, plan, "", verification)
+	if !verification.Passed {
+		return s.replan(ctx, task, plan, verification)
+	}
+
+	return s.finishTask(ctx, task, domain.TaskStatusDone, "")
+}
+
+// replan asks the Planner for a revised plan after verification failed and executes it.
+// Purpose: Gives the agent a bounded number of chances (MaxReplans) to achieve the goal.
+//          Each revision is saved as a new plan linked to the one it replaces; steps of the
+//          replaced plan that never ran are marked SKIPPED.
+// Inputs:
+//   - ctx: Context for cancellation and timeout control
+//   - task: The task being executed (PlanID is moved to the revision)
+//   - plan: The plan that failed verification
+//   - verification: The failed verdict passed to the Planner
+// Outputs:
+//   - error: Returns error if task or plan state could not be persisted,
+//            or the interruption cause if CancelTask or the deadline stopped it
+func (s *OrchestratorService) replan(ctx context.Context, task *domain.Task, plan *domain.Plan, verification *domain.Verification) error {
+	if plan.Revision >= s.cfg.MaxReplans {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, fmt.Sprintf("%s (after %d replans)", verificationFailure(verification), plan.Revision))
+	}
+
+	skipped := make([]string, 0)
+	for i := range plan.Steps {
+		step := &plan.Steps[i]
+		if step.Status != domain.StepStatusPending {
+			continue
+		}
+		if err := s.setStepStatus(ctx, task, plan.ID, step, domain.StepStatusSkipped); err != nil {
+			return err
+		}
+		skipped = append(skipped, step.ID)
+	}
+
+	if err := s.setTaskStatus(ctx, task, domain.TaskStatusPlanning); err != nil {
+		return err
+	}
+
+	agent, err := s.resolveAgent(task.TargetAgent)
+	if err != nil {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, fmt.Sprintf("agent unavailable: %v", err))
+	}
+
+	reviseCtx, meter := metered(s.streaming(execctx.WithAgentProfile(ctx, agent.Profile()), task, "", domain.UsagePhaseReplanning))
+	revised, err := agent.Planner().RevisePlan(reviseCtx, task, plan, verification, s.agentTools(agent))
+	s.chargeUsage(ctx, task, "", domain.UsagePhaseReplanning, meter)
+	if isInterrupted(ctx) {
+		return context.Cause(ctx)
+	}
+	if err != nil {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, fmt.Sprintf("replanning failed: %v", err))
+	}
+	if revised == nil || len(revised.Steps) == 0 {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, "planner returned an empty revised plan")
+	}
+	if err := revised.Validate(); err != nil {
+		return s.finishTask(ctx, task, domain.TaskStatusFailed, fmt.Sprintf("planner returned an invalid revised plan: %v", err))
+	}
+
+	if revised.ID == "" || revised.ID == plan.ID {
+		revised.ID = s.idGen.Generate()
+	}
+	revised.TaskID = task.ID
+	revised.Revision = plan.Revision + 1
+	revised.PreviousPlanID = plan.ID
+	revised.RevisionReason = verificationFailure(verification)
+	if revised.Goal == "" {
+		revised.Goal = plan.Goal
+	}
+
+	if err := s.plans.SavePlan(ctx, revised); err != nil {
+		return fmt.Errorf("failed to save revised plan: %w", err)
+	}
+
+	task.PlanID = revised.ID
+	s.recordEvent(ctx, task, "PLAN_REVISED", systemActor, map[string]interface{}{
+		"plan_id":          revised.ID,
+		"previous_plan_id": plan.ID,
+		"revision":         revised.Revision,
+		"max_replans":      s.cfg.MaxReplans,
+		"reason":           verification.Reason,
+		"issues":           verification.Issues,
+		"step_count":       len(revised.Steps),
+		"skipped_steps":    skipped,
+	})
+
+	return s.executePlan(ctx, task, revised)
+}
+
+// recordVerification emits a VERIFICATION_COMPLETED audit event.
+// stepID is empty for the final verification phase.
+func (s *OrchestratorService) recordVerification(ctx context.Context, task *domain.Task, plan *domain.Plan, stepID string, verification *domain.Verification) {
+	s.recordEvent(ctx, task, "VERIFICATION_COMPLETED", systemActor, map[string]interface{}{
+		"plan_id":  plan.ID,
+		"revision": plan.Revision,
+		"step_id":  stepID,
+		"passed":   verification.Passed,
+		"reason":   verification.Reason,
+		"issues":   verification.Issues,
+	})
+}
+
+// verificationFailure formats a failed verdict as a failure reason.
+func verificationFailure(verification *domain.Verification) string {
+	if len(verification.Issues) == 0 {
+		return fmt.Sprintf("verification failed: %s", verification.Reason)
+	}
+	return fmt.Sprintf("verification failed: %s (%s)", verification.Reason, strings.Join(verification.Issues, "; "))
+}
@@ -0,0 +1,171 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// scriptedVerifier returns the scripted verdicts in order, then the fallback verdict.
+type scriptedVerifier struct {
+	mu       sync.Mutex
+	verdicts []bool
+	fallback bool
+	calls    int
+}
+
+func (v *scriptedVerifier) Verify(ctx context.Context, task *domain.Task, plan *domain.Plan, results []*domain.StepResult) (*domain.Verification, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.calls++
+	passed := v.fallback
+	if len(v.verdicts) > 0 {
+		passed, v.verdicts = v.verdicts[0], v.verdicts[1:]
+	}
+	if passed {
+		return &domain.Verification{Passed: true, Reason: "goal met"}, nil
+	}
+	return &domain.Verification{Passed: false, Reason: "goal unmet", Issues: []string{"missing summary"}}, nil
+}
+
+// withReplanning verifies with verifier and revises failed plans with the given revisions.
+func withReplanning(verifier *scriptedVerifier, maxReplans int, revisions ...[]domain.Step) harnessOption {
+	return func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		planner := deps.Planner.(fixedPlanner)
+		planner.revisions = revisions
+		deps.Planner = planner
+		deps.Verifier = verifier
+		cfg.MaxReplans = maxReplans
+	}
+}
+
+func TestOrchestratorReplansAfterFailedVerification(t *testing.T) {
+	think := func(id string, dependsOn ...string) domain.Step {
+		return domain.Step{ID: id, Type: domain.StepTypeThink, Status: domain.StepStatusPending, DependsOn: dependsOn}
+	}
+	revision1 := []domain.Step{think("retry-1")}
+	revision2 := []domain.Step{think("retry-2")}
+
+	tests := []struct {
+		name        string
+		steps       []domain.Step
+		verdicts    []bool
+		maxReplans  int
+		revisions   [][]domain.Step
+		wantStatus  domain.TaskStatus
+		wantPlans   int
+		wantReason  string
+		wantSkipped []string // Steps of the first plan that never ran
+	}{
+		{
+			name:       "final verification fails once",
+			steps:      []domain.Step{think("a")},
+			verdicts:   []bool{false, true},
+			maxReplans: 2,
+			revisions:  [][]domain.Step{revision1, revision2},
+			wantStatus: domain.TaskStatusDone,
+			wantPlans:  2,
+		},
+		{
+			name: "failed VERIFY step skips the rest of the plan",
+			steps: []domain.Step{
+				think("a"),
+				{ID: "check", Type: domain.StepTypeVerify, Status: domain.StepStatusPending, DependsOn: []string{"a"}},
+				think("c", "check"),
+			},
+			verdicts:    []bool{false, true},
+			maxReplans:  2,
+			revisions:   [][]domain.Step{revision1, revision2},
+			wantStatus:  domain.TaskStatusDone,
+			wantPlans:   2,
+			wantSkipped: []string{"c"},
+		},
+		{
+			name:       "max replans reached",
+			steps:      []domain.Step{think("a")},
+			maxReplans: 2,
+			revisions:  [][]domain.Step{revision1, revision2},
+			wantStatus: domain.TaskStatusFailed,
+			wantPlans:  3,
+			wantReason: "verification failed: goal unmet (missing summary) (after 2 replans)",
+		},
+		{
+			name:       "replanning disabled",
+			steps:      []domain.Step{think("a")},
+			maxReplans: 0,
+			wantStatus: domain.TaskStatusFailed,
+			wantPlans:  1,
+			wantReason: "verification failed: goal unmet (missing summary) (after 0 replans)",
+		},
+		{
+			name:       "planner cannot revise",
+			steps:      []domain.Step{think("a")},
+			maxReplans: 2,
+			wantStatus: domain.TaskStatusFailed,
+			wantPlans:  1,
+			wantReason: "replanning failed: no revision scripted for revision 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &scriptedVerifier{verdicts: tt.verdicts}
+			h := newHarness(t, tt.steps, newScriptedExecutor(), withReplanning(verifier, tt.maxReplans, tt.revisions...))
+
+			task, err := h.orchestrator.StartTask(context.Background(), "summarize the report", "alice", domain.TaskOptions{})
+			if err != nil {
+				t.Fatalf("StartTask = %v", err)
+			}
+			done := h.waitForStatus(t, task.ID, tt.wantStatus)
+			if reason := done.Metadata["failure_reason"]; reason != tt.wantReason {
+				t.Errorf("failure_reason = %q, want %q", reason, tt.wantReason)
+			}
+
+			plans, err := h.orchestrator.GetTaskPlans(context.Background(), task.ID)
+			if err != nil {
+				t.Fatalf("GetTaskPlans = %v", err)
+			}
+			if len(plans) != tt.wantPlans {
+				t.Fatalf("GetTaskPlans returned %d plans, want %d", len(plans), tt.wantPlans)
+			}
+			for i, plan := range plans {
+				if plan.Revision != i {
+					t.Errorf("plans[%d].Revision = %d, want %d", i, plan.Revision, i)
+				}
+				if i == 0 {
+					if plan.PreviousPlanID != "" || plan.RevisionReason != "" {
+						t.Errorf("first plan links to %q (%q), want no previous plan", plan.PreviousPlanID, plan.RevisionReason)
+					}
+					continue
+				}
+				if plan.PreviousPlanID != plans[i-1].ID {
+					t.Errorf("plans[%d].PreviousPlanID = %q, want %q", i, plan.PreviousPlanID, plans[i-1].ID)
+				}
+				if !strings.HasPrefix(plan.RevisionReason, "verification failed: goal unmet") {
+					t.Errorf("plans[%d].RevisionReason = %q, want the failed verdict", i, plan.RevisionReason)
+				}
+			}
+			if done.PlanID != plans[len(plans)-1].ID {
+				t.Errorf("task PlanID = %s, want the latest revision %s", done.PlanID, plans[len(plans)-1].ID)
+			}
+			if n := h.audit.count("PLAN_REVISED"); n != tt.wantPlans-1 {
+				t.Errorf("PLAN_REVISED events = %d, want %d", n, tt.wantPlans-1)
+			}
+
+			for _, stepID := range tt.wantSkipped {
+				for _, step := range plans[0].Steps {
+					if step.ID == stepID && step.Status != domain.StepStatusSkipped {
+						t.Errorf("step %s of the replaced plan is %s, want SKIPPED", stepID, step.Status)
+					}
+				}
+				if n := h.executor.callCount(stepID); n != 0 {
+					t.Errorf("step %s ran %d times after verification failed", stepID, n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+)
+
+// errShutdown is the cancellation cause of executions interrupted because the worker pool stopped.
+var errShutdown = errors.New("worker pool shut down")
+
+// isShutdown reports whether ctx was interrupted by a worker pool shutdown.
+func isShutdown(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errShutdown)
+}
+
+// WorkerPool runs queued tasks in the background with bounded concurrency.
+// Each worker takes the next task ID from the TaskQueue and hands it to Orchestrator.RunTask,
+// so task throughput is independent of the HTTP connections that submitted the tasks.
+type WorkerPool struct {
+	queue        ports.TaskQueue
+	orchestrator ports.Orchestrator
+	logger       ports.Logger
+	size         int
+	wg           sync.WaitGroup
+}
+
+// NewWorkerPool creates a worker pool that drains the task queue.
+// Purpose: Factory function wiring the queue to the orchestrator.
+// Inputs:
+//   - queue: Implementation of the TaskQueue port to consume
+//   - orchestrator: Orchestrator whose RunTask executes each dequeued task
+//   - logger: Implementation of the Logger port for worker errors
+//   - size: Number of concurrent workers (values below 1 are treated as 1)
+// Outputs:
+//   - *WorkerPool: Pool ready to be started
+func NewWorkerPool(queue ports.TaskQueue, orchestrator ports.Orchestrator, logger ports.Logger, size int) *WorkerPool {
+	if size < 1 {
+		size = 1
+	}
+	return &WorkerPool{
+		queue:        queue,
+		orchestrator: orchestrator,
+		logger:       logger,
+		size:         size,
+	}
+}
+
+// Start launches the workers in the background.
+// Purpose: Begins consuming the queue; workers stop once ctx is done. Tasks still running
+//          then are interrupted without writing state, so they stay unfinished for
+//          RecoverTasks instead of failing with context.Canceled.
+// Inputs:
+//   - ctx: Lifetime of the pool (cancel it to shut the workers down)
+// Outputs: None
+func (p *WorkerPool) Start(ctx context.Context) {
+	for i := 0; i < p.size; i++ {
+		p.wg.Add(1)
+		go p.work(ctx, i)
+	}
+
+	p.logger.Info("worker pool started", map[string]interface{}{
+		"workers": p.size,
+	})
+}
+
+// Wait blocks until every worker has stopped.
+// Purpose: Lets the caller finish a graceful shutdown after canceling the pool context.
+// Inputs: None
+// Outputs: None
+func (p *WorkerPool) Wait() {
+	p.wg.Wait()
+}
+
+// work is the loop of a single worker.
+func (p *WorkerPool) work(ctx context.Context, worker int) {
+	defer p.wg.Done()
+
+	for {
+		taskID, err := p.queue.Dequeue(ctx)
+		if err != nil {
+			return
+		}
+		// Dequeue prefers waiting tasks over a done context; once shut down, a task taken
+		// stays NEW for RecoverTasks instead of starting on an interrupted context
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := p.run(ctx, taskID); err != nil {
+			p.logger.Error("failed to run queued task", err, map[string]interface{}{
+				"task_id": taskID,
+				"worker":  worker,
+			})
+		}
+	}
+}
+
+// run executes a task on a context that a shutdown cancels with errShutdown.
+// A panic while running the task is returned as an error so the worker keeps serving the
+// queue; the task is left in its last persisted status for RecoverTasks.
+func (p *WorkerPool) run(ctx context.Context, taskID string) (err error) {
+	execCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() { cancel(errShutdown) })
+	defer func() {
+		stop()
+		cancel(nil)
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while running task: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return p.orchestrator.RunTask(execCtx, taskID)
+}
@@ -0,0 +1,269 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JAROBOTAI/jaro/internal/adapters/memory"
+	"github.com/JAROBOTAI/jaro/internal/core/domain"
+	"github.com/JAROBOTAI/jaro/internal/core/ports"
+	"github.com/JAROBOTAI/jaro/internal/core/services"
+)
+
+// stubOrchestrator runs dequeued tasks with run; the pool calls nothing else.
+type stubOrchestrator struct {
+	ports.Orchestrator
+	run func(ctx context.Context, taskID string) error
+}
+
+func (o stubOrchestrator) RunTask(ctx context.Context, taskID string) error {
+	return o.run(ctx, taskID)
+}
+
+// errorLogger keeps the errors logged by the pool.
+type errorLogger struct {
+	nopLogger
+	mu   sync.Mutex
+	errs []error
+}
+
+func (l *errorLogger) Error(msg string, err error, fields map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errs = append(l.errs, err)
+}
+
+func (l *errorLogger) errors() []error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]error(nil), l.errs...)
+}
+
+// startPool runs a pool on the queue until the test ends.
+func startPool(t *testing.T, queue ports.TaskQueue, orchestrator ports.Orchestrator, logger ports.Logger, size int) {
+	t.Helper()
+	pool := services.NewWorkerPool(queue, orchestrator, logger, size)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool.Start(ctx)
+	t.Cleanup(func() {
+		cancel()
+		pool.Wait()
+	})
+}
+
+// enqueueTasks queues the tasks with the given IDs.
+func enqueueTasks(t *testing.T, queue ports.TaskQueue, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		if err := queue.Enqueue(context.Background(), &domain.Task{ID: id, UserID: "alice", Priority: domain.TaskPriorityNormal}); err != nil {
+			t.Fatalf("Enqueue(%s) = %v", id, err)
+		}
+	}
+}
+
+// receiveID reads the next task ID or fails the test.
+func receiveID(t *testing.T, ids <-chan string) string {
+	t.Helper()
+	select {
+	case id := <-ids:
+		return id
+	case <-time.After(testTimeout):
+		t.Fatal("no task was run")
+		return ""
+	}
+}
+
+func TestWorkerPoolBoundsConcurrency(t *testing.T) {
+	const workers, tasks = 3, 10
+	queue := memory.NewTaskQueue(100, 0, newFakeClock())
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	started := make(chan string, tasks)
+	release := make(chan struct{})
+	startPool(t, queue, stubOrchestrator{run: func(ctx context.Context, taskID string) error {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+		started <- taskID
+		<-release
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	}}, nopLogger{}, workers)
+
+	ids := make([]string, 0, tasks)
+	for i := 1; i <= tasks; i++ {
+		ids = append(ids, fmt.Sprintf("task-%d", i))
+	}
+	enqueueTasks(t, queue, ids...)
+
+	// Every worker is busy: the remaining tasks wait in the queue
+	for i := 0; i < workers; i++ {
+		receiveID(t, started)
+	}
+	if n := queue.Len(); n != tasks-workers {
+		t.Errorf("queued tasks = %d, want %d", n, tasks-workers)
+	}
+	select {
+	case id := <-started:
+		t.Fatalf("task %s started while every worker was busy", id)
+	default:
+	}
+
+	close(release)
+	for i := workers; i < tasks; i++ {
+		receiveID(t, started)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if peak != workers {
+		t.Errorf("peak concurrency = %d, want %d", peak, workers)
+	}
+}
+
+func TestWorkerPoolSurvivesPanicsAndErrors(t *testing.T) {
+	queue := memory.NewTaskQueue(100, 0, newFakeClock())
+	logger := &errorLogger{}
+	done := make(chan string, 3)
+	// A single worker must run all three tasks
+	startPool(t, queue, stubOrchestrator{run: func(ctx context.Context, taskID string) error {
+		done <- taskID
+		switch taskID {
+		case "panics":
+			panic("executor bug")
+		case "fails":
+			return errors.New("repository unavailable")
+		}
+		return nil
+	}}, logger, 1)
+
+	enqueueTasks(t, queue, "panics", "fails", "works")
+	for _, want := range []string{"panics", "fails", "works"} {
+		if got := receiveID(t, done); got != want {
+			t.Fatalf("ran %s, want %s", got, want)
+		}
+	}
+
+	// The worker logs both failures before it takes the next task
+	errs := logger.errors()
+	if len(errs) != 2 {
+		t.Fatalf("logged errors = %v, want 2", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "panic while running task: executor bug") {
+		t.Errorf("first error = %q, want the recovered panic", errs[0])
+	}
+	if errs[1].Error() != "repository unavailable" {
+		t.Errorf("second error = %q, want the returned error", errs[1])
+	}
+}
+
+// panickingExecutor panics on one step and runs the others with the scripted executor.
+type panickingExecutor struct {
+	*scriptedExecutor
+	step string
+}
+
+func (e panickingExecutor) ExecuteStep(ctx context.Context, task *domain.Task, step *domain.Step) (*domain.StepResult, error) {
+	if step.ID == e.step {
+		panic("executor bug in step " + step.ID)
+	}
+	return e.scriptedExecutor.ExecuteStep(ctx, task, step)
+}
+
+func TestOrchestratorFailsStepWhenExecutorPanics(t *testing.T) {
+	h := newHarness(t, thinkStep("a"), newScriptedExecutor(), withSubTaskPlans(map[string][]domain.Step{
+		"crash": thinkStep("boom"),
+	}), func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		deps.Executor = panickingExecutor{scriptedExecutor: deps.Executor.(*scriptedExecutor), step: "boom"}
+	})
+	ctx := context.Background()
+
+	crashed, err := h.orchestrator.StartTask(ctx, "crash", "alice", domain.TaskOptions{})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	h.waitForStatus(t, crashed.ID, domain.TaskStatusFailed)
+	result := h.stepResult(t, crashed.ID, "boom")
+	if !strings.Contains(result.ErrorMessage, "executor panicked: executor bug in step boom") {
+		t.Errorf("step boom error = %q, want the recovered panic", result.ErrorMessage)
+	}
+	if n := h.executor.callCount("boom"); n != 0 {
+		t.Errorf("step boom reached the executor %d times, want 0", n)
+	}
+
+	// The panic is not retried and the pool keeps running tasks
+	task, err := h.orchestrator.StartTask(ctx, "work", "alice", domain.TaskOptions{})
+	if err != nil {
+		t.Fatalf("StartTask = %v", err)
+	}
+	h.waitForStatus(t, task.ID, domain.TaskStatusDone)
+}
+
+func TestWorkerPoolShutdownLeavesTasksRecoverable(t *testing.T) {
+	steps := []domain.Step{
+		{ID: "a", Type: domain.StepTypeThink, Status: domain.StepStatusPending},
+		{ID: "b", Type: domain.StepTypeThink, Status: domain.StepStatusPending, DependsOn: []string{"a"}},
+	}
+	executor := newScriptedExecutor()
+	executor.block["a"] = true
+	h := newHarness(t, steps, executor)
+	ctx := context.Background()
+
+	ids := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		task, err := h.orchestrator.StartTask(ctx, "work", "alice", domain.TaskOptions{})
+		if err != nil {
+			t.Fatalf("StartTask = %v", err)
+		}
+		ids = append(ids, task.ID)
+	}
+	// Two workers block in step a; the third task waits in the queue
+	h.waitForStart(t, "a")
+	h.waitForStart(t, "a")
+
+	h.stopWorkers()
+	statuses := make(map[domain.TaskStatus]int)
+	for _, id := range ids {
+		task, err := h.orchestrator.GetTaskStatus(ctx, id)
+		if err != nil {
+			t.Fatalf("GetTaskStatus = %v", err)
+		}
+		statuses[task.Status]++
+	}
+	if statuses[domain.TaskStatusExecuting] != 2 || statuses[domain.TaskStatusNew] != 1 {
+		t.Fatalf("statuses after shutdown = %v, want 2 EXECUTING and 1 NEW", statuses)
+	}
+	if n := h.audit.count("TASK_FAILED") + h.audit.count("TASK_CANCELED"); n != 0 {
+		t.Errorf("shutdown finished %d tasks, want none", n)
+	}
+
+	// A restarted process recovers and finishes every task
+	restarted := newScriptedExecutor()
+	next := newHarness(t, steps, restarted, func(deps *services.OrchestratorDeps, cfg *services.OrchestratorConfig) {
+		deps.Repo = h.deps.Repo
+		deps.Plans = h.deps.Plans
+		deps.Approvals = h.deps.Approvals
+		deps.IDGen = h.deps.IDGen
+	})
+	recovered, err := next.orchestrator.RecoverTasks(ctx)
+	if err != nil {
+		t.Fatalf("RecoverTasks = %v", err)
+	}
+	if len(recovered) != len(ids) {
+		t.Fatalf("recovered %d tasks, want %d", len(recovered), len(ids))
+	}
+	for _, id := range ids {
+		next.waitForStatus(t, id, domain.TaskStatusDone)
+	}
+	if a, b := restarted.callCount("a"), restarted.callCount("b"); a != 3 || b != 3 {
+		t.Errorf("steps after restart ran (a %d, b %d) times, want 3 each", a, b)
+	}
+}